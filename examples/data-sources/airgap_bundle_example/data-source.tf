@@ -0,0 +1,21 @@
+resource "replicated_customer" "airgap" {
+  app_id            = "my-app"
+  name              = "airgap-test"
+  channel_id        = "2fvVIbMQtNBwMzeTJt2yJrEKEFN"
+  is_airgap_enabled = true
+}
+
+# builds the airgap bundle of the release currently promoted to the channel,
+# starting the build as soon as terraform plan reads the data source
+data "replicated_airgap_bundle" "current" {
+  app_id        = "my-app"
+  channel_id    = "2fvVIbMQtNBwMzeTJt2yJrEKEFN"
+  customer_id   = replicated_customer.airgap.id
+  build         = true
+  wait_duration = "45m"
+}
+
+output "airgap_bundle_url" {
+  value     = data.replicated_airgap_bundle.current.download_url
+  sensitive = true
+}
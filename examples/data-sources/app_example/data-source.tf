@@ -0,0 +1,7 @@
+data "replicated_app" "tf_app" {
+  slug = "my-app"
+}
+
+output "app_id" {
+  value = data.replicated_app.tf_app.id
+}
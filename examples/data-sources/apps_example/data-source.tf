@@ -0,0 +1,7 @@
+data "replicated_apps" "staging" {
+  name_regex = "(?i)staging"
+}
+
+output "staging_app_slugs" {
+  value = data.replicated_apps.staging.apps[*].slug
+}
@@ -0,0 +1,8 @@
+data "replicated_channel" "stable" {
+  app_id = "my-app"
+  name   = "Stable"
+}
+
+output "current_version" {
+  value = data.replicated_channel.stable.current_version
+}
@@ -0,0 +1,16 @@
+data "replicated_channel" "beta" {
+  app_id = "my-app"
+  name   = "Beta"
+}
+
+# the most recent 1.x release promoted to the Beta channel
+data "replicated_channel_releases" "beta" {
+  app_id     = "my-app"
+  channel_id = data.replicated_channel.beta.id
+  semver     = ">= 1.0.0, < 2.0.0"
+  latest     = true
+}
+
+output "beta_version" {
+  value = data.replicated_channel_releases.beta.releases[0].version_label
+}
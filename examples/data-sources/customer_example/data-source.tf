@@ -0,0 +1,9 @@
+data "replicated_customer" "tf_customer" {
+  app_id = "my-app"
+  email  = "customer@example.com"
+}
+
+output "customer_license_id" {
+  value     = data.replicated_customer.tf_customer.license_id
+  sensitive = true
+}
@@ -0,0 +1,8 @@
+data "replicated_customer_license" "tf_customer" {
+  customer_id = replicated_customer.tf_customer.id
+  output_path = "${path.module}/license.yaml"
+}
+
+output "license_id" {
+  value = data.replicated_customer_license.tf_customer.license_id
+}
@@ -0,0 +1,11 @@
+# customers on the stable channel whose license expires within 30 days
+data "replicated_customers" "expiring" {
+  app_id         = "my-app"
+  channel_id     = "2fvVIbMQtNBwMzeTJt2yJrEKEFN"
+  expires_after  = "+0d"
+  expires_before = "+30d"
+}
+
+output "expiring_customers" {
+  value = data.replicated_customers.expiring.customers[*].name
+}
@@ -0,0 +1,12 @@
+# lint errors and warnings fail the plan before the release is created
+data "replicated_release_lint" "manifests" {
+  yaml_dir           = "${path.module}/manifests"
+  warnings_as_errors = true
+}
+
+resource "replicated_release" "manifests" {
+  app_id   = "my-app"
+  yaml_dir = "${path.module}/manifests"
+
+  depends_on = [data.replicated_release_lint.manifests]
+}
@@ -0,0 +1,4 @@
+ephemeral "replicated_customer_license" "tf_customer" {
+  app_id      = "app_id"
+  customer_id = "customer_id"
+}
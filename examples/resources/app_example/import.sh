@@ -0,0 +1,2 @@
+# Apps can be imported by their id or slug
+terraform import replicated_app.tf_app <app_slug>
@@ -0,0 +1,7 @@
+resource "replicated_app" "tf_app" {
+  name = "My App"
+
+  lifecycle {
+    prevent_destroy = true
+  }
+}
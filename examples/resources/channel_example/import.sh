@@ -0,0 +1,2 @@
+# Channels are imported by the id or slug of their app and the channel id
+terraform import replicated_channel.beta <app_slug>/<channel_id>
@@ -0,0 +1,11 @@
+resource "replicated_channel" "beta" {
+  app_id          = "my-app"
+  name            = "Beta"
+  description     = "Releases under test by design partners"
+  semver_required = true
+
+  custom_hostnames = {
+    registry       = "registry.example.com"
+    replicated_app = "updates.example.com"
+  }
+}
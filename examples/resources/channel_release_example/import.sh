@@ -0,0 +1,3 @@
+# Promotions are imported by the id or slug of their app, the channel id and
+# the sequence of the promotion on the channel
+terraform import replicated_channel_release.beta <app_slug>/<channel_id>/<channel_sequence>
@@ -0,0 +1,15 @@
+resource "replicated_release" "app" {
+  app_id   = "my-app"
+  yaml_dir = "${path.module}/manifests"
+}
+
+resource "replicated_channel_release" "beta" {
+  app_id        = "my-app"
+  channel_id    = replicated_channel.beta.id
+  sequence      = replicated_release.app.sequence
+  version_label = "1.4.0-beta.1"
+  release_notes = file("${path.module}/CHANGELOG.md")
+
+  # demote the release from the channel when the promotion is destroyed
+  destroy_policy = "demote"
+}
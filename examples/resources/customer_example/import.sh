@@ -0,0 +1,8 @@
+# Customers can be imported by their canonical id
+terraform import replicated_customer.tf_customer app/<app_id>/customer/<customer_id>
+
+# by app slug and customer name
+terraform import replicated_customer.tf_customer <app_slug>/<customer_name>
+
+# or by customer id alone
+terraform import replicated_customer.tf_customer <customer_id>
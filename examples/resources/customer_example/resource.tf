@@ -1,3 +1,8 @@
+variable "customer_api_key" {
+  type      = string
+  sensitive = true
+}
+
 resource "replicated_customer" "tf_customer" {
   name       = "terraform_customer"
   app_id     = "app_id"
@@ -10,5 +15,34 @@ resource "replicated_customer" "tf_customer" {
 
   entitlement_values = {
     environment = "test"
+    seats       = 10
   }
+
+  secret_entitlement_values = {
+    api_key = var.customer_api_key
+  }
+}
+
+resource "replicated_customer" "tf_customer_multiple_channels" {
+  name   = "terraform_customer_multiple_channels"
+  app_id = "app_id"
+
+  # keep the customer when the resource is destroyed, and pick it up again
+  # if it was archived in the meantime
+  deletion_policy     = "retain"
+  unarchive_on_create = true
+
+  # expire one year after the customer is created
+  expires_at = "+365d"
+
+  channels = [
+    {
+      id         = "stable_channel_id"
+      is_default = true
+    },
+    {
+      id                      = "beta_channel_id"
+      pinned_release_sequence = 3
+    },
+  ]
 }
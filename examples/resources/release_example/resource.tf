@@ -0,0 +1,17 @@
+resource "replicated_release" "app" {
+  app_id   = "my-app"
+  yaml_dir = "${path.module}/manifests"
+}
+
+resource "replicated_release" "helm" {
+  app_id = "my-app"
+  files = [
+    "${path.module}/charts/my-chart-1.2.0.tgz",
+    "${path.module}/kots/my-chart.yaml",
+    "${path.module}/embedded-cluster.yaml",
+  ]
+}
+
+output "sequence" {
+  value = replicated_release.app.sequence
+}
@@ -0,0 +1,335 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/kotsclient"
+	"github.com/replicatedhq/replicated/pkg/platformclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AirgapBundleDataSource{}
+var _ datasource.DataSourceWithConfigure = &AirgapBundleDataSource{}
+
+func NewAirgapBundleDataSource() datasource.DataSource {
+	return &AirgapBundleDataSource{}
+}
+
+// AirgapBundleDataSource defines the data source implementation.
+type AirgapBundleDataSource struct {
+	client      VendorAPI
+	appResolver *appResolver
+}
+
+// AirgapBundleDataSourceModel describes the data source data model.
+type AirgapBundleDataSourceModel struct {
+	AppId             types.String `tfsdk:"app_id"`
+	ChannelId         types.String `tfsdk:"channel_id"`
+	CustomerId        types.String `tfsdk:"customer_id"`
+	ChannelSequence   types.Int64  `tfsdk:"channel_sequence"`
+	Build             types.Bool   `tfsdk:"build"`
+	WaitDuration      types.String `tfsdk:"wait_duration"`
+	Sequence          types.Int64  `tfsdk:"sequence"`
+	VersionLabel      types.String `tfsdk:"version_label"`
+	AirgapBuildStatus types.String `tfsdk:"airgap_build_status"`
+	DownloadURL       types.String `tfsdk:"download_url"`
+}
+
+// Statuses of an airgap bundle build, any other status means the build is in
+// progress.
+const (
+	airgapBuildStatusNone   = ""
+	airgapBuildStatusBuilt  = "built"
+	airgapBuildStatusFailed = "failed"
+)
+
+// defaultAirgapBuildWait is how long to wait for an airgap build when
+// wait_duration is not set.
+const defaultAirgapBuildWait = 30 * time.Minute
+
+// airgapBuildPollInterval is the time between two checks of the status of an
+// airgap build, tests lower it.
+var airgapBuildPollInterval = 10 * time.Second
+
+// errAirgapBuildTimeout is returned by waitForAirgapBuild when the build did
+// not complete in time.
+var errAirgapBuildTimeout = errors.New("timed out waiting for the airgap build")
+
+func (d *AirgapBundleDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_airgap_bundle"
+}
+
+func (d *AirgapBundleDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Waits for the airgap bundle of a release promoted to a channel to be built, and returns a signed url a customer can download it from. " +
+			"The customer must have airgap enabled and access to the channel",
+
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
+				MarkdownDescription: "ID or slug of the app",
+				Required:            true,
+			},
+			"channel_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the channel the release is promoted to",
+				Required:            true,
+			},
+			"customer_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the customer the download url is signed for, or ID of a `replicated_customer` resource",
+				Required:            true,
+			},
+			"channel_sequence": schema.Int64Attribute{
+				MarkdownDescription: "Channel sequence of the promotion, defaults to the release currently promoted to the channel",
+				Optional:            true,
+				Computed:            true,
+			},
+			"build": schema.BoolAttribute{
+				MarkdownDescription: "Start a build when the release has no airgap bundle or its last build failed (defaults to false). " +
+					"Data sources are read during `terraform plan`, so the build starts on plan, not only on apply. " +
+					"Not needed on channels that build airgap bundles automatically",
+				Optional: true,
+			},
+			"wait_duration": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for the airgap bundle to be built, a positive duration such as `45m` (defaults to 30m)",
+				Optional:            true,
+			},
+			"sequence": schema.Int64Attribute{
+				MarkdownDescription: "Sequence of the release",
+				Computed:            true,
+			},
+			"version_label": schema.StringAttribute{
+				MarkdownDescription: "Version label of the release in the channel",
+				Computed:            true,
+			},
+			"airgap_build_status": schema.StringAttribute{
+				MarkdownDescription: "Status of the airgap bundle build, always `built`",
+				Computed:            true,
+			},
+			"download_url": schema.StringAttribute{
+				MarkdownDescription: "Signed url to download the airgap bundle with the license of the customer",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (d *AirgapBundleDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*ReplicatedProviderClients)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ReplicatedProviderClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = clients.vendorAPI
+	d.appResolver = clients.appResolver
+}
+
+func (d *AirgapBundleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AirgapBundleDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	waitDuration := defaultAirgapBuildWait
+	if data.WaitDuration.ValueString() != "" {
+		d, err := time.ParseDuration(data.WaitDuration.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("wait_duration"), "Invalid Wait Duration", fmt.Sprintf("Unable to parse wait duration, got error: %s", err))
+			return
+		}
+		if d <= 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("wait_duration"), "Invalid Wait Duration", fmt.Sprintf("The wait duration must be positive, got: %s", data.WaitDuration.ValueString()))
+			return
+		}
+		waitDuration = d
+	}
+
+	// accept the id of a replicated_customer resource as well
+	customerID := data.CustomerId.ValueString()
+	if strings.HasPrefix(customerID, "app/") {
+		_, resourceCustomerID, diags := parseCustomerResourceID(customerID)
+		if diags.HasError() {
+			resp.Diagnostics.AddAttributeError(path.Root("customer_id"), "Invalid Customer ID", fmt.Sprintf("Expected a customer id or a customer resource id, got: %q", customerID))
+			return
+		}
+		customerID = resourceCustomerID
+	}
+
+	appID, err := d.appResolver.resolveAppID(data.AppId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("app_id"), "Invalid App", fmt.Sprintf("Unable to resolve app, got error: %s", err))
+		return
+	}
+	channelID := data.ChannelId.ValueString()
+
+	customer, err := d.client.GetCustomerByNameOrId(appID, customerID)
+	if err != nil {
+		if errors.As(err, &kotsclient.ErrCustomerNotFound{}) {
+			resp.Diagnostics.AddAttributeError(path.Root("customer_id"), "Customer Not Found", fmt.Sprintf("App %s has no active customer with id %q.", data.AppId.ValueString(), customerID))
+			return
+		}
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to get customer, got error: %s", err))
+		return
+	}
+	if !customer.IsAirgapEnabled {
+		resp.Diagnostics.AddAttributeError(path.Root("customer_id"), "Airgap Not Enabled", fmt.Sprintf("Customer %q does not have airgap enabled, set is_airgap_enabled on the customer.", customer.Name))
+		return
+	}
+
+	releases, err := d.client.ListChannelReleases(appID, channelID)
+	if err != nil {
+		if errors.Is(err, platformclient.ErrNotFound) {
+			resp.Diagnostics.AddAttributeError(path.Root("channel_id"), "Channel Not Found", fmt.Sprintf("App %s has no channel with id %q.", data.AppId.ValueString(), channelID))
+			return
+		}
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to list channel releases, got error: %s", err))
+		return
+	}
+
+	var release *AppChannelRelease
+	for i := range releases {
+		if releases[i].IsDemoted {
+			continue
+		}
+		if data.ChannelSequence.IsNull() {
+			if release == nil || releases[i].ChannelSequence > release.ChannelSequence {
+				release = &releases[i]
+			}
+		} else if releases[i].ChannelSequence == data.ChannelSequence.ValueInt64() {
+			release = &releases[i]
+		}
+	}
+	if release == nil {
+		if data.ChannelSequence.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("channel_id"), "Channel Release Not Found", fmt.Sprintf("No release is promoted to channel %q.", channelID))
+		} else {
+			resp.Diagnostics.AddAttributeError(path.Root("channel_sequence"), "Channel Release Not Found", fmt.Sprintf("Channel %q has no promoted release with channel sequence %d.", channelID, data.ChannelSequence.ValueInt64()))
+		}
+		return
+	}
+
+	rebuilding := false
+	if release.AirgapBuildStatus == airgapBuildStatusNone || release.AirgapBuildStatus == airgapBuildStatusFailed {
+		if !data.Build.ValueBool() {
+			if release.AirgapBuildStatus == airgapBuildStatusFailed {
+				resp.Diagnostics.AddError("Airgap Build Failed", fmt.Sprintf("The airgap build of channel sequence %d failed: %s. Set build to retry it.", release.ChannelSequence, release.AirgapBuildError))
+			} else {
+				resp.Diagnostics.AddAttributeError(path.Root("build"), "Airgap Bundle Not Built", fmt.Sprintf("Channel sequence %d has no airgap bundle, set build to start a build or build airgap bundles automatically on the channel.", release.ChannelSequence))
+			}
+			return
+		}
+
+		if err := d.client.BuildAirgapRelease(appID, channelID, release.ChannelSequence); err != nil {
+			resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to start airgap build, got error: %s", err))
+			return
+		}
+		tflog.Trace(ctx, "started an airgap build", map[string]interface{}{"channel_id": channelID, "channel_sequence": release.ChannelSequence})
+		rebuilding = release.AirgapBuildStatus == airgapBuildStatusFailed
+	}
+
+	channelSequence := release.ChannelSequence
+	release, err = waitForAirgapBuild(ctx, d.client, appID, channelID, channelSequence, waitDuration, rebuilding)
+	if err != nil {
+		if errors.Is(err, errAirgapBuildTimeout) {
+			resp.Diagnostics.AddAttributeError(path.Root("wait_duration"), "Airgap Build Timeout", fmt.Sprintf("The airgap bundle of channel sequence %d was not built after %s.", channelSequence, waitDuration))
+			return
+		}
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to get airgap build status, got error: %s", err))
+		return
+	}
+	if release.AirgapBuildStatus == airgapBuildStatusFailed {
+		resp.Diagnostics.AddError("Airgap Build Failed", fmt.Sprintf("The airgap build of channel sequence %d failed: %s.", release.ChannelSequence, release.AirgapBuildError))
+		return
+	}
+
+	downloadURL, err := d.client.GetAirgapDownloadURL(appID, customer.ID, channelID, release.ChannelSequence)
+	if err != nil {
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to get airgap download url, got error: %s", err))
+		return
+	}
+
+	data.ChannelSequence = types.Int64Value(release.ChannelSequence)
+	data.Sequence = types.Int64Value(release.Sequence)
+	data.VersionLabel = types.StringValue(release.Semver)
+	data.AirgapBuildStatus = types.StringValue(release.AirgapBuildStatus)
+	data.DownloadURL = types.StringValue(downloadURL)
+
+	tflog.Trace(ctx, "read an airgap bundle", map[string]interface{}{"channel_id": channelID, "channel_sequence": release.ChannelSequence})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// waitForAirgapBuild polls the promotion with the channel sequence until its
+// airgap build is built or failed, or returns errAirgapBuildTimeout after
+// duration. A build started over a failed one reports the old failure until
+// it is queued, so when rebuilding a failed status only counts once another
+// status was seen.
+func waitForAirgapBuild(ctx context.Context, client VendorAPI, appID string, channelID string, channelSequence int64, duration time.Duration, rebuilding bool) (*AppChannelRelease, error) {
+	deadline := time.Now().Add(duration)
+	for {
+		releases, err := client.ListChannelReleases(appID, channelID)
+		if err != nil {
+			return nil, errors.Wrap(err, "list channel releases")
+		}
+
+		var release *AppChannelRelease
+		for i := range releases {
+			if releases[i].ChannelSequence == channelSequence {
+				release = &releases[i]
+				break
+			}
+		}
+		if release == nil {
+			return nil, errors.Errorf("channel sequence %d not found", channelSequence)
+		}
+
+		switch release.AirgapBuildStatus {
+		case airgapBuildStatusBuilt:
+			return release, nil
+		case airgapBuildStatusFailed:
+			if !rebuilding {
+				return release, nil
+			}
+		default:
+			rebuilding = false
+		}
+
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			return nil, errAirgapBuildTimeout
+		}
+		if wait > airgapBuildPollInterval {
+			wait = airgapBuildPollInterval
+		}
+
+		tflog.Debug(ctx, "waiting for the airgap build", map[string]interface{}{"channel_sequence": channelSequence, "status": release.AirgapBuildStatus})
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
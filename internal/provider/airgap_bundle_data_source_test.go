@@ -0,0 +1,380 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/replicatedhq/replicated/pkg/kotsclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccAirgapBundleDataSource(t *testing.T) {
+	testAccVendorAPI(t)
+	rName := acctest.RandomWithPrefix(testAccResourcePrefix)
+	dir := testWriteFiles(t, t.TempDir(), map[string]string{"config.yaml": "kind: Config"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccChannelReleaseResourceConfig(rName, dir, "1.0.0") + fmt.Sprintf(`
+					resource "replicated_customer" "test" {
+						app_id            = %[1]q
+						name              = %[2]q
+						channel_id        = replicated_channel.test.id
+						is_airgap_enabled = true
+					}
+
+					data "replicated_airgap_bundle" "test" {
+						app_id           = %[1]q
+						channel_id       = replicated_channel.test.id
+						customer_id      = replicated_customer.test.id
+						channel_sequence = replicated_channel_release.test.channel_sequence
+						build            = true
+					}
+				`, testAccAppID, rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.replicated_airgap_bundle.test", "airgap_build_status", "built"),
+					resource.TestCheckResourceAttr("data.replicated_airgap_bundle.test", "version_label", "1.0.0"),
+					resource.TestCheckResourceAttrPair("data.replicated_airgap_bundle.test", "sequence", "replicated_release.test", "sequence"),
+					resource.TestCheckResourceAttrSet("data.replicated_airgap_bundle.test", "download_url"),
+				),
+			},
+		},
+	})
+}
+
+func TestAirgapBundleDataSourceRead(t *testing.T) {
+	pollInterval := airgapBuildPollInterval
+	airgapBuildPollInterval = time.Millisecond
+	t.Cleanup(func() { airgapBuildPollInterval = pollInterval })
+
+	tests := []struct {
+		name           string
+		config         func(m *AirgapBundleDataSourceModel, customerID string)
+		setup          func(api *fakeVendorAPI, channelID string)
+		wantSequence   int64
+		wantChannelSeq int64
+		wantErr        string
+	}{
+		{
+			name:           "built",
+			wantSequence:   2,
+			wantChannelSeq: 1,
+		},
+		{
+			name: "channel sequence",
+			config: func(m *AirgapBundleDataSourceModel, customerID string) {
+				m.ChannelSequence = types.Int64Value(0)
+			},
+			wantSequence:   1,
+			wantChannelSeq: 0,
+		},
+		{
+			name: "demoted release",
+			setup: func(api *fakeVendorAPI, channelID string) {
+				require.NoError(t, api.DemoteChannelRelease(testFakeAppID, channelID, 1))
+			},
+			wantSequence:   1,
+			wantChannelSeq: 0,
+		},
+		{
+			name: "customer resource id",
+			config: func(m *AirgapBundleDataSourceModel, customerID string) {
+				m.CustomerId = types.StringValue(formatCustomerResourceID(testFakeAppID, customerID))
+			},
+			wantSequence:   2,
+			wantChannelSeq: 1,
+		},
+		{
+			name: "building",
+			setup: func(api *fakeVendorAPI, channelID string) {
+				api.channelReleases[channelID][1].AirgapBuildStatus = "building"
+			},
+			wantSequence:   2,
+			wantChannelSeq: 1,
+		},
+		{
+			name: "build",
+			config: func(m *AirgapBundleDataSourceModel, customerID string) {
+				m.Build = types.BoolValue(true)
+			},
+			setup: func(api *fakeVendorAPI, channelID string) {
+				api.channelReleases[channelID][1].AirgapBuildStatus = ""
+			},
+			wantSequence:   2,
+			wantChannelSeq: 1,
+		},
+		{
+			name: "retry failed build",
+			config: func(m *AirgapBundleDataSourceModel, customerID string) {
+				m.Build = types.BoolValue(true)
+			},
+			setup: func(api *fakeVendorAPI, channelID string) {
+				api.channelReleases[channelID][1].AirgapBuildStatus = "failed"
+			},
+			wantSequence:   2,
+			wantChannelSeq: 1,
+		},
+		{
+			name: "retry failed build reported late",
+			config: func(m *AirgapBundleDataSourceModel, customerID string) {
+				m.Build = types.BoolValue(true)
+			},
+			setup: func(api *fakeVendorAPI, channelID string) {
+				api.channelReleases[channelID][1].AirgapBuildStatus = "failed"
+				api.airgapBuildQueuedListings = 1
+			},
+			wantSequence:   2,
+			wantChannelSeq: 1,
+		},
+		{
+			name: "retry fails again",
+			config: func(m *AirgapBundleDataSourceModel, customerID string) {
+				m.Build = types.BoolValue(true)
+			},
+			setup: func(api *fakeVendorAPI, channelID string) {
+				api.channelReleases[channelID][1].AirgapBuildStatus = "failed"
+				api.airgapBuildQueuedListings = 1
+				api.airgapBuildStatus = "failed"
+			},
+			wantErr: "Airgap Build Failed",
+		},
+		{
+			name: "retry never leaves failed",
+			config: func(m *AirgapBundleDataSourceModel, customerID string) {
+				m.Build = types.BoolValue(true)
+				m.WaitDuration = types.StringValue("20ms")
+			},
+			setup: func(api *fakeVendorAPI, channelID string) {
+				api.channelReleases[channelID][1].AirgapBuildStatus = "failed"
+				api.airgapBuildQueuedListings = 1000
+			},
+			wantErr: "Airgap Build Timeout",
+		},
+		{
+			name: "not built",
+			setup: func(api *fakeVendorAPI, channelID string) {
+				api.channelReleases[channelID][1].AirgapBuildStatus = ""
+			},
+			wantErr: "Airgap Bundle Not Built",
+		},
+		{
+			name: "failed build",
+			setup: func(api *fakeVendorAPI, channelID string) {
+				api.channelReleases[channelID][1].AirgapBuildStatus = "failed"
+			},
+			wantErr: "Airgap Build Failed",
+		},
+		{
+			name: "build fails",
+			config: func(m *AirgapBundleDataSourceModel, customerID string) {
+				m.Build = types.BoolValue(true)
+			},
+			setup: func(api *fakeVendorAPI, channelID string) {
+				api.channelReleases[channelID][1].AirgapBuildStatus = ""
+				api.airgapBuildStatus = "failed"
+			},
+			wantErr: "Airgap Build Failed",
+		},
+		{
+			name: "timeout",
+			config: func(m *AirgapBundleDataSourceModel, customerID string) {
+				m.WaitDuration = types.StringValue("20ms")
+			},
+			setup: func(api *fakeVendorAPI, channelID string) {
+				api.channelReleases[channelID][1].AirgapBuildStatus = "building"
+				api.airgapBuildStatus = "building"
+			},
+			wantErr: "Airgap Build Timeout",
+		},
+		{
+			name: "invalid wait duration",
+			config: func(m *AirgapBundleDataSourceModel, customerID string) {
+				m.WaitDuration = types.StringValue("soon")
+			},
+			wantErr: "Invalid Wait Duration",
+		},
+		{
+			name: "zero wait duration",
+			config: func(m *AirgapBundleDataSourceModel, customerID string) {
+				m.WaitDuration = types.StringValue("0s")
+			},
+			wantErr: "Invalid Wait Duration",
+		},
+		{
+			name: "negative wait duration",
+			config: func(m *AirgapBundleDataSourceModel, customerID string) {
+				m.WaitDuration = types.StringValue("-5m")
+			},
+			wantErr: "Invalid Wait Duration",
+		},
+		{
+			name: "unknown channel sequence",
+			config: func(m *AirgapBundleDataSourceModel, customerID string) {
+				m.ChannelSequence = types.Int64Value(5)
+			},
+			wantErr: "Channel Release Not Found",
+		},
+		{
+			name: "unknown channel",
+			config: func(m *AirgapBundleDataSourceModel, customerID string) {
+				m.ChannelId = types.StringValue("missing")
+			},
+			wantErr: "Channel Not Found",
+		},
+		{
+			name: "unknown customer",
+			config: func(m *AirgapBundleDataSourceModel, customerID string) {
+				m.CustomerId = types.StringValue("missing")
+			},
+			wantErr: "Customer Not Found",
+		},
+		{
+			name: "airgap not enabled",
+			setup: func(api *fakeVendorAPI, channelID string) {
+				for _, customer := range api.customers {
+					customer.IsAirgapEnabled = false
+				}
+			},
+			wantErr: "Airgap Not Enabled",
+		},
+		{
+			name: "build api error",
+			config: func(m *AirgapBundleDataSourceModel, customerID string) {
+				m.Build = types.BoolValue(true)
+			},
+			setup: func(api *fakeVendorAPI, channelID string) {
+				api.channelReleases[channelID][1].AirgapBuildStatus = ""
+				api.errs["BuildAirgapRelease"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+		{
+			name: "download url api error",
+			setup: func(api *fakeVendorAPI, channelID string) {
+				api.errs["GetAirgapDownloadURL"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			channel, err := api.CreateChannel(testFakeAppID, "Beta", "")
+			require.NoError(t, err)
+			for i := 0; i < 2; i++ {
+				_, err := api.CreateRelease(testFakeAppID, "[]")
+				require.NoError(t, err)
+			}
+			require.NoError(t, api.PromoteRelease(testFakeAppID, 1, "1.0.0", "", false, channel.ID))
+			require.NoError(t, api.PromoteRelease(testFakeAppID, 2, "1.1.0", "", false, channel.ID))
+			for _, release := range api.channelReleases[channel.ID] {
+				release.AirgapBuildStatus = "built"
+			}
+			customer, err := api.CreateCustomer(CreateCustomerOpts{
+				CreateCustomerOpts: kotsclient.CreateCustomerOpts{
+					Name:            "acme",
+					AppID:           testFakeAppID,
+					Channels:        []kotsclient.CustomerChannel{{ID: channel.ID, IsDefault: true}},
+					IsAirgapEnabled: true,
+				},
+			})
+			require.NoError(t, err)
+			if tt.setup != nil {
+				tt.setup(api, channel.ID)
+			}
+			d := NewAirgapBundleDataSource()
+			testConfiguredDataSource(t, d, api)
+			s := testDataSourceSchema(t, d)
+
+			config := AirgapBundleDataSourceModel{
+				AppId:             types.StringValue("test-app"),
+				ChannelId:         types.StringValue(channel.ID),
+				CustomerId:        types.StringValue(customer.ID),
+				ChannelSequence:   types.Int64Null(),
+				Build:             types.BoolNull(),
+				WaitDuration:      types.StringNull(),
+				Sequence:          types.Int64Null(),
+				VersionLabel:      types.StringNull(),
+				AirgapBuildStatus: types.StringNull(),
+				DownloadURL:       types.StringNull(),
+			}
+			if tt.config != nil {
+				tt.config(&config, customer.ID)
+			}
+			c, state := testDataSourceConfig(t, s, &config)
+			resp := datasource.ReadResponse{State: state}
+			d.Read(context.Background(), datasource.ReadRequest{Config: c}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var got AirgapBundleDataSourceModel
+			require.False(t, resp.State.Get(context.Background(), &got).HasError())
+			assert.Equal(t, tt.wantChannelSeq, got.ChannelSequence.ValueInt64())
+			assert.Equal(t, tt.wantSequence, got.Sequence.ValueInt64())
+			assert.Equal(t, fmt.Sprintf("1.%d.0", tt.wantChannelSeq), got.VersionLabel.ValueString())
+			assert.Equal(t, "built", got.AirgapBuildStatus.ValueString())
+			assert.Equal(t, fmt.Sprintf("https://airgap.example.com/%s/%s/%d?customer=%s", testFakeAppID, channel.ID, tt.wantChannelSeq, customer.ID), got.DownloadURL.ValueString())
+		})
+	}
+}
+
+func TestWaitForAirgapBuildShortWait(t *testing.T) {
+	// waits shorter than the poll interval still poll again at the deadline
+	pollInterval := airgapBuildPollInterval
+	airgapBuildPollInterval = time.Hour
+	t.Cleanup(func() { airgapBuildPollInterval = pollInterval })
+
+	tests := []struct {
+		name        string
+		finalStatus string
+		wantErr     error
+	}{
+		{
+			name:        "built at the deadline",
+			finalStatus: "built",
+		},
+		{
+			name:        "still building",
+			finalStatus: "building",
+			wantErr:     errAirgapBuildTimeout,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			channel, err := api.CreateChannel(testFakeAppID, "Beta", "")
+			require.NoError(t, err)
+			_, err = api.CreateRelease(testFakeAppID, "[]")
+			require.NoError(t, err)
+			require.NoError(t, api.PromoteRelease(testFakeAppID, 1, "1.0.0", "", false, channel.ID))
+			api.channelReleases[channel.ID][0].AirgapBuildStatus = "building"
+			api.airgapBuildStatus = tt.finalStatus
+
+			start := time.Now()
+			release, err := waitForAirgapBuild(context.Background(), api, testFakeAppID, channel.ID, 0, 20*time.Millisecond, false)
+			assert.Less(t, time.Since(start), time.Second)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "built", release.AirgapBuildStatus)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	rtypes "github.com/replicatedhq/replicated/pkg/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AppDataSource{}
+var _ datasource.DataSourceWithConfigure = &AppDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &AppDataSource{}
+
+func NewAppDataSource() datasource.DataSource {
+	return &AppDataSource{}
+}
+
+// AppDataSource defines the data source implementation.
+type AppDataSource struct {
+	client VendorAPI
+}
+
+// AppDataModel describes an app read by the app data sources.
+type AppDataModel struct {
+	Id   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+	Slug types.String `tfsdk:"slug"`
+}
+
+var appDataAttrTypes = map[string]attr.Type{
+	"id":   types.StringType,
+	"name": types.StringType,
+	"slug": types.StringType,
+}
+
+func appDataModel(app *rtypes.App) AppDataModel {
+	return AppDataModel{
+		Id:   types.StringValue(app.ID),
+		Name: types.StringValue(app.Name),
+		Slug: types.StringValue(app.Slug),
+	}
+}
+
+func (d *AppDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app"
+}
+
+func (d *AppDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up an app by its ID, slug or name",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the app to look up. Exactly one of `id`, `slug` and `name` must be set",
+				Optional:            true,
+				Computed:            true,
+			},
+			"slug": schema.StringAttribute{
+				MarkdownDescription: "Slug of the app to look up",
+				Optional:            true,
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the app to look up",
+				Optional:            true,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *AppDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("slug"),
+			path.MatchRoot("name"),
+		),
+	}
+}
+
+func (d *AppDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*ReplicatedProviderClients)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ReplicatedProviderClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = clients.vendorAPI
+}
+
+func (d *AppDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AppDataModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apps, err := d.client.ListApps(true)
+	if err != nil {
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to list apps, got error: %s", err))
+		return
+	}
+
+	var matches []*rtypes.App
+	for _, app := range apps {
+		if app.App == nil {
+			continue
+		}
+		switch {
+		case !data.Id.IsNull() && app.App.ID == data.Id.ValueString(),
+			!data.Slug.IsNull() && app.App.Slug == data.Slug.ValueString(),
+			!data.Name.IsNull() && app.App.Name == data.Name.ValueString():
+			matches = append(matches, app.App)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		resp.Diagnostics.AddError("App Not Found", "No app matches the given id, slug or name.")
+		return
+	case 1:
+	default:
+		resp.Diagnostics.AddAttributeError(path.Root("name"), "Ambiguous App", fmt.Sprintf("%d apps are named %q, look the app up by slug instead.", len(matches), data.Name.ValueString()))
+		return
+	}
+
+	data = appDataModel(matches[0])
+
+	tflog.Trace(ctx, "read an app")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppDataSourceRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  AppDataModel
+		setup   func(api *fakeVendorAPI)
+		wantErr string
+	}{
+		{
+			name:   "id",
+			config: AppDataModel{Id: types.StringValue("2fvVIbMQtNBwMzeTJt2yJrEKEFN"), Name: types.StringNull(), Slug: types.StringNull()},
+		},
+		{
+			name:   "slug",
+			config: AppDataModel{Id: types.StringNull(), Name: types.StringNull(), Slug: types.StringValue("test-app")},
+		},
+		{
+			name:   "name",
+			config: AppDataModel{Id: types.StringNull(), Name: types.StringValue("Test App"), Slug: types.StringNull()},
+		},
+		{
+			name:    "unknown slug",
+			config:  AppDataModel{Id: types.StringNull(), Name: types.StringNull(), Slug: types.StringValue("other-app")},
+			wantErr: "App Not Found",
+		},
+		{
+			name:   "ambiguous name",
+			config: AppDataModel{Id: types.StringNull(), Name: types.StringValue("Test App"), Slug: types.StringNull()},
+			setup: func(api *fakeVendorAPI) {
+				_, err := api.CreateKOTSApp("Test App")
+				require.NoError(t, err)
+			},
+			wantErr: "Ambiguous App",
+		},
+		{
+			name:   "api error",
+			config: AppDataModel{Id: types.StringNull(), Name: types.StringNull(), Slug: types.StringValue("test-app")},
+			setup: func(api *fakeVendorAPI) {
+				api.errs["ListApps"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			if tt.setup != nil {
+				tt.setup(api)
+			}
+			d := NewAppDataSource()
+			testConfiguredDataSource(t, d, api)
+			s := testDataSourceSchema(t, d)
+
+			c, state := testDataSourceConfig(t, s, &tt.config)
+			resp := datasource.ReadResponse{State: state}
+			d.Read(context.Background(), datasource.ReadRequest{Config: c}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var got AppDataModel
+			require.False(t, resp.State.Get(context.Background(), &got).HasError())
+			assert.Equal(t, AppDataModel{
+				Id:   types.StringValue("2fvVIbMQtNBwMzeTJt2yJrEKEFN"),
+				Name: types.StringValue("Test App"),
+				Slug: types.StringValue("test-app"),
+			}, got)
+		})
+	}
+}
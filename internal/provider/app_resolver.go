@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// appResolver translates app slugs into app ids. Lookups are cached for the
+// lifetime of the provider instance so that every resource sharing the
+// provider only lists the apps once.
+type appResolver struct {
+	client VendorAPI
+
+	mu  sync.Mutex
+	ids map[string]string
+}
+
+func newAppResolver(client VendorAPI) *appResolver {
+	return &appResolver{
+		client: client,
+		ids:    map[string]string{},
+	}
+}
+
+// resolveAppID returns the canonical id of the app identified by appIDOrSlug,
+// which may be either the app id or its slug.
+func (r *appResolver) resolveAppID(appIDOrSlug string) (string, error) {
+	if appIDOrSlug == "" {
+		return "", errors.New("app id or slug is required")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if id, ok := r.ids[appIDOrSlug]; ok {
+		return id, nil
+	}
+
+	// the app may have been created since the cache was last filled, so
+	// refresh it before giving up
+	if err := r.refresh(); err != nil {
+		return "", err
+	}
+
+	if id, ok := r.ids[appIDOrSlug]; ok {
+		return id, nil
+	}
+
+	return "", fmt.Errorf("app %q not found", appIDOrSlug)
+}
+
+// refresh reloads the cache from the vendor api. The caller must hold r.mu.
+func (r *appResolver) refresh() error {
+	apps, err := r.client.ListApps(true)
+	if err != nil {
+		return errors.Wrap(err, "list apps")
+	}
+
+	for _, app := range apps {
+		if app.App == nil {
+			continue
+		}
+		r.ids[app.App.ID] = app.App.ID
+		if app.App.Slug != "" {
+			r.ids[app.App.Slug] = app.App.ID
+		}
+	}
+
+	return nil
+}
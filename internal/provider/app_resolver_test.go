@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppResolverResolveAppID(t *testing.T) {
+	listCalls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		listCalls++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"apps":[{"id":"2fvVIbMQtNBwMzeTJt2yJrEKEFN","name":"My App","slug":"my-app"}]}`))
+	}))
+	defer server.Close()
+
+	client := newVendorAPIClient(server.URL, "token", server.Client())
+	resolver := newAppResolver(client)
+
+	tests := []struct {
+		name        string
+		appIDOrSlug string
+		wantID      string
+		wantErr     bool
+	}{
+		{
+			name:        "slug",
+			appIDOrSlug: "my-app",
+			wantID:      "2fvVIbMQtNBwMzeTJt2yJrEKEFN",
+		},
+		{
+			name:        "id",
+			appIDOrSlug: "2fvVIbMQtNBwMzeTJt2yJrEKEFN",
+			wantID:      "2fvVIbMQtNBwMzeTJt2yJrEKEFN",
+		},
+		{
+			name:        "unknown",
+			appIDOrSlug: "other-app",
+			wantErr:     true,
+		},
+		{
+			name:        "empty",
+			appIDOrSlug: "",
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotID, err := resolver.resolveAppID(tt.appIDOrSlug)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantID, gotID)
+		})
+	}
+
+	// the slug and the id are served from the cache, only the unknown app
+	// forces a second listing
+	assert.Equal(t, 2, listCalls)
+}
@@ -0,0 +1,223 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	rtypes "github.com/replicatedhq/replicated/pkg/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AppResource{}
+var _ resource.ResourceWithImportState = &AppResource{}
+
+func NewAppResource() resource.Resource {
+	return &AppResource{}
+}
+
+// AppResource defines the resource implementation.
+type AppResource struct {
+	client VendorAPI
+}
+
+// AppResourceModel describes the resource data model.
+type AppResourceModel struct {
+	Id                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	Slug               types.String `tfsdk:"slug"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+}
+
+func (r *AppResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_app"
+}
+
+func (r *AppResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "App resource. Destroying the resource deletes the app with its channels, releases and customers, which cannot be undone. " +
+			"Set `deletion_protection` to guard against it",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the app",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the app. The Vendor API cannot rename apps, so changing it replaces the app",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"slug": schema.StringAttribute{
+				MarkdownDescription: "Slug of the app, derived from the name by the Vendor API",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Fail to destroy or replace the app while set (default false)",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *AppResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*ReplicatedProviderClients)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ReplicatedProviderClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = clients.vendorAPI
+}
+
+func (r *AppResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AppResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	app, err := r.client.CreateKOTSApp(data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to create app, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(app.Id)
+	data.Name = types.StringValue(app.Name)
+	data.Slug = types.StringValue(app.Slug)
+
+	tflog.Trace(ctx, "created an app", map[string]interface{}{"app_id": app.Id})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AppResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AppResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	app, err := findApp(r.client, data.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to list apps, got error: %s", err))
+		return
+	}
+	if app == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Id = types.StringValue(app.ID)
+	data.Name = types.StringValue(app.Name)
+	data.Slug = types.StringValue(app.Slug)
+	// deletion_protection is null after an import, which leaves it at its
+	// default
+	if data.DeletionProtection.IsNull() {
+		data.DeletionProtection = types.BoolValue(false)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AppResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// every configurable attribute requires replacement
+	var data AppResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AppResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data AppResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Deletion Protection Enabled",
+			fmt.Sprintf("App %s has deletion_protection set, destroying it would delete its channels, releases and customers. Set deletion_protection to false and apply before destroying or replacing the app.", data.Slug.ValueString()),
+		)
+		return
+	}
+
+	if err := r.client.DeleteKOTSApp(data.Id.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to delete app, got error: %s", err))
+		return
+	}
+}
+
+// ImportState accepts the id or the slug of the app.
+func (r *AppResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	app, err := findApp(r.client, req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to list apps, got error: %s", err))
+		return
+	}
+	if app == nil {
+		resp.Diagnostics.AddError("Invalid App", fmt.Sprintf("No app with id or slug %q was found", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), app.ID)...)
+}
+
+// findApp returns the app with the given id or slug, or nil when there is no
+// such app.
+func findApp(client VendorAPI, idOrSlug string) (*rtypes.App, error) {
+	apps, err := client.ListApps(true)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, app := range apps {
+		if app.App == nil {
+			continue
+		}
+		if app.App.ID == idOrSlug || app.App.Slug == idOrSlug {
+			return app.App, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,307 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccAppResource(t *testing.T) {
+	testAccVendorAPI(t)
+	rName := acctest.RandomWithPrefix(testAccResourcePrefix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "replicated_app" "test" {
+						name = %q
+					}
+
+					data "replicated_app" "test" {
+						slug = replicated_app.test.slug
+					}
+				`, rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("replicated_app.test", "name", rName),
+					resource.TestCheckResourceAttrSet("replicated_app.test", "id"),
+					resource.TestCheckResourceAttrPair("data.replicated_app.test", "id", "replicated_app.test", "id"),
+				),
+			},
+			{
+				ResourceName:      "replicated_app.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAppResourceCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(api *fakeVendorAPI)
+		wantErr string
+	}{
+		{
+			name: "app",
+		},
+		{
+			name: "api error",
+			setup: func(api *fakeVendorAPI) {
+				api.errs["CreateKOTSApp"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			if tt.setup != nil {
+				tt.setup(api)
+			}
+			r := NewAppResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			plan := AppResourceModel{
+				Id:                 types.StringUnknown(),
+				Name:               types.StringValue("My App"),
+				Slug:               types.StringUnknown(),
+				DeletionProtection: types.BoolValue(false),
+			}
+			resp := fwresource.CreateResponse{State: testState(t, s, nil)}
+			r.Create(context.Background(), fwresource.CreateRequest{Plan: testPlan(t, s, &plan)}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var got AppResourceModel
+			require.False(t, resp.State.Get(context.Background(), &got).HasError())
+			assert.True(t, strings.HasPrefix(got.Id.ValueString(), "app-"))
+			assert.Equal(t, "My App", got.Name.ValueString())
+			assert.Equal(t, "my-app", got.Slug.ValueString())
+			assert.False(t, got.DeletionProtection.ValueBool())
+
+			app, err := findApp(api, got.Id.ValueString())
+			require.NoError(t, err)
+			assert.NotNil(t, app)
+		})
+	}
+}
+
+func TestAppResourceRead(t *testing.T) {
+	tests := []struct {
+		name        string
+		setup       func(api *fakeVendorAPI, m *AppResourceModel)
+		wantRemoved bool
+		wantErr     string
+	}{
+		{
+			name: "app",
+		},
+		{
+			name: "deleted app",
+			setup: func(api *fakeVendorAPI, m *AppResourceModel) {
+				require.NoError(t, api.DeleteKOTSApp(m.Id.ValueString()))
+			},
+			wantRemoved: true,
+		},
+		{
+			name: "imported app",
+			setup: func(api *fakeVendorAPI, m *AppResourceModel) {
+				m.Name = types.StringNull()
+				m.Slug = types.StringNull()
+				m.DeletionProtection = types.BoolNull()
+			},
+		},
+		{
+			name: "protected app",
+			setup: func(api *fakeVendorAPI, m *AppResourceModel) {
+				m.DeletionProtection = types.BoolValue(true)
+			},
+		},
+		{
+			name: "api error",
+			setup: func(api *fakeVendorAPI, m *AppResourceModel) {
+				api.errs["ListApps"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			app, err := api.CreateKOTSApp("My App")
+			require.NoError(t, err)
+			prior := AppResourceModel{
+				Id:                 types.StringValue(app.Id),
+				Name:               types.StringValue(app.Name),
+				Slug:               types.StringValue(app.Slug),
+				DeletionProtection: types.BoolValue(false),
+			}
+			if tt.setup != nil {
+				tt.setup(api, &prior)
+			}
+			r := NewAppResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			state := testState(t, s, &prior)
+			resp := fwresource.ReadResponse{State: state}
+			r.Read(context.Background(), fwresource.ReadRequest{State: state}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			if tt.wantRemoved {
+				assert.True(t, resp.State.Raw.IsNull())
+				return
+			}
+
+			var got AppResourceModel
+			require.False(t, resp.State.Get(context.Background(), &got).HasError())
+			assert.Equal(t, app.Id, got.Id.ValueString())
+			assert.Equal(t, "My App", got.Name.ValueString())
+			assert.Equal(t, "my-app", got.Slug.ValueString())
+			assert.Equal(t, prior.DeletionProtection.Equal(types.BoolValue(true)), got.DeletionProtection.ValueBool())
+		})
+	}
+}
+
+func TestAppResourceDelete(t *testing.T) {
+	tests := []struct {
+		name      string
+		protected bool
+		setup     func(api *fakeVendorAPI)
+		wantErr   string
+	}{
+		{
+			name: "app",
+		},
+		{
+			name:      "protected app",
+			protected: true,
+			wantErr:   "Deletion Protection Enabled",
+		},
+		{
+			name: "api error",
+			setup: func(api *fakeVendorAPI) {
+				api.errs["DeleteKOTSApp"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			app, err := api.CreateKOTSApp("My App")
+			require.NoError(t, err)
+			if tt.setup != nil {
+				tt.setup(api)
+			}
+			r := NewAppResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			state := testState(t, s, &AppResourceModel{
+				Id:                 types.StringValue(app.Id),
+				Name:               types.StringValue(app.Name),
+				Slug:               types.StringValue(app.Slug),
+				DeletionProtection: types.BoolValue(tt.protected),
+			})
+			resp := fwresource.DeleteResponse{State: state}
+			r.Delete(context.Background(), fwresource.DeleteRequest{State: state}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				got, err := findApp(api, app.Id)
+				require.NoError(t, err)
+				assert.NotNil(t, got)
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			got, err := findApp(api, app.Id)
+			require.NoError(t, err)
+			assert.Nil(t, got)
+		})
+	}
+}
+
+func TestAppResourceImportState(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		setup   func(api *fakeVendorAPI)
+		wantErr string
+	}{
+		{
+			name: "id",
+			id:   "2fvVIbMQtNBwMzeTJt2yJrEKEFN",
+		},
+		{
+			name: "slug",
+			id:   "test-app",
+		},
+		{
+			name:    "unknown app",
+			id:      "other-app",
+			wantErr: "Invalid App",
+		},
+		{
+			name: "api error",
+			id:   "test-app",
+			setup: func(api *fakeVendorAPI) {
+				api.errs["ListApps"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			if tt.setup != nil {
+				tt.setup(api)
+			}
+			r := NewAppResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			resp := fwresource.ImportStateResponse{State: testState(t, s, nil)}
+			r.(fwresource.ResourceWithImportState).ImportState(context.Background(), fwresource.ImportStateRequest{ID: tt.id}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var id string
+			require.False(t, resp.State.GetAttribute(context.Background(), path.Root("id"), &id).HasError())
+			assert.Equal(t, "2fvVIbMQtNBwMzeTJt2yJrEKEFN", id)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppsDataSourceRead(t *testing.T) {
+	tests := []struct {
+		name      string
+		nameRegex types.String
+		setup     func(api *fakeVendorAPI)
+		wantSlugs []string
+		wantErr   string
+	}{
+		{
+			name:      "all apps",
+			nameRegex: types.StringNull(),
+			wantSlugs: []string{"another-app", "test-app"},
+		},
+		{
+			name:      "name regex",
+			nameRegex: types.StringValue("^Test"),
+			wantSlugs: []string{"test-app"},
+		},
+		{
+			name:      "no match",
+			nameRegex: types.StringValue("^Missing"),
+			wantSlugs: []string{},
+		},
+		{
+			name:      "invalid regex",
+			nameRegex: types.StringValue("("),
+			wantErr:   "Invalid Regular Expression",
+		},
+		{
+			name:      "api error",
+			nameRegex: types.StringNull(),
+			setup: func(api *fakeVendorAPI) {
+				api.errs["ListApps"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			_, err := api.CreateKOTSApp("Another App")
+			require.NoError(t, err)
+			if tt.setup != nil {
+				tt.setup(api)
+			}
+			d := NewAppsDataSource()
+			testConfiguredDataSource(t, d, api)
+			s := testDataSourceSchema(t, d)
+
+			config := AppsDataSourceModel{
+				NameRegex: tt.nameRegex,
+				Apps:      types.ListUnknown(types.ObjectType{AttrTypes: appDataAttrTypes}),
+			}
+			c, state := testDataSourceConfig(t, s, &config)
+			resp := datasource.ReadResponse{State: state}
+			d.Read(context.Background(), datasource.ReadRequest{Config: c}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var got AppsDataSourceModel
+			require.False(t, resp.State.Get(context.Background(), &got).HasError())
+			var apps []AppDataModel
+			require.False(t, got.Apps.ElementsAs(context.Background(), &apps, false).HasError())
+			slugs := []string{}
+			for _, app := range apps {
+				slugs = append(slugs, app.Slug.ValueString())
+			}
+			assert.Equal(t, tt.wantSlugs, slugs)
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ChannelDataSource{}
+var _ datasource.DataSourceWithConfigure = &ChannelDataSource{}
+
+func NewChannelDataSource() datasource.DataSource {
+	return &ChannelDataSource{}
+}
+
+// ChannelDataSource defines the data source implementation.
+type ChannelDataSource struct {
+	client      VendorAPI
+	appResolver *appResolver
+}
+
+// ChannelDataSourceModel describes the data source data model.
+type ChannelDataSourceModel struct {
+	Id                       types.String `tfsdk:"id"`
+	AppId                    types.String `tfsdk:"app_id"`
+	Name                     types.String `tfsdk:"name"`
+	Description              types.String `tfsdk:"description"`
+	Slug                     types.String `tfsdk:"slug"`
+	SemverRequired           types.Bool   `tfsdk:"semver_required"`
+	IsDefault                types.Bool   `tfsdk:"is_default"`
+	BuildAirgapAutomatically types.Bool   `tfsdk:"build_airgap_automatically"`
+	CustomHostnames          types.Object `tfsdk:"custom_hostnames"`
+	ReleaseSequence          types.Int64  `tfsdk:"release_sequence"`
+	CurrentVersion           types.String `tfsdk:"current_version"`
+}
+
+func (d *ChannelDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_channel"
+}
+
+func (d *ChannelDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a release channel of an app by its name",
+
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
+				MarkdownDescription: "ID or slug of the app to which the channel belongs",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the channel",
+				Required:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the channel",
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the channel",
+				Computed:            true,
+			},
+			"slug": schema.StringAttribute{
+				MarkdownDescription: "Slug of the channel",
+				Computed:            true,
+			},
+			"semver_required": schema.BoolAttribute{
+				MarkdownDescription: "Do releases promoted to the channel require a semantic version label",
+				Computed:            true,
+			},
+			"is_default": schema.BoolAttribute{
+				MarkdownDescription: "Is this the default channel of the app",
+				Computed:            true,
+			},
+			"build_airgap_automatically": schema.BoolAttribute{
+				MarkdownDescription: "Are airgap bundles built for every release promoted to the channel",
+				Computed:            true,
+			},
+			"custom_hostnames": schema.SingleNestedAttribute{
+				MarkdownDescription: "Custom hostnames of the channel, not set when the channel uses the app defaults",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"registry": schema.StringAttribute{
+						MarkdownDescription: "Hostname of the Replicated registry",
+						Computed:            true,
+					},
+					"proxy": schema.StringAttribute{
+						MarkdownDescription: "Hostname of the Replicated proxy registry",
+						Computed:            true,
+					},
+					"download_portal": schema.StringAttribute{
+						MarkdownDescription: "Hostname of the download portal",
+						Computed:            true,
+					},
+					"replicated_app": schema.StringAttribute{
+						MarkdownDescription: "Hostname of the replicated.app endpoint",
+						Computed:            true,
+					},
+				},
+			},
+			"release_sequence": schema.Int64Attribute{
+				MarkdownDescription: "Sequence of the release currently promoted to the channel",
+				Computed:            true,
+			},
+			"current_version": schema.StringAttribute{
+				MarkdownDescription: "Version label of the release currently promoted to the channel",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ChannelDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*ReplicatedProviderClients)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ReplicatedProviderClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = clients.vendorAPI
+	d.appResolver = clients.appResolver
+}
+
+func (d *ChannelDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ChannelDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appID, err := d.appResolver.resolveAppID(data.AppId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("app_id"), "Invalid App", fmt.Sprintf("Unable to resolve app, got error: %s", err))
+		return
+	}
+
+	channels, err := d.client.ListAppChannels(appID)
+	if err != nil {
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to list channels, got error: %s", err))
+		return
+	}
+
+	var matches []AppChannel
+	for _, channel := range channels {
+		if channel.Name == data.Name.ValueString() && !channel.IsArchived {
+			matches = append(matches, channel)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		resp.Diagnostics.AddAttributeError(path.Root("name"), "Channel Not Found", fmt.Sprintf("App %s has no channel named %q.", data.AppId.ValueString(), data.Name.ValueString()))
+		return
+	case 1:
+	default:
+		resp.Diagnostics.AddAttributeError(path.Root("name"), "Ambiguous Channel", fmt.Sprintf("App %s has %d channels named %q.", data.AppId.ValueString(), len(matches), data.Name.ValueString()))
+		return
+	}
+
+	channel := matches[0]
+	data.Id = types.StringValue(channel.ID)
+	data.Description = types.StringValue(channel.Description)
+	data.Slug = types.StringValue(channel.Slug)
+	data.SemverRequired = types.BoolValue(channel.SemverRequired)
+	data.IsDefault = types.BoolValue(channel.IsDefault)
+	data.BuildAirgapAutomatically = types.BoolValue(channel.BuildAirgapAutomatically)
+	data.CustomHostnames = customHostnamesValue(channel.CustomHostNameOverrides)
+	data.ReleaseSequence = types.Int64Value(channel.ReleaseSequence)
+	data.CurrentVersion = types.StringValue(channel.CurrentVersion)
+
+	tflog.Trace(ctx, "read a channel", map[string]interface{}{"channel_id": channel.ID})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
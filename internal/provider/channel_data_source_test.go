@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelDataSourceRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		channel string
+		setup   func(api *fakeVendorAPI)
+		wantErr string
+	}{
+		{
+			name:    "channel",
+			channel: "Beta",
+		},
+		{
+			name:    "unknown channel",
+			channel: "Stable",
+			wantErr: "Channel Not Found",
+		},
+		{
+			name:    "archived channel",
+			channel: "Beta",
+			setup: func(api *fakeVendorAPI) {
+				archived, err := api.CreateChannel(testFakeAppID, "Beta", "")
+				require.NoError(t, err)
+				require.NoError(t, api.ArchiveChannel(testFakeAppID, archived.ID))
+			},
+		},
+		{
+			name:    "ambiguous channel",
+			channel: "Beta",
+			setup: func(api *fakeVendorAPI) {
+				_, err := api.CreateChannel(testFakeAppID, "Beta", "")
+				require.NoError(t, err)
+			},
+			wantErr: "Ambiguous Channel",
+		},
+		{
+			name:    "api error",
+			channel: "Beta",
+			setup: func(api *fakeVendorAPI) {
+				api.errs["ListAppChannels"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			channel, err := api.CreateChannel(testFakeAppID, "Beta", "beta releases")
+			require.NoError(t, err)
+			_, err = api.UpdateAppChannel(testFakeAppID, channel.ID, UpdateChannelOpts{
+				Name:           "Beta",
+				Description:    "beta releases",
+				SemverRequired: true,
+			})
+			require.NoError(t, err)
+			if tt.setup != nil {
+				tt.setup(api)
+			}
+			d := NewChannelDataSource()
+			testConfiguredDataSource(t, d, api)
+			s := testDataSourceSchema(t, d)
+
+			config := ChannelDataSourceModel{
+				Id:                       types.StringNull(),
+				AppId:                    types.StringValue("test-app"),
+				Name:                     types.StringValue(tt.channel),
+				Description:              types.StringNull(),
+				Slug:                     types.StringNull(),
+				SemverRequired:           types.BoolNull(),
+				IsDefault:                types.BoolNull(),
+				BuildAirgapAutomatically: types.BoolNull(),
+				CustomHostnames:          types.ObjectNull(customHostnamesAttrTypes),
+				ReleaseSequence:          types.Int64Null(),
+				CurrentVersion:           types.StringNull(),
+			}
+			c, state := testDataSourceConfig(t, s, &config)
+			resp := datasource.ReadResponse{State: state}
+			d.Read(context.Background(), datasource.ReadRequest{Config: c}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var got ChannelDataSourceModel
+			require.False(t, resp.State.Get(context.Background(), &got).HasError())
+			assert.Equal(t, ChannelDataSourceModel{
+				Id:                       types.StringValue(channel.ID),
+				AppId:                    types.StringValue("test-app"),
+				Name:                     types.StringValue("Beta"),
+				Description:              types.StringValue("beta releases"),
+				Slug:                     types.StringValue("beta"),
+				SemverRequired:           types.BoolValue(true),
+				IsDefault:                types.BoolValue(false),
+				BuildAirgapAutomatically: types.BoolValue(false),
+				CustomHostnames:          types.ObjectNull(customHostnamesAttrTypes),
+				ReleaseSequence:          types.Int64Value(0),
+				CurrentVersion:           types.StringValue(""),
+			}, got)
+		})
+	}
+}
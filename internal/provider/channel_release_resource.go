@@ -0,0 +1,349 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/platformclient"
+)
+
+const (
+	// channelReleaseDestroyKeep leaves the release promoted on destroy
+	channelReleaseDestroyKeep = "keep"
+	// channelReleaseDestroyDemote demotes the release on destroy
+	channelReleaseDestroyDemote = "demote"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ChannelReleaseResource{}
+var _ resource.ResourceWithImportState = &ChannelReleaseResource{}
+var _ resource.ResourceWithModifyPlan = &ChannelReleaseResource{}
+
+func NewChannelReleaseResource() resource.Resource {
+	return &ChannelReleaseResource{}
+}
+
+// ChannelReleaseResource defines the resource implementation.
+type ChannelReleaseResource struct {
+	client      VendorAPI
+	appResolver *appResolver
+}
+
+// ChannelReleaseResourceModel describes the resource data model.
+type ChannelReleaseResourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	AppId           types.String `tfsdk:"app_id"`
+	ChannelId       types.String `tfsdk:"channel_id"`
+	Sequence        types.Int64  `tfsdk:"sequence"`
+	VersionLabel    types.String `tfsdk:"version_label"`
+	ReleaseNotes    types.String `tfsdk:"release_notes"`
+	Required        types.Bool   `tfsdk:"required"`
+	DestroyPolicy   types.String `tfsdk:"destroy_policy"`
+	ChannelSequence types.Int64  `tfsdk:"channel_sequence"`
+}
+
+func (r *ChannelReleaseResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_channel_release"
+}
+
+func (r *ChannelReleaseResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Promotion of a release to a channel. Changing the sequence, version label, release notes or required flag promotes the release again",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the promotion, `<channel_id>/<channel_sequence>`",
+				Computed:            true,
+			},
+			"app_id": schema.StringAttribute{
+				MarkdownDescription: "ID or slug of the app the channel belongs to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"channel_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the channel to promote the release to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"sequence": schema.Int64Attribute{
+				MarkdownDescription: "Sequence of the release to promote",
+				Required:            true,
+			},
+			"version_label": schema.StringAttribute{
+				MarkdownDescription: "Version label of the promotion, a semantic version when the channel requires one",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"release_notes": schema.StringAttribute{
+				MarkdownDescription: "Release notes of the promotion, in markdown",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"required": schema.BoolAttribute{
+				MarkdownDescription: "Prevent installations from skipping this release when they update (default false)",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"destroy_policy": schema.StringAttribute{
+				MarkdownDescription: "What destroying the resource does: `keep` leaves the release promoted, `demote` demotes it from the channel (default `keep`)",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(channelReleaseDestroyKeep),
+				Validators: []validator.String{
+					stringvalidator.OneOf(channelReleaseDestroyKeep, channelReleaseDestroyDemote),
+				},
+			},
+			"channel_sequence": schema.Int64Attribute{
+				MarkdownDescription: "Sequence of the promotion on the channel",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *ChannelReleaseResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*ReplicatedProviderClients)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ReplicatedProviderClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = clients.vendorAPI
+	r.appResolver = clients.appResolver
+}
+
+func (r *ChannelReleaseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ChannelReleaseResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.promote(ctx, &data, resp.State.Set, &resp.Diagnostics)
+}
+
+func (r *ChannelReleaseResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ChannelReleaseResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appID, err := r.appResolver.resolveAppID(data.AppId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to resolve app, got error: %s", err))
+		return
+	}
+
+	releases, err := r.client.ListChannelReleases(appID, data.ChannelId.ValueString())
+	if err != nil {
+		if errors.Is(err, platformclient.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to list channel releases, got error: %s", err))
+		return
+	}
+
+	var release *AppChannelRelease
+	for i := range releases {
+		if releases[i].ChannelSequence == data.ChannelSequence.ValueInt64() {
+			release = &releases[i]
+		}
+	}
+	// a demoted promotion is gone, the next apply promotes the release again
+	if release == nil || release.IsDemoted {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Id = types.StringValue(channelReleaseID(data.ChannelId.ValueString(), release.ChannelSequence))
+	data.Sequence = types.Int64Value(release.Sequence)
+	data.VersionLabel = types.StringValue(release.Semver)
+	data.ReleaseNotes = types.StringValue(release.ReleaseNotes)
+	data.Required = types.BoolValue(release.IsRequired)
+	if data.DestroyPolicy.IsNull() {
+		data.DestroyPolicy = types.StringValue(channelReleaseDestroyKeep)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ChannelReleaseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ChannelReleaseResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// ModifyPlan keeps the channel sequence when only the destroy policy
+	// changed, there is nothing to promote then
+	if !data.ChannelSequence.IsUnknown() {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	r.promote(ctx, &data, resp.State.Set, &resp.Diagnostics)
+}
+
+func (r *ChannelReleaseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ChannelReleaseResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.DestroyPolicy.ValueString() != channelReleaseDestroyDemote {
+		return
+	}
+
+	appID, err := r.appResolver.resolveAppID(data.AppId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to resolve app, got error: %s", err))
+		return
+	}
+
+	if err := r.client.DemoteChannelRelease(appID, data.ChannelId.ValueString(), data.ChannelSequence.ValueInt64()); err != nil {
+		if errors.Is(err, platformclient.ErrNotFound) {
+			return
+		}
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to demote channel release, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "demoted a channel release", map[string]interface{}{"channel_id": data.ChannelId.ValueString(), "channel_sequence": data.ChannelSequence.ValueInt64()})
+}
+
+func (r *ChannelReleaseResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to do on create and destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan ChannelReleaseResourceModel
+	var state ChannelReleaseResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the promotion is kept unless one of its settings changed
+	if plan.Sequence.Equal(state.Sequence) &&
+		plan.VersionLabel.Equal(state.VersionLabel) &&
+		plan.ReleaseNotes.Equal(state.ReleaseNotes) &&
+		plan.Required.Equal(state.Required) {
+		plan.Id = state.Id
+		plan.ChannelSequence = state.ChannelSequence
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+	}
+}
+
+// ImportState accepts <app_id_or_slug>/<channel_id>/<channel_sequence>.
+func (r *ChannelReleaseResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", fmt.Sprintf("Expected import identifier with format: <app_id_or_slug>/<channel_id>/<channel_sequence>. Got: %q", req.ID))
+		return
+	}
+	channelSequence, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil || channelSequence < 0 {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", fmt.Sprintf("Expected the channel sequence to be a number. Got: %q", parts[2]))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("channel_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("channel_sequence"), channelSequence)...)
+}
+
+// promote promotes the release in data and saves the promotion with
+// setState.
+func (r *ChannelReleaseResource) promote(ctx context.Context, data *ChannelReleaseResourceModel, setState func(context.Context, interface{}) diag.Diagnostics, diags *diag.Diagnostics) {
+	appID, err := r.appResolver.resolveAppID(data.AppId.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("app_id"), "Invalid App", fmt.Sprintf("Unable to resolve app, got error: %s", err))
+		return
+	}
+
+	channelID := data.ChannelId.ValueString()
+	err = r.client.PromoteRelease(appID, data.Sequence.ValueInt64(), data.VersionLabel.ValueString(), data.ReleaseNotes.ValueString(), data.Required.ValueBool(), channelID)
+	if err != nil {
+		diags.AddError("Server Error", fmt.Sprintf("Unable to promote release, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "promoted a release", map[string]interface{}{"channel_id": channelID, "sequence": data.Sequence.ValueInt64()})
+
+	// the promote endpoint does not return the promotion, it is the latest
+	// promotion of the release to the channel
+	releases, err := r.client.ListChannelReleases(appID, channelID)
+	if err != nil {
+		diags.AddError("Server Error", fmt.Sprintf("Unable to list channel releases, got error: %s", err))
+		return
+	}
+
+	var release *AppChannelRelease
+	for i := range releases {
+		if releases[i].Sequence == data.Sequence.ValueInt64() && (release == nil || releases[i].ChannelSequence > release.ChannelSequence) {
+			release = &releases[i]
+		}
+	}
+	if release == nil {
+		diags.AddError("Server Error", fmt.Sprintf("Release %d was promoted but is not listed on channel %s.", data.Sequence.ValueInt64(), channelID))
+		return
+	}
+
+	data.Id = types.StringValue(channelReleaseID(channelID, release.ChannelSequence))
+	data.ChannelSequence = types.Int64Value(release.ChannelSequence)
+
+	diags.Append(setState(ctx, data)...)
+}
+
+// channelReleaseID returns the ID of the promotion with the channel sequence.
+func channelReleaseID(channelID string, channelSequence int64) string {
+	return fmt.Sprintf("%s/%d", channelID, channelSequence)
+}
@@ -0,0 +1,563 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccChannelReleaseResource(t *testing.T) {
+	testAccVendorAPI(t)
+	rName := acctest.RandomWithPrefix(testAccResourcePrefix)
+	dir := testWriteFiles(t, t.TempDir(), map[string]string{"config.yaml": "kind: Config"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccChannelReleaseResourceConfig(rName, dir, "1.0.0"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("replicated_channel_release.test", "sequence", "replicated_release.test", "sequence"),
+					resource.TestCheckResourceAttr("replicated_channel_release.test", "version_label", "1.0.0"),
+					resource.TestCheckResourceAttr("replicated_channel_release.test", "channel_sequence", "0"),
+					resource.TestCheckResourceAttr("data.replicated_channel.test", "current_version", "1.0.0"),
+				),
+			},
+			{
+				ResourceName:            "replicated_channel_release.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdPrefix:     testAccAppID + "/",
+				ImportStateVerifyIgnore: []string{"destroy_policy"},
+			},
+			{
+				Config: testAccChannelReleaseResourceConfig(rName, dir, "1.0.1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("replicated_channel_release.test", "version_label", "1.0.1"),
+					resource.TestCheckResourceAttr("replicated_channel_release.test", "channel_sequence", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccChannelReleaseResourceConfig(name string, dir string, versionLabel string) string {
+	return fmt.Sprintf(`
+		resource "replicated_channel" "test" {
+			app_id = %[2]q
+			name   = %[1]q
+		}
+
+		resource "replicated_release" "test" {
+			app_id   = %[2]q
+			yaml_dir = %[3]q
+		}
+
+		resource "replicated_channel_release" "test" {
+			app_id         = %[2]q
+			channel_id     = replicated_channel.test.id
+			sequence       = replicated_release.test.sequence
+			version_label  = %[4]q
+			release_notes  = "promoted by the acceptance tests"
+			destroy_policy = "demote"
+		}
+
+		data "replicated_channel" "test" {
+			app_id = %[2]q
+			name   = replicated_channel.test.name
+
+			depends_on = [replicated_channel_release.test]
+		}
+	`, name, testAccAppID, dir, versionLabel)
+}
+
+// testFakeChannelRelease returns a fake vendor api with a channel and two
+// releases, and the model of a promotion of the first release to the channel.
+func testFakeChannelRelease(t *testing.T) (*fakeVendorAPI, ChannelReleaseResourceModel) {
+	t.Helper()
+
+	api := newFakeVendorAPI()
+	channel, err := api.CreateChannel(testFakeAppID, "Beta", "")
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		_, err := api.CreateRelease(testFakeAppID, "[]")
+		require.NoError(t, err)
+	}
+
+	return api, ChannelReleaseResourceModel{
+		Id:              types.StringUnknown(),
+		AppId:           types.StringValue("test-app"),
+		ChannelId:       types.StringValue(channel.ID),
+		Sequence:        types.Int64Value(1),
+		VersionLabel:    types.StringValue("1.0.0"),
+		ReleaseNotes:    types.StringValue("first release"),
+		Required:        types.BoolValue(false),
+		DestroyPolicy:   types.StringValue(channelReleaseDestroyKeep),
+		ChannelSequence: types.Int64Unknown(),
+	}
+}
+
+// testPromoted returns m as saved after promoting it with channel sequence
+// channelSequence.
+func testPromoted(t *testing.T, api *fakeVendorAPI, m ChannelReleaseResourceModel, channelSequence int64) ChannelReleaseResourceModel {
+	t.Helper()
+
+	require.NoError(t, api.PromoteRelease(testFakeAppID, m.Sequence.ValueInt64(), m.VersionLabel.ValueString(), m.ReleaseNotes.ValueString(), m.Required.ValueBool(), m.ChannelId.ValueString()))
+	m.Id = types.StringValue(channelReleaseID(m.ChannelId.ValueString(), channelSequence))
+	m.ChannelSequence = types.Int64Value(channelSequence)
+	return m
+}
+
+func TestChannelReleaseResourceCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		plan    func(m *ChannelReleaseResourceModel)
+		setup   func(api *fakeVendorAPI, m ChannelReleaseResourceModel)
+		want    int64
+		wantErr string
+	}{
+		{
+			name: "promotion",
+		},
+		{
+			name: "promoted before",
+			setup: func(api *fakeVendorAPI, m ChannelReleaseResourceModel) {
+				testPromoted(t, api, m, 0)
+				m.Sequence = types.Int64Value(2)
+				testPromoted(t, api, m, 1)
+			},
+			want: 2,
+		},
+		{
+			name: "unknown release",
+			plan: func(m *ChannelReleaseResourceModel) {
+				m.Sequence = types.Int64Value(3)
+			},
+			wantErr: "Server Error",
+		},
+		{
+			name: "unknown app",
+			plan: func(m *ChannelReleaseResourceModel) {
+				m.AppId = types.StringValue("other-app")
+			},
+			wantErr: "Invalid App",
+		},
+		{
+			name: "api error",
+			setup: func(api *fakeVendorAPI, m ChannelReleaseResourceModel) {
+				api.errs["PromoteRelease"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api, plan := testFakeChannelRelease(t)
+			if tt.plan != nil {
+				tt.plan(&plan)
+			}
+			if tt.setup != nil {
+				tt.setup(api, plan)
+			}
+			r := NewChannelReleaseResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			resp := fwresource.CreateResponse{State: testState(t, s, nil)}
+			r.Create(context.Background(), fwresource.CreateRequest{Plan: testPlan(t, s, &plan)}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var got ChannelReleaseResourceModel
+			require.False(t, resp.State.Get(context.Background(), &got).HasError())
+			assert.Equal(t, tt.want, got.ChannelSequence.ValueInt64())
+			assert.Equal(t, channelReleaseID(plan.ChannelId.ValueString(), tt.want), got.Id.ValueString())
+
+			releases, err := api.ListChannelReleases(testFakeAppID, plan.ChannelId.ValueString())
+			require.NoError(t, err)
+			require.Len(t, releases, int(tt.want)+1)
+			assert.Equal(t, AppChannelRelease{
+				ChannelSequence: tt.want,
+				Sequence:        1,
+				Semver:          "1.0.0",
+				ReleaseNotes:    "first release",
+				Created:         fakeNow,
+			}, releases[tt.want])
+		})
+	}
+}
+
+func TestChannelReleaseResourceRead(t *testing.T) {
+	tests := []struct {
+		name        string
+		setup       func(api *fakeVendorAPI, m *ChannelReleaseResourceModel)
+		wantRemoved bool
+		wantErr     string
+	}{
+		{
+			name: "promotion",
+		},
+		{
+			name: "imported promotion",
+			setup: func(api *fakeVendorAPI, m *ChannelReleaseResourceModel) {
+				*m = ChannelReleaseResourceModel{
+					Id:              types.StringNull(),
+					AppId:           m.AppId,
+					ChannelId:       m.ChannelId,
+					Sequence:        types.Int64Null(),
+					VersionLabel:    types.StringNull(),
+					ReleaseNotes:    types.StringNull(),
+					Required:        types.BoolNull(),
+					DestroyPolicy:   types.StringNull(),
+					ChannelSequence: m.ChannelSequence,
+				}
+			},
+		},
+		{
+			name: "demoted promotion",
+			setup: func(api *fakeVendorAPI, m *ChannelReleaseResourceModel) {
+				require.NoError(t, api.DemoteChannelRelease(testFakeAppID, m.ChannelId.ValueString(), 0))
+			},
+			wantRemoved: true,
+		},
+		{
+			name: "missing promotion",
+			setup: func(api *fakeVendorAPI, m *ChannelReleaseResourceModel) {
+				m.ChannelSequence = types.Int64Value(4)
+			},
+			wantRemoved: true,
+		},
+		{
+			name: "missing channel",
+			setup: func(api *fakeVendorAPI, m *ChannelReleaseResourceModel) {
+				m.ChannelId = types.StringValue("missing")
+			},
+			wantRemoved: true,
+		},
+		{
+			name: "api error",
+			setup: func(api *fakeVendorAPI, m *ChannelReleaseResourceModel) {
+				api.errs["ListChannelReleases"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api, plan := testFakeChannelRelease(t)
+			promoted := testPromoted(t, api, plan, 0)
+			prior := promoted
+			if tt.setup != nil {
+				tt.setup(api, &prior)
+			}
+			r := NewChannelReleaseResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			state := testState(t, s, &prior)
+			resp := fwresource.ReadResponse{State: state}
+			r.Read(context.Background(), fwresource.ReadRequest{State: state}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			if tt.wantRemoved {
+				assert.True(t, resp.State.Raw.IsNull())
+				return
+			}
+
+			var got ChannelReleaseResourceModel
+			require.False(t, resp.State.Get(context.Background(), &got).HasError())
+			assert.Equal(t, promoted, got)
+		})
+	}
+}
+
+func TestChannelReleaseResourceUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		plan    func(m *ChannelReleaseResourceModel)
+		setup   func(api *fakeVendorAPI)
+		want    int64
+		wantErr string
+	}{
+		{
+			name: "new sequence",
+			plan: func(m *ChannelReleaseResourceModel) {
+				m.Sequence = types.Int64Value(2)
+				m.VersionLabel = types.StringValue("1.1.0")
+				m.Id = types.StringUnknown()
+				m.ChannelSequence = types.Int64Unknown()
+			},
+			want: 1,
+		},
+		{
+			name: "destroy policy",
+			plan: func(m *ChannelReleaseResourceModel) {
+				m.DestroyPolicy = types.StringValue(channelReleaseDestroyDemote)
+			},
+			setup: func(api *fakeVendorAPI) {
+				api.errs["PromoteRelease"] = errors.New("should not be called")
+			},
+			want: 0,
+		},
+		{
+			name: "api error",
+			plan: func(m *ChannelReleaseResourceModel) {
+				m.Sequence = types.Int64Value(2)
+				m.Id = types.StringUnknown()
+				m.ChannelSequence = types.Int64Unknown()
+			},
+			setup: func(api *fakeVendorAPI) {
+				api.errs["PromoteRelease"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api, plan := testFakeChannelRelease(t)
+			prior := testPromoted(t, api, plan, 0)
+			if tt.setup != nil {
+				tt.setup(api)
+			}
+			r := NewChannelReleaseResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			planned := prior
+			tt.plan(&planned)
+			resp := fwresource.UpdateResponse{State: testState(t, s, &prior)}
+			r.Update(context.Background(), fwresource.UpdateRequest{
+				Plan:  testPlan(t, s, &planned),
+				State: testState(t, s, &prior),
+			}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var got ChannelReleaseResourceModel
+			require.False(t, resp.State.Get(context.Background(), &got).HasError())
+			assert.Equal(t, tt.want, got.ChannelSequence.ValueInt64())
+			assert.Equal(t, planned.Sequence, got.Sequence)
+			assert.Equal(t, planned.DestroyPolicy, got.DestroyPolicy)
+
+			releases, err := api.ListChannelReleases(testFakeAppID, plan.ChannelId.ValueString())
+			require.NoError(t, err)
+			assert.Len(t, releases, int(tt.want)+1)
+		})
+	}
+}
+
+func TestChannelReleaseResourceModifyPlan(t *testing.T) {
+	tests := []struct {
+		name     string
+		plan     func(m *ChannelReleaseResourceModel)
+		wantKept bool
+	}{
+		{
+			name: "destroy policy",
+			plan: func(m *ChannelReleaseResourceModel) {
+				m.DestroyPolicy = types.StringValue(channelReleaseDestroyDemote)
+			},
+			wantKept: true,
+		},
+		{
+			name: "sequence",
+			plan: func(m *ChannelReleaseResourceModel) {
+				m.Sequence = types.Int64Value(2)
+			},
+		},
+		{
+			name: "release notes",
+			plan: func(m *ChannelReleaseResourceModel) {
+				m.ReleaseNotes = types.StringValue("updated notes")
+			},
+		},
+		{
+			name: "unknown sequence",
+			plan: func(m *ChannelReleaseResourceModel) {
+				m.Sequence = types.Int64Unknown()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api, plan := testFakeChannelRelease(t)
+			prior := testPromoted(t, api, plan, 0)
+			r := NewChannelReleaseResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			// the framework marks the computed attributes unknown when the
+			// configuration changes
+			planned := prior
+			planned.Id = types.StringUnknown()
+			planned.ChannelSequence = types.Int64Unknown()
+			tt.plan(&planned)
+
+			resp := fwresource.ModifyPlanResponse{Plan: testPlan(t, s, &planned)}
+			r.(fwresource.ResourceWithModifyPlan).ModifyPlan(context.Background(), fwresource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: s, Raw: testState(t, s, &planned).Raw},
+				Plan:   testPlan(t, s, &planned),
+				State:  testState(t, s, &prior),
+			}, &resp)
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var got ChannelReleaseResourceModel
+			require.False(t, resp.Plan.Get(context.Background(), &got).HasError())
+			if tt.wantKept {
+				assert.Equal(t, prior.ChannelSequence, got.ChannelSequence)
+				assert.Equal(t, prior.Id, got.Id)
+			} else {
+				assert.True(t, got.ChannelSequence.IsUnknown())
+				assert.True(t, got.Id.IsUnknown())
+			}
+		})
+	}
+}
+
+func TestChannelReleaseResourceDelete(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      string
+		setup       func(api *fakeVendorAPI, m *ChannelReleaseResourceModel)
+		wantDemoted bool
+		wantErr     string
+	}{
+		{
+			name:   "keep",
+			policy: channelReleaseDestroyKeep,
+			setup: func(api *fakeVendorAPI, m *ChannelReleaseResourceModel) {
+				api.errs["DemoteChannelRelease"] = errors.New("should not be called")
+			},
+		},
+		{
+			name:        "demote",
+			policy:      channelReleaseDestroyDemote,
+			wantDemoted: true,
+		},
+		{
+			name:   "missing promotion",
+			policy: channelReleaseDestroyDemote,
+			setup: func(api *fakeVendorAPI, m *ChannelReleaseResourceModel) {
+				m.ChannelSequence = types.Int64Value(4)
+			},
+		},
+		{
+			name:   "api error",
+			policy: channelReleaseDestroyDemote,
+			setup: func(api *fakeVendorAPI, m *ChannelReleaseResourceModel) {
+				api.errs["DemoteChannelRelease"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api, plan := testFakeChannelRelease(t)
+			plan.DestroyPolicy = types.StringValue(tt.policy)
+			prior := testPromoted(t, api, plan, 0)
+			if tt.setup != nil {
+				tt.setup(api, &prior)
+			}
+			r := NewChannelReleaseResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			state := testState(t, s, &prior)
+			resp := fwresource.DeleteResponse{State: state}
+			r.Delete(context.Background(), fwresource.DeleteRequest{State: state}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			releases, err := api.ListChannelReleases(testFakeAppID, plan.ChannelId.ValueString())
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDemoted, releases[0].IsDemoted)
+		})
+	}
+}
+
+func TestChannelReleaseResourceImportState(t *testing.T) {
+	tests := []struct {
+		name                string
+		id                  string
+		wantAppID           string
+		wantChannelID       string
+		wantChannelSequence int64
+		wantErr             string
+	}{
+		{
+			name:                "promotion",
+			id:                  "test-app/channel-1/3",
+			wantAppID:           "test-app",
+			wantChannelID:       "channel-1",
+			wantChannelSequence: 3,
+		},
+		{
+			name:    "missing channel sequence",
+			id:      "test-app/channel-1",
+			wantErr: "Unexpected Import Identifier",
+		},
+		{
+			name:    "invalid channel sequence",
+			id:      "test-app/channel-1/latest",
+			wantErr: "Unexpected Import Identifier",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewChannelReleaseResource()
+			testConfiguredResource(t, r, newFakeVendorAPI())
+			s := testResourceSchema(t, r)
+
+			resp := fwresource.ImportStateResponse{State: testState(t, s, nil)}
+			r.(fwresource.ResourceWithImportState).ImportState(context.Background(), fwresource.ImportStateRequest{ID: tt.id}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var appID, channelID string
+			var channelSequence int64
+			require.False(t, resp.State.GetAttribute(context.Background(), path.Root("app_id"), &appID).HasError())
+			require.False(t, resp.State.GetAttribute(context.Background(), path.Root("channel_id"), &channelID).HasError())
+			require.False(t, resp.State.GetAttribute(context.Background(), path.Root("channel_sequence"), &channelSequence).HasError())
+			assert.Equal(t, tt.wantAppID, appID)
+			assert.Equal(t, tt.wantChannelID, channelID)
+			assert.Equal(t, tt.wantChannelSequence, channelSequence)
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/platformclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ChannelReleasesDataSource{}
+var _ datasource.DataSourceWithConfigure = &ChannelReleasesDataSource{}
+
+func NewChannelReleasesDataSource() datasource.DataSource {
+	return &ChannelReleasesDataSource{}
+}
+
+// ChannelReleasesDataSource defines the data source implementation.
+type ChannelReleasesDataSource struct {
+	client      VendorAPI
+	appResolver *appResolver
+}
+
+// ChannelReleasesDataSourceModel describes the data source data model.
+type ChannelReleasesDataSourceModel struct {
+	AppId          types.String `tfsdk:"app_id"`
+	ChannelId      types.String `tfsdk:"channel_id"`
+	Semver         types.String `tfsdk:"semver"`
+	Latest         types.Bool   `tfsdk:"latest"`
+	IncludeDemoted types.Bool   `tfsdk:"include_demoted"`
+	Releases       types.List   `tfsdk:"releases"`
+}
+
+// ChannelReleaseDataModel describes a release promoted to a channel.
+type ChannelReleaseDataModel struct {
+	ChannelSequence   types.Int64  `tfsdk:"channel_sequence"`
+	Sequence          types.Int64  `tfsdk:"sequence"`
+	VersionLabel      types.String `tfsdk:"version_label"`
+	ReleaseNotes      types.String `tfsdk:"release_notes"`
+	CreatedAt         types.String `tfsdk:"created_at"`
+	Required          types.Bool   `tfsdk:"required"`
+	Demoted           types.Bool   `tfsdk:"demoted"`
+	AirgapBuildStatus types.String `tfsdk:"airgap_build_status"`
+	AirgapBuildError  types.String `tfsdk:"airgap_build_error"`
+}
+
+var channelReleaseDataAttrTypes = map[string]attr.Type{
+	"channel_sequence":    types.Int64Type,
+	"sequence":            types.Int64Type,
+	"version_label":       types.StringType,
+	"release_notes":       types.StringType,
+	"created_at":          types.StringType,
+	"required":            types.BoolType,
+	"demoted":             types.BoolType,
+	"airgap_build_status": types.StringType,
+	"airgap_build_error":  types.StringType,
+}
+
+func (d *ChannelReleasesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_channel_releases"
+}
+
+func (d *ChannelReleasesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the releases promoted to a channel, most recent promotion first, optionally filtered by version label",
+
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
+				MarkdownDescription: "ID or slug of the app to which the channel belongs",
+				Required:            true,
+			},
+			"channel_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the channel",
+				Required:            true,
+			},
+			"semver": schema.StringAttribute{
+				MarkdownDescription: "Only list releases whose version label matches this semantic version constraint, such as `>= 1.2.0, < 2.0.0` or `~1.4`. " +
+					"Releases whose version label is not a semantic version are excluded",
+				Optional: true,
+			},
+			"latest": schema.BoolAttribute{
+				MarkdownDescription: "Only list the most recently promoted release matching the filters (defaults to false)",
+				Optional:            true,
+			},
+			"include_demoted": schema.BoolAttribute{
+				MarkdownDescription: "Also list demoted releases (defaults to false)",
+				Optional:            true,
+			},
+			"releases": schema.ListNestedAttribute{
+				MarkdownDescription: "Releases promoted to the channel matching the filters, most recent promotion first",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"channel_sequence": schema.Int64Attribute{
+							MarkdownDescription: "Sequence of the promotion in the channel",
+							Computed:            true,
+						},
+						"sequence": schema.Int64Attribute{
+							MarkdownDescription: "Sequence of the release",
+							Computed:            true,
+						},
+						"version_label": schema.StringAttribute{
+							MarkdownDescription: "Version label of the release in the channel",
+							Computed:            true,
+						},
+						"release_notes": schema.StringAttribute{
+							MarkdownDescription: "Release notes of the promotion",
+							Computed:            true,
+						},
+						"created_at": schema.StringAttribute{
+							MarkdownDescription: "Time the release was promoted to the channel",
+							Computed:            true,
+						},
+						"required": schema.BoolAttribute{
+							MarkdownDescription: "Must installations update through this release instead of skipping it",
+							Computed:            true,
+						},
+						"demoted": schema.BoolAttribute{
+							MarkdownDescription: "Was the release demoted from the channel",
+							Computed:            true,
+						},
+						"airgap_build_status": schema.StringAttribute{
+							MarkdownDescription: "Status of the airgap bundle build of the release, empty when no bundle was built",
+							Computed:            true,
+						},
+						"airgap_build_error": schema.StringAttribute{
+							MarkdownDescription: "Error of the airgap bundle build, empty unless the build failed",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ChannelReleasesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*ReplicatedProviderClients)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ReplicatedProviderClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = clients.vendorAPI
+	d.appResolver = clients.appResolver
+}
+
+func (d *ChannelReleasesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ChannelReleasesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := channelReleaseFilter{includeDemoted: data.IncludeDemoted.ValueBool()}
+	if !data.Semver.IsNull() {
+		constraint, err := semver.NewConstraint(data.Semver.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("semver"), "Invalid Semver Constraint", fmt.Sprintf("Unable to parse semver constraint, got error: %s", err))
+			return
+		}
+		filter.semver = constraint
+	}
+
+	appID, err := d.appResolver.resolveAppID(data.AppId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("app_id"), "Invalid App", fmt.Sprintf("Unable to resolve app, got error: %s", err))
+		return
+	}
+
+	releases, err := d.client.ListChannelReleases(appID, data.ChannelId.ValueString())
+	if err != nil {
+		if errors.Is(err, platformclient.ErrNotFound) {
+			resp.Diagnostics.AddAttributeError(path.Root("channel_id"), "Channel Not Found", fmt.Sprintf("App %s has no channel with id %q.", data.AppId.ValueString(), data.ChannelId.ValueString()))
+			return
+		}
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to list channel releases, got error: %s", err))
+		return
+	}
+
+	var matches []AppChannelRelease
+	for _, release := range releases {
+		if filter.matches(release) {
+			matches = append(matches, release)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].ChannelSequence > matches[j].ChannelSequence
+	})
+	if data.Latest.ValueBool() && len(matches) > 1 {
+		matches = matches[:1]
+	}
+
+	values := make([]ChannelReleaseDataModel, 0, len(matches))
+	for _, release := range matches {
+		values = append(values, channelReleaseDataModel(release))
+	}
+
+	list, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: channelReleaseDataAttrTypes}, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Releases = list
+
+	tflog.Trace(ctx, "listed channel releases", map[string]interface{}{"count": len(values)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func channelReleaseDataModel(release AppChannelRelease) ChannelReleaseDataModel {
+	createdAt := types.StringNull()
+	if !release.Created.IsZero() {
+		createdAt = types.StringValue(release.Created.UTC().Format(time.RFC3339))
+	}
+
+	return ChannelReleaseDataModel{
+		ChannelSequence:   types.Int64Value(release.ChannelSequence),
+		Sequence:          types.Int64Value(release.Sequence),
+		VersionLabel:      types.StringValue(release.Semver),
+		ReleaseNotes:      types.StringValue(release.ReleaseNotes),
+		CreatedAt:         createdAt,
+		Required:          types.BoolValue(release.IsRequired),
+		Demoted:           types.BoolValue(release.IsDemoted),
+		AirgapBuildStatus: types.StringValue(release.AirgapBuildStatus),
+		AirgapBuildError:  types.StringValue(release.AirgapBuildError),
+	}
+}
+
+// channelReleaseFilter selects channel releases by version label, demoted
+// releases only match when includeDemoted is set.
+type channelReleaseFilter struct {
+	semver         *semver.Constraints
+	includeDemoted bool
+}
+
+func (f channelReleaseFilter) matches(release AppChannelRelease) bool {
+	if release.IsDemoted && !f.includeDemoted {
+		return false
+	}
+
+	if f.semver != nil {
+		version, err := semver.NewVersion(release.Semver)
+		if err != nil {
+			return false
+		}
+		if !f.semver.Check(version) {
+			return false
+		}
+	}
+
+	return true
+}
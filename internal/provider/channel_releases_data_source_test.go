@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccChannelReleasesDataSource(t *testing.T) {
+	testAccVendorAPI(t)
+	rName := acctest.RandomWithPrefix(testAccResourcePrefix)
+	dir := testWriteFiles(t, t.TempDir(), map[string]string{"config.yaml": "kind: Config"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccChannelReleaseResourceConfig(rName, dir, "1.0.0") + fmt.Sprintf(`
+					data "replicated_channel_releases" "test" {
+						app_id     = %q
+						channel_id = replicated_channel.test.id
+						semver     = ">= 1.0.0"
+						latest     = true
+
+						depends_on = [replicated_channel_release.test]
+					}
+				`, testAccAppID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.replicated_channel_releases.test", "releases.#", "1"),
+					resource.TestCheckResourceAttr("data.replicated_channel_releases.test", "releases.0.version_label", "1.0.0"),
+					resource.TestCheckResourceAttr("data.replicated_channel_releases.test", "releases.0.release_notes", "promoted by the acceptance tests"),
+					resource.TestCheckResourceAttrPair("data.replicated_channel_releases.test", "releases.0.sequence", "replicated_release.test", "sequence"),
+					resource.TestCheckResourceAttrSet("data.replicated_channel_releases.test", "releases.0.created_at"),
+				),
+			},
+		},
+	})
+}
+
+func TestChannelReleasesDataSourceRead(t *testing.T) {
+	tests := []struct {
+		name           string
+		channelID      string
+		semver         string
+		latest         bool
+		includeDemoted bool
+		setup          func(api *fakeVendorAPI)
+		want           []int64
+		wantErr        string
+	}{
+		{
+			name: "releases",
+			want: []int64{2, 1, 0},
+		},
+		{
+			name:           "include demoted",
+			includeDemoted: true,
+			want:           []int64{3, 2, 1, 0},
+		},
+		{
+			name:   "latest",
+			latest: true,
+			want:   []int64{2},
+		},
+		{
+			name:   "semver",
+			semver: "< 1.1.0",
+			want:   []int64{0},
+		},
+		{
+			name:           "semver including demoted",
+			semver:         ">= 1.1.0",
+			includeDemoted: true,
+			want:           []int64{3, 1},
+		},
+		{
+			name:   "latest matching semver",
+			semver: "~1",
+			latest: true,
+			want:   []int64{1},
+		},
+		{
+			name:   "no match",
+			semver: ">= 2.0.0",
+			latest: true,
+			want:   []int64{},
+		},
+		{
+			name:    "invalid semver",
+			semver:  "not a constraint",
+			wantErr: "Invalid Semver Constraint",
+		},
+		{
+			name:      "unknown channel",
+			channelID: "missing",
+			wantErr:   "Channel Not Found",
+		},
+		{
+			name: "api error",
+			setup: func(api *fakeVendorAPI) {
+				api.errs["ListChannelReleases"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			channel, err := api.CreateChannel(testFakeAppID, "Beta", "")
+			require.NoError(t, err)
+			for i := 0; i < 2; i++ {
+				_, err := api.CreateRelease(testFakeAppID, "[]")
+				require.NoError(t, err)
+			}
+			require.NoError(t, api.PromoteRelease(testFakeAppID, 1, "1.0.0", "first release", true, channel.ID))
+			require.NoError(t, api.PromoteRelease(testFakeAppID, 2, "1.1.0", "", false, channel.ID))
+			require.NoError(t, api.PromoteRelease(testFakeAppID, 2, "nightly", "", false, channel.ID))
+			require.NoError(t, api.PromoteRelease(testFakeAppID, 2, "1.2.0", "", false, channel.ID))
+			require.NoError(t, api.DemoteChannelRelease(testFakeAppID, channel.ID, 3))
+			api.channelReleases[channel.ID][0].AirgapBuildStatus = "built"
+			if tt.setup != nil {
+				tt.setup(api)
+			}
+			d := NewChannelReleasesDataSource()
+			testConfiguredDataSource(t, d, api)
+			s := testDataSourceSchema(t, d)
+
+			config := ChannelReleasesDataSourceModel{
+				AppId:          types.StringValue("test-app"),
+				ChannelId:      types.StringValue(channel.ID),
+				Semver:         types.StringNull(),
+				Latest:         types.BoolValue(tt.latest),
+				IncludeDemoted: types.BoolValue(tt.includeDemoted),
+				Releases:       types.ListNull(types.ObjectType{AttrTypes: channelReleaseDataAttrTypes}),
+			}
+			if tt.channelID != "" {
+				config.ChannelId = types.StringValue(tt.channelID)
+			}
+			if tt.semver != "" {
+				config.Semver = types.StringValue(tt.semver)
+			}
+			c, state := testDataSourceConfig(t, s, &config)
+			resp := datasource.ReadResponse{State: state}
+			d.Read(context.Background(), datasource.ReadRequest{Config: c}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var got ChannelReleasesDataSourceModel
+			require.False(t, resp.State.Get(context.Background(), &got).HasError())
+			var releases []ChannelReleaseDataModel
+			require.False(t, got.Releases.ElementsAs(context.Background(), &releases, false).HasError())
+
+			channelSequences := []int64{}
+			for _, release := range releases {
+				channelSequences = append(channelSequences, release.ChannelSequence.ValueInt64())
+				if release.ChannelSequence.ValueInt64() == 0 {
+					assert.Equal(t, ChannelReleaseDataModel{
+						ChannelSequence:   types.Int64Value(0),
+						Sequence:          types.Int64Value(1),
+						VersionLabel:      types.StringValue("1.0.0"),
+						ReleaseNotes:      types.StringValue("first release"),
+						CreatedAt:         types.StringValue(fakeNow.Format(time.RFC3339)),
+						Required:          types.BoolValue(true),
+						Demoted:           types.BoolValue(false),
+						AirgapBuildStatus: types.StringValue("built"),
+						AirgapBuildError:  types.StringValue(""),
+					}, release)
+				}
+			}
+			assert.Equal(t, tt.want, channelSequences)
+		})
+	}
+}
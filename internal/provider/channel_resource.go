@@ -0,0 +1,428 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/platformclient"
+	rtypes "github.com/replicatedhq/replicated/pkg/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ChannelResource{}
+var _ resource.ResourceWithImportState = &ChannelResource{}
+var _ resource.ResourceWithModifyPlan = &ChannelResource{}
+
+func NewChannelResource() resource.Resource {
+	return &ChannelResource{}
+}
+
+// ChannelResource defines the resource implementation.
+type ChannelResource struct {
+	client      VendorAPI
+	appResolver *appResolver
+}
+
+// ChannelResourceModel describes the resource data model.
+type ChannelResourceModel struct {
+	Id                       types.String `tfsdk:"id"`
+	AppId                    types.String `tfsdk:"app_id"`
+	Name                     types.String `tfsdk:"name"`
+	Description              types.String `tfsdk:"description"`
+	Slug                     types.String `tfsdk:"slug"`
+	SemverRequired           types.Bool   `tfsdk:"semver_required"`
+	IsDefault                types.Bool   `tfsdk:"is_default"`
+	BuildAirgapAutomatically types.Bool   `tfsdk:"build_airgap_automatically"`
+	CustomHostnames          types.Object `tfsdk:"custom_hostnames"`
+}
+
+var customHostnamesAttrTypes = map[string]attr.Type{
+	"registry":        types.StringType,
+	"proxy":           types.StringType,
+	"download_portal": types.StringType,
+	"replicated_app":  types.StringType,
+}
+
+// customHostnamesModel describes the custom hostnames of a channel.
+type customHostnamesModel struct {
+	Registry       types.String `tfsdk:"registry"`
+	Proxy          types.String `tfsdk:"proxy"`
+	DownloadPortal types.String `tfsdk:"download_portal"`
+	ReplicatedApp  types.String `tfsdk:"replicated_app"`
+}
+
+func (r *ChannelResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_channel"
+}
+
+func (r *ChannelResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Release channel of an app. Destroying the resource archives the channel",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "ID of the channel",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"app_id": schema.StringAttribute{
+				MarkdownDescription: "ID or slug of the app to which the channel belongs",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the channel",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the channel",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"slug": schema.StringAttribute{
+				MarkdownDescription: "Slug of the channel, derived from the name when the channel is created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"semver_required": schema.BoolAttribute{
+				MarkdownDescription: "Require releases promoted to the channel to have a semantic version label (default false)",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"is_default": schema.BoolAttribute{
+				MarkdownDescription: "Is this the default channel of the app. Setting it makes the channel the default channel, the app always has exactly one default channel so it cannot be unset, set it on another channel instead",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"build_airgap_automatically": schema.BoolAttribute{
+				MarkdownDescription: "Build airgap bundles for every release promoted to the channel (default false)",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"custom_hostnames": schema.SingleNestedAttribute{
+				MarkdownDescription: "Custom hostnames used by the Helm and Embedded Cluster install instructions of the channel, in place of the app defaults",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"registry": schema.StringAttribute{
+						MarkdownDescription: "Hostname of the Replicated registry",
+						Optional:            true,
+					},
+					"proxy": schema.StringAttribute{
+						MarkdownDescription: "Hostname of the Replicated proxy registry",
+						Optional:            true,
+					},
+					"download_portal": schema.StringAttribute{
+						MarkdownDescription: "Hostname of the download portal",
+						Optional:            true,
+					},
+					"replicated_app": schema.StringAttribute{
+						MarkdownDescription: "Hostname of the replicated.app endpoint, which serves the Embedded Cluster installer and the Helm charts",
+						Optional:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *ChannelResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*ReplicatedProviderClients)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ReplicatedProviderClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = clients.vendorAPI
+	r.appResolver = clients.appResolver
+}
+
+func (r *ChannelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ChannelResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appID, err := r.appResolver.resolveAppID(data.AppId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("app_id"), "Invalid App", fmt.Sprintf("Unable to resolve app, got error: %s", err))
+		return
+	}
+
+	opts, diags := data.updateOpts(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.client.CreateChannel(appID, data.Name.ValueString(), data.Description.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to create channel, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "created a channel", map[string]interface{}{"channel_id": created.ID})
+
+	// the create endpoint only takes the name and description, the settings
+	// are applied with an update
+	channel, err := r.client.UpdateAppChannel(appID, created.ID, opts)
+	if err != nil {
+		// save the id so that the channel is not leaked
+		data.Id = types.StringValue(created.ID)
+		data.Slug = types.StringValue(created.Slug)
+		data.IsDefault = types.BoolValue(false)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to update channel settings, got error: %s", err))
+		return
+	}
+
+	if data.IsDefault.ValueBool() {
+		if err := r.client.SetDefaultChannel(appID, channel.ID); err != nil {
+			data.Id = types.StringValue(channel.ID)
+			data.Slug = types.StringValue(channel.Slug)
+			data.IsDefault = types.BoolValue(false)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to set default channel, got error: %s", err))
+			return
+		}
+		channel.IsDefault = true
+	}
+
+	data.applyChannel(channel)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ChannelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ChannelResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appID, err := r.appResolver.resolveAppID(data.AppId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to resolve app, got error: %s", err))
+		return
+	}
+
+	channel, err := r.client.GetAppChannel(appID, data.Id.ValueString())
+	if err != nil {
+		if errors.Is(err, platformclient.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to get channel, got error: %s", err))
+		return
+	}
+	if channel.IsArchived {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.applyChannel(channel)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ChannelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ChannelResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appID, err := r.appResolver.resolveAppID(data.AppId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("app_id"), "Invalid App", fmt.Sprintf("Unable to resolve app, got error: %s", err))
+		return
+	}
+
+	opts, diags := data.updateOpts(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	channel, err := r.client.UpdateAppChannel(appID, data.Id.ValueString(), opts)
+	if err != nil {
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to update channel, got error: %s", err))
+		return
+	}
+
+	if data.IsDefault.ValueBool() && !channel.IsDefault {
+		if err := r.client.SetDefaultChannel(appID, channel.ID); err != nil {
+			resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to set default channel, got error: %s", err))
+			return
+		}
+		channel.IsDefault = true
+	}
+
+	data.applyChannel(channel)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ChannelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ChannelResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appID, err := r.appResolver.resolveAppID(data.AppId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to resolve app, got error: %s", err))
+		return
+	}
+
+	if err := r.client.ArchiveChannel(appID, data.Id.ValueString()); err != nil {
+		if errors.Is(err, platformclient.ErrNotFound) {
+			return
+		}
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to archive channel, got error: %s", err))
+		return
+	}
+}
+
+// ImportState accepts <app_id_or_slug>/<channel_id>.
+func (r *ChannelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", fmt.Sprintf("Expected import identifier with format: <app_id_or_slug>/<channel_id>. Got: %q", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+}
+
+// ModifyPlan rejects unsetting is_default on the default channel at plan time,
+// since the Vendor API cannot leave an app without a default channel.
+func (r *ChannelResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to check on create and destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan ChannelResourceModel
+	var state ChannelResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.IsDefault.ValueBool() && !plan.IsDefault.IsUnknown() && !plan.IsDefault.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("is_default"),
+			"Invalid Default Channel",
+			fmt.Sprintf("Channel %q is the default channel of the app, and an app always has a default channel. "+
+				"To change the default channel, set is_default = true on another channel of the app instead. "+
+				"To keep this channel as the default, remove is_default from its configuration or set it to true.", state.Name.ValueString()),
+		)
+	}
+}
+
+// updateOpts returns the settings of the channel in m.
+func (m *ChannelResourceModel) updateOpts(ctx context.Context) (UpdateChannelOpts, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	opts := UpdateChannelOpts{
+		Name:                     m.Name.ValueString(),
+		Description:              m.Description.ValueString(),
+		SemverRequired:           m.SemverRequired.ValueBool(),
+		BuildAirgapAutomatically: m.BuildAirgapAutomatically.ValueBool(),
+	}
+
+	if !m.CustomHostnames.IsNull() && !m.CustomHostnames.IsUnknown() {
+		var hostnames customHostnamesModel
+		diags.Append(m.CustomHostnames.As(ctx, &hostnames, basetypes.ObjectAsOptions{})...)
+		opts.CustomHostNameOverrides.Registry.Hostname = hostnames.Registry.ValueString()
+		opts.CustomHostNameOverrides.Proxy.Hostname = hostnames.Proxy.ValueString()
+		opts.CustomHostNameOverrides.DownloadPortal.Hostname = hostnames.DownloadPortal.ValueString()
+		opts.CustomHostNameOverrides.ReplicatedApp.Hostname = hostnames.ReplicatedApp.ValueString()
+	}
+
+	return opts, diags
+}
+
+// applyChannel sets the attributes of m from channel.
+func (m *ChannelResourceModel) applyChannel(channel *AppChannel) {
+	m.Id = types.StringValue(channel.ID)
+	m.Name = types.StringValue(channel.Name)
+	m.Description = types.StringValue(channel.Description)
+	m.Slug = types.StringValue(channel.Slug)
+	m.SemverRequired = types.BoolValue(channel.SemverRequired)
+	m.IsDefault = types.BoolValue(channel.IsDefault)
+	m.BuildAirgapAutomatically = types.BoolValue(channel.BuildAirgapAutomatically)
+	m.CustomHostnames = customHostnamesValue(channel.CustomHostNameOverrides)
+}
+
+// customHostnamesValue returns the custom hostnames attribute of a channel,
+// null when the channel uses the app defaults.
+func customHostnamesValue(overrides rtypes.CustomHostNameOverrides) types.Object {
+	if overrides == (rtypes.CustomHostNameOverrides{}) {
+		return types.ObjectNull(customHostnamesAttrTypes)
+	}
+
+	hostname := func(value string) types.String {
+		if value == "" {
+			return types.StringNull()
+		}
+		return types.StringValue(value)
+	}
+
+	return types.ObjectValueMust(customHostnamesAttrTypes, map[string]attr.Value{
+		"registry":        hostname(overrides.Registry.Hostname),
+		"proxy":           hostname(overrides.Proxy.Hostname),
+		"download_portal": hostname(overrides.DownloadPortal.Hostname),
+		"replicated_app":  hostname(overrides.ReplicatedApp.Hostname),
+	})
+}
@@ -0,0 +1,547 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccChannelResource(t *testing.T) {
+	testAccVendorAPI(t)
+	rName := acctest.RandomWithPrefix(testAccResourcePrefix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccChannelResourceConfig(rName, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("replicated_channel.test", "name", rName),
+					resource.TestCheckResourceAttr("replicated_channel.test", "semver_required", "false"),
+					resource.TestCheckResourceAttr("replicated_channel.test", "is_default", "false"),
+					resource.TestCheckResourceAttrSet("replicated_channel.test", "id"),
+					resource.TestCheckResourceAttrPair("data.replicated_channel.test", "id", "replicated_channel.test", "id"),
+				),
+			},
+			{
+				ResourceName:      "replicated_channel.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["replicated_channel.test"]
+					if !ok {
+						return "", fmt.Errorf("replicated_channel.test not found in state")
+					}
+					return testAccAppID + "/" + rs.Primary.ID, nil
+				},
+			},
+			{
+				Config: testAccChannelResourceConfig(rName, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("replicated_channel.test", "semver_required", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccChannelResourceConfig(name string, semverRequired bool) string {
+	return fmt.Sprintf(`
+		resource "replicated_channel" "test" {
+			app_id          = %[2]q
+			name            = %[1]q
+			description     = "created by the acceptance tests"
+			semver_required = %[3]t
+		}
+
+		data "replicated_channel" "test" {
+			app_id = replicated_channel.test.app_id
+			name   = replicated_channel.test.name
+		}
+	`, name, testAccAppID, semverRequired)
+}
+
+func testChannelResourceModel(id string) ChannelResourceModel {
+	return ChannelResourceModel{
+		Id:                       types.StringValue(id),
+		AppId:                    types.StringValue("test-app"),
+		Name:                     types.StringValue("Beta"),
+		Description:              types.StringValue(""),
+		Slug:                     types.StringValue("beta"),
+		SemverRequired:           types.BoolValue(false),
+		IsDefault:                types.BoolValue(false),
+		BuildAirgapAutomatically: types.BoolValue(false),
+		CustomHostnames:          types.ObjectNull(customHostnamesAttrTypes),
+	}
+}
+
+func TestChannelResourceCreate(t *testing.T) {
+	tests := []struct {
+		name        string
+		plan        func(m *ChannelResourceModel)
+		setup       func(api *fakeVendorAPI)
+		wantDefault bool
+		wantErr     string
+		wantState   bool
+	}{
+		{
+			name: "channel",
+		},
+		{
+			name: "settings",
+			plan: func(m *ChannelResourceModel) {
+				m.SemverRequired = types.BoolValue(true)
+				m.BuildAirgapAutomatically = types.BoolValue(true)
+				m.CustomHostnames = types.ObjectValueMust(customHostnamesAttrTypes, map[string]attr.Value{
+					"registry":        types.StringValue("registry.example.com"),
+					"proxy":           types.StringNull(),
+					"download_portal": types.StringNull(),
+					"replicated_app":  types.StringNull(),
+				})
+			},
+		},
+		{
+			name: "default",
+			plan: func(m *ChannelResourceModel) {
+				m.IsDefault = types.BoolValue(true)
+			},
+			wantDefault: true,
+		},
+		{
+			name: "unknown app",
+			plan: func(m *ChannelResourceModel) {
+				m.AppId = types.StringValue("other-app")
+			},
+			wantErr: "Invalid App",
+		},
+		{
+			name: "api error",
+			setup: func(api *fakeVendorAPI) {
+				api.errs["CreateChannel"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+		{
+			name: "settings error",
+			setup: func(api *fakeVendorAPI) {
+				api.errs["UpdateAppChannel"] = errors.New("boom")
+			},
+			wantErr:   "Server Error",
+			wantState: true,
+		},
+		{
+			name: "default error",
+			plan: func(m *ChannelResourceModel) {
+				m.IsDefault = types.BoolValue(true)
+			},
+			setup: func(api *fakeVendorAPI) {
+				api.errs["SetDefaultChannel"] = errors.New("boom")
+			},
+			wantErr:   "Server Error",
+			wantState: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			if tt.setup != nil {
+				tt.setup(api)
+			}
+			r := NewChannelResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			plan := testChannelResourceModel("")
+			plan.Id = types.StringUnknown()
+			plan.Slug = types.StringUnknown()
+			if tt.plan != nil {
+				tt.plan(&plan)
+			}
+			resp := fwresource.CreateResponse{State: testState(t, s, nil)}
+			r.Create(context.Background(), fwresource.CreateRequest{Plan: testPlan(t, s, &plan)}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				assert.Equal(t, tt.wantState, !resp.State.Raw.IsNull())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var got ChannelResourceModel
+			require.False(t, resp.State.Get(context.Background(), &got).HasError())
+			assert.True(t, strings.HasPrefix(got.Id.ValueString(), "channel-"))
+			assert.Equal(t, "test-app", got.AppId.ValueString())
+			assert.Equal(t, "beta", got.Slug.ValueString())
+			assert.Equal(t, plan.SemverRequired, got.SemverRequired)
+			assert.Equal(t, plan.BuildAirgapAutomatically, got.BuildAirgapAutomatically)
+			assert.Equal(t, plan.CustomHostnames, got.CustomHostnames)
+			assert.Equal(t, tt.wantDefault, got.IsDefault.ValueBool())
+
+			channel, err := api.GetAppChannel(testFakeAppID, got.Id.ValueString())
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDefault, channel.IsDefault)
+		})
+	}
+}
+
+func TestChannelResourceRead(t *testing.T) {
+	tests := []struct {
+		name        string
+		setup       func(api *fakeVendorAPI, m *ChannelResourceModel)
+		wantRemoved bool
+		wantErr     string
+	}{
+		{
+			name: "channel",
+		},
+		{
+			name: "archived channel",
+			setup: func(api *fakeVendorAPI, m *ChannelResourceModel) {
+				require.NoError(t, api.ArchiveChannel(testFakeAppID, m.Id.ValueString()))
+			},
+			wantRemoved: true,
+		},
+		{
+			name: "missing channel",
+			setup: func(api *fakeVendorAPI, m *ChannelResourceModel) {
+				m.Id = types.StringValue("missing")
+			},
+			wantRemoved: true,
+		},
+		{
+			name: "imported channel",
+			setup: func(api *fakeVendorAPI, m *ChannelResourceModel) {
+				*m = ChannelResourceModel{
+					Id:                       m.Id,
+					AppId:                    m.AppId,
+					Name:                     types.StringNull(),
+					Description:              types.StringNull(),
+					Slug:                     types.StringNull(),
+					SemverRequired:           types.BoolNull(),
+					IsDefault:                types.BoolNull(),
+					BuildAirgapAutomatically: types.BoolNull(),
+					CustomHostnames:          types.ObjectNull(customHostnamesAttrTypes),
+				}
+			},
+		},
+		{
+			name: "api error",
+			setup: func(api *fakeVendorAPI, m *ChannelResourceModel) {
+				api.errs["GetAppChannel"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			channel, err := api.CreateChannel(testFakeAppID, "Beta", "")
+			require.NoError(t, err)
+			prior := testChannelResourceModel(channel.ID)
+			if tt.setup != nil {
+				tt.setup(api, &prior)
+			}
+			r := NewChannelResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			state := testState(t, s, &prior)
+			resp := fwresource.ReadResponse{State: state}
+			r.Read(context.Background(), fwresource.ReadRequest{State: state}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			if tt.wantRemoved {
+				assert.True(t, resp.State.Raw.IsNull())
+				return
+			}
+
+			var got ChannelResourceModel
+			require.False(t, resp.State.Get(context.Background(), &got).HasError())
+			assert.Equal(t, testChannelResourceModel(channel.ID), got)
+		})
+	}
+}
+
+func TestChannelResourceUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		prior   func(m *ChannelResourceModel)
+		plan    func(m *ChannelResourceModel)
+		setup   func(api *fakeVendorAPI, channelID string)
+		wantErr string
+	}{
+		{
+			name: "settings",
+			plan: func(m *ChannelResourceModel) {
+				m.Name = types.StringValue("Beta 2")
+				m.Description = types.StringValue("beta releases")
+				m.SemverRequired = types.BoolValue(true)
+			},
+		},
+		{
+			name: "make default",
+			plan: func(m *ChannelResourceModel) {
+				m.IsDefault = types.BoolValue(true)
+			},
+		},
+		{
+			name: "keep default",
+			prior: func(m *ChannelResourceModel) {
+				m.IsDefault = types.BoolValue(true)
+			},
+			plan: func(m *ChannelResourceModel) {
+				m.IsDefault = types.BoolValue(true)
+				m.SemverRequired = types.BoolValue(true)
+			},
+			setup: func(api *fakeVendorAPI, channelID string) {
+				require.NoError(t, api.SetDefaultChannel(testFakeAppID, channelID))
+				api.errs["SetDefaultChannel"] = errors.New("should not be called")
+			},
+		},
+		{
+			name: "api error",
+			setup: func(api *fakeVendorAPI, channelID string) {
+				api.errs["UpdateAppChannel"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			channel, err := api.CreateChannel(testFakeAppID, "Beta", "")
+			require.NoError(t, err)
+			if tt.setup != nil {
+				tt.setup(api, channel.ID)
+			}
+			r := NewChannelResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			prior := testChannelResourceModel(channel.ID)
+			if tt.prior != nil {
+				tt.prior(&prior)
+			}
+			plan := testChannelResourceModel(channel.ID)
+			if tt.plan != nil {
+				tt.plan(&plan)
+			}
+			resp := fwresource.UpdateResponse{State: testState(t, s, &prior)}
+			r.Update(context.Background(), fwresource.UpdateRequest{
+				Plan:  testPlan(t, s, &plan),
+				State: testState(t, s, &prior),
+			}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var got ChannelResourceModel
+			require.False(t, resp.State.Get(context.Background(), &got).HasError())
+			assert.Equal(t, plan, got)
+
+			updated, err := api.GetAppChannel(testFakeAppID, channel.ID)
+			require.NoError(t, err)
+			assert.Equal(t, plan.Name.ValueString(), updated.Name)
+			assert.Equal(t, plan.SemverRequired.ValueBool(), updated.SemverRequired)
+			assert.Equal(t, plan.IsDefault.ValueBool(), updated.IsDefault)
+		})
+	}
+}
+
+func TestChannelResourceModifyPlan(t *testing.T) {
+	tests := []struct {
+		name         string
+		priorDefault bool
+		planDefault  types.Bool
+		create       bool
+		wantErr      string
+	}{
+		{
+			name:         "make default",
+			priorDefault: false,
+			planDefault:  types.BoolValue(true),
+		},
+		{
+			name:         "keep default",
+			priorDefault: true,
+			planDefault:  types.BoolValue(true),
+		},
+		{
+			name:         "unset default",
+			priorDefault: true,
+			planDefault:  types.BoolValue(false),
+			wantErr:      "Invalid Default Channel",
+		},
+		{
+			name:         "unknown default",
+			priorDefault: true,
+			planDefault:  types.BoolUnknown(),
+		},
+		{
+			name:        "create",
+			planDefault: types.BoolValue(false),
+			create:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewChannelResource()
+			s := testResourceSchema(t, r)
+
+			prior := testChannelResourceModel("channel-1")
+			prior.IsDefault = types.BoolValue(tt.priorDefault)
+			plan := testChannelResourceModel("channel-1")
+			plan.IsDefault = tt.planDefault
+
+			state := testState(t, s, &prior)
+			if tt.create {
+				state = testState(t, s, nil)
+			}
+			resp := fwresource.ModifyPlanResponse{Plan: testPlan(t, s, &plan)}
+			r.(fwresource.ResourceWithModifyPlan).ModifyPlan(context.Background(), fwresource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: s, Raw: testState(t, s, &plan).Raw},
+				Plan:   testPlan(t, s, &plan),
+				State:  state,
+			}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "set is_default = true on another channel")
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+		})
+	}
+}
+
+func TestChannelResourceDelete(t *testing.T) {
+	tests := []struct {
+		name         string
+		setup        func(api *fakeVendorAPI, m *ChannelResourceModel)
+		wantArchived bool
+		wantErr      string
+	}{
+		{
+			name:         "channel",
+			wantArchived: true,
+		},
+		{
+			name: "missing channel",
+			setup: func(api *fakeVendorAPI, m *ChannelResourceModel) {
+				m.Id = types.StringValue("missing")
+			},
+		},
+		{
+			name: "default channel",
+			setup: func(api *fakeVendorAPI, m *ChannelResourceModel) {
+				require.NoError(t, api.SetDefaultChannel(testFakeAppID, m.Id.ValueString()))
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			channel, err := api.CreateChannel(testFakeAppID, "Beta", "")
+			require.NoError(t, err)
+			prior := testChannelResourceModel(channel.ID)
+			if tt.setup != nil {
+				tt.setup(api, &prior)
+			}
+			r := NewChannelResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			state := testState(t, s, &prior)
+			resp := fwresource.DeleteResponse{State: state}
+			r.Delete(context.Background(), fwresource.DeleteRequest{State: state}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			got, err := api.GetAppChannel(testFakeAppID, channel.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantArchived, got.IsArchived)
+		})
+	}
+}
+
+func TestChannelResourceImportState(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        string
+		wantAppID string
+		wantID    string
+		wantErr   string
+	}{
+		{
+			name:      "slug",
+			id:        "test-app/channel-1",
+			wantAppID: "test-app",
+			wantID:    "channel-1",
+		},
+		{
+			name:    "missing channel id",
+			id:      "test-app",
+			wantErr: "Unexpected Import Identifier",
+		},
+		{
+			name:    "empty app",
+			id:      "/channel-1",
+			wantErr: "Unexpected Import Identifier",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewChannelResource()
+			testConfiguredResource(t, r, newFakeVendorAPI())
+			s := testResourceSchema(t, r)
+
+			resp := fwresource.ImportStateResponse{State: testState(t, s, nil)}
+			r.(fwresource.ResourceWithImportState).ImportState(context.Background(), fwresource.ImportStateRequest{ID: tt.id}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var appID, id string
+			require.False(t, resp.State.GetAttribute(context.Background(), path.Root("app_id"), &appID).HasError())
+			require.False(t, resp.State.GetAttribute(context.Background(), path.Root("id"), &id).HasError())
+			assert.Equal(t, tt.wantAppID, appID)
+			assert.Equal(t, tt.wantID, id)
+		})
+	}
+}
@@ -27,7 +27,7 @@ func NewClusterResource() resource.Resource {
 
 // ClusterResource defines the resource implementation.
 type ClusterResource struct {
-	client *kotsclient.VendorV3Client
+	client VendorAPI
 }
 
 // ClusterResourceModel describes the resource data model.
@@ -113,13 +113,13 @@ func (r *ClusterResource) Configure(ctx context.Context, req resource.ConfigureR
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *kotsclient.VendorV3Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ReplicatedProviderClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = &client.kotsVendorV3Client
+	r.client = client.vendorAPI
 }
 
 func (r *ClusterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -316,7 +316,7 @@ func (r *ClusterResource) ImportState(ctx context.Context, req resource.ImportSt
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
-func waitForCluster(kotsRestClient *kotsclient.VendorV3Client, id string, duration time.Duration) (*rtypes.Cluster, error) {
+func waitForCluster(kotsRestClient VendorAPI, id string, duration time.Duration) (*rtypes.Cluster, error) {
 	start := time.Now()
 	for {
 		cluster, err := kotsRestClient.GetCluster(id)
@@ -1,21 +1,34 @@
 package provider
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"testing"
 
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/replicatedhq/replicated/pkg/kotsclient"
+	rtypes "github.com/replicatedhq/replicated/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccClusterResource(t *testing.T) {
+	testAccVendorAPI(t)
+	rName := acctest.RandomWithPrefix(testAccResourcePrefix)
+
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
-				Config: testAccClusterResourceConfig("kind"),
+				Config: testAccClusterResourceConfig(rName, "kind"),
 				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("replicated_cluster.test", "name", rName),
 					resource.TestCheckResourceAttr("replicated_cluster.test", "distribution", "kind"),
 					resource.TestCheckResourceAttrSet("replicated_cluster.test", "version"),
 					resource.TestCheckResourceAttrSet("replicated_cluster.test", "id"),
@@ -29,7 +42,7 @@ func TestAccClusterResource(t *testing.T) {
 			},
 			// Update and Read testing
 			{
-				Config: testAccClusterResourceConfig("kind"),
+				Config: testAccClusterResourceConfig(rName, "kind"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("replicated_cluster.test", "distribution", "kind"),
 				),
@@ -39,10 +52,325 @@ func TestAccClusterResource(t *testing.T) {
 	})
 }
 
-func testAccClusterResourceConfig(distribution string) string {
+func testAccClusterResourceConfig(name string, distribution string) string {
 	return fmt.Sprintf(`
 resource "replicated_cluster" "test" {
-  distribution = %[1]q
+  name         = %[1]q
+  distribution = %[2]q
+}
+`, name, distribution)
+}
+
+func testClusterPlanModel() ClusterResourceModel {
+	return ClusterResourceModel{
+		Id:           types.StringUnknown(),
+		Name:         types.StringUnknown(),
+		Distribution: types.StringValue("kind"),
+		Version:      types.StringUnknown(),
+		InstanceType: types.StringUnknown(),
+		Disk:         types.Int64Unknown(),
+		Nodes:        types.Int64Unknown(),
+		TTL:          types.StringNull(),
+		WaitDuration: types.StringNull(),
+		Kubeconfig:   types.StringUnknown(),
+	}
 }
-`, distribution)
+
+func TestClusterResourceCreate(t *testing.T) {
+	tests := []struct {
+		name           string
+		plan           func(m *ClusterResourceModel)
+		setup          func(api *fakeVendorAPI)
+		wantErr        string
+		wantKubeconfig bool
+		check          func(t *testing.T, api *fakeVendorAPI, m ClusterResourceModel)
+	}{
+		{
+			name: "defaults",
+			check: func(t *testing.T, api *fakeVendorAPI, m ClusterResourceModel) {
+				assert.Equal(t, kotsclient.CreateClusterOpts{KubernetesDistribution: "kind"}, *api.lastCreateClusterOpts)
+				assert.Equal(t, "1.30.0", m.Version.ValueString())
+				assert.Equal(t, int64(1), m.Nodes.ValueInt64())
+				assert.Equal(t, int64(50), m.Disk.ValueInt64())
+				assert.Equal(t, "r1.small", m.InstanceType.ValueString())
+				assert.Equal(t, "", m.Kubeconfig.ValueString())
+			},
+		},
+		{
+			name: "all options",
+			plan: func(m *ClusterResourceModel) {
+				m.Name = types.StringValue("my-cluster")
+				m.Version = types.StringValue("1.29.0")
+				m.InstanceType = types.StringValue("r1.large")
+				m.Disk = types.Int64Value(100)
+				m.Nodes = types.Int64Value(3)
+				m.TTL = types.StringValue("2h")
+			},
+			check: func(t *testing.T, api *fakeVendorAPI, m ClusterResourceModel) {
+				assert.Equal(t, kotsclient.CreateClusterOpts{
+					Name:                   "my-cluster",
+					KubernetesDistribution: "kind",
+					KubernetesVersion:      "1.29.0",
+					InstanceType:           "r1.large",
+					DiskGiB:                100,
+					NodeCount:              3,
+					TTL:                    "2h",
+				}, *api.lastCreateClusterOpts)
+				assert.Equal(t, "my-cluster", m.Name.ValueString())
+				assert.Equal(t, int64(3), m.Nodes.ValueInt64())
+			},
+		},
+		{
+			name: "wait for running cluster",
+			plan: func(m *ClusterResourceModel) {
+				m.WaitDuration = types.StringValue("1m")
+			},
+			check: func(t *testing.T, api *fakeVendorAPI, m ClusterResourceModel) {
+				assert.Equal(t, "kubeconfig-"+m.Id.ValueString(), m.Kubeconfig.ValueString())
+			},
+		},
+		{
+			name: "invalid wait duration",
+			plan: func(m *ClusterResourceModel) {
+				m.WaitDuration = types.StringValue("soon")
+			},
+			wantErr: "Invalid wait duration",
+		},
+		{
+			name: "invalid ttl",
+			plan: func(m *ClusterResourceModel) {
+				m.TTL = types.StringValue("forever")
+			},
+			wantErr: "Invalid ttl",
+		},
+		{
+			name: "api error",
+			setup: func(api *fakeVendorAPI) {
+				api.errs["CreateCluster"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+		{
+			name: "validation error",
+			setup: func(api *fakeVendorAPI) {
+				api.clusterValidationError = &kotsclient.CreateClusterErrorError{Message: "unsupported distribution"}
+			},
+			wantErr: "Validation Error",
+		},
+		{
+			name: "cluster failed to provision",
+			plan: func(m *ClusterResourceModel) {
+				m.WaitDuration = types.StringValue("1m")
+			},
+			setup: func(api *fakeVendorAPI) {
+				api.clusterStatus = rtypes.ClusterStatusError
+			},
+			wantErr: "Server Error",
+		},
+		{
+			name: "kubeconfig error",
+			plan: func(m *ClusterResourceModel) {
+				m.WaitDuration = types.StringValue("1m")
+			},
+			setup: func(api *fakeVendorAPI) {
+				api.errs["GetClusterKubeconfig"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			if tt.setup != nil {
+				tt.setup(api)
+			}
+			r := NewClusterResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			plan := testClusterPlanModel()
+			if tt.plan != nil {
+				tt.plan(&plan)
+			}
+
+			resp := fwresource.CreateResponse{State: testState(t, s, nil)}
+			r.Create(context.Background(), fwresource.CreateRequest{Plan: testPlan(t, s, &plan)}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var got ClusterResourceModel
+			require.False(t, resp.State.Get(context.Background(), &got).HasError())
+			assert.Contains(t, api.clusters, got.Id.ValueString())
+			assert.Equal(t, "kind", got.Distribution.ValueString())
+			if tt.check != nil {
+				tt.check(t, api, got)
+			}
+		})
+	}
+}
+
+func TestClusterResourceRead(t *testing.T) {
+	tests := []struct {
+		name        string
+		setup       func(api *fakeVendorAPI) string
+		wantErr     string
+		wantRemoved bool
+		check       func(t *testing.T, m ClusterResourceModel)
+	}{
+		{
+			name: "running cluster",
+			setup: func(api *fakeVendorAPI) string {
+				cl, _, _ := api.CreateCluster(kotsclient.CreateClusterOpts{Name: "my-cluster", KubernetesDistribution: "k3s"})
+				return cl.ID
+			},
+			check: func(t *testing.T, m ClusterResourceModel) {
+				assert.Equal(t, "my-cluster", m.Name.ValueString())
+				assert.Equal(t, "k3s", m.Distribution.ValueString())
+				assert.Equal(t, "kubeconfig-"+m.Id.ValueString(), m.Kubeconfig.ValueString())
+			},
+		},
+		{
+			name: "provisioning cluster",
+			setup: func(api *fakeVendorAPI) string {
+				api.clusterStatus = rtypes.ClusterStatusProvisioning
+				cl, _, _ := api.CreateCluster(kotsclient.CreateClusterOpts{KubernetesDistribution: "kind"})
+				return cl.ID
+			},
+			check: func(t *testing.T, m ClusterResourceModel) {
+				assert.Equal(t, "", m.Kubeconfig.ValueString())
+			},
+		},
+		{
+			name: "not found",
+			setup: func(api *fakeVendorAPI) string {
+				return "missing"
+			},
+			wantRemoved: true,
+		},
+		{
+			name: "api error",
+			setup: func(api *fakeVendorAPI) string {
+				api.errs["GetCluster"] = errors.New("boom")
+				return "cluster-1"
+			},
+			wantErr: "Server Error",
+		},
+		{
+			name: "kubeconfig error",
+			setup: func(api *fakeVendorAPI) string {
+				cl, _, _ := api.CreateCluster(kotsclient.CreateClusterOpts{KubernetesDistribution: "kind"})
+				api.errs["GetClusterKubeconfig"] = errors.New("boom")
+				return cl.ID
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			id := tt.setup(api)
+			r := NewClusterResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			prior := ClusterResourceModel{
+				Id:           types.StringValue(id),
+				Distribution: types.StringValue("kind"),
+			}
+			state := testState(t, s, &prior)
+			resp := fwresource.ReadResponse{State: state}
+			r.Read(context.Background(), fwresource.ReadRequest{State: state}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			if tt.wantRemoved {
+				assert.True(t, resp.State.Raw.IsNull())
+				return
+			}
+
+			var got ClusterResourceModel
+			require.False(t, resp.State.Get(context.Background(), &got).HasError())
+			assert.Equal(t, id, got.Id.ValueString())
+			if tt.check != nil {
+				tt.check(t, got)
+			}
+		})
+	}
+}
+
+func TestClusterResourceUpdate(t *testing.T) {
+	api := newFakeVendorAPI()
+	r := NewClusterResource()
+	testConfiguredResource(t, r, api)
+	s := testResourceSchema(t, r)
+
+	planned := ClusterResourceModel{
+		Id:           types.StringValue("cluster-1"),
+		Distribution: types.StringValue("kind"),
+		TTL:          types.StringValue("4h"),
+	}
+	resp := fwresource.UpdateResponse{State: testState(t, s, nil)}
+	r.Update(context.Background(), fwresource.UpdateRequest{Plan: testPlan(t, s, &planned)}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	var got ClusterResourceModel
+	require.False(t, resp.State.Get(context.Background(), &got).HasError())
+	assert.Equal(t, planned, got)
+}
+
+func TestClusterResourceDelete(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(api *fakeVendorAPI) string
+		wantErr string
+	}{
+		{
+			name: "existing cluster",
+			setup: func(api *fakeVendorAPI) string {
+				cl, _, _ := api.CreateCluster(kotsclient.CreateClusterOpts{KubernetesDistribution: "kind"})
+				return cl.ID
+			},
+		},
+		{
+			name: "api error",
+			setup: func(api *fakeVendorAPI) string {
+				cl, _, _ := api.CreateCluster(kotsclient.CreateClusterOpts{KubernetesDistribution: "kind"})
+				api.errs["RemoveCluster"] = errors.New("boom")
+				return cl.ID
+			},
+			wantErr: "Client Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			id := tt.setup(api)
+			r := NewClusterResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			state := testState(t, s, &ClusterResourceModel{Id: types.StringValue(id), Distribution: types.StringValue("kind")})
+			resp := fwresource.DeleteResponse{State: state}
+			r.Delete(context.Background(), fwresource.DeleteRequest{State: state}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				assert.Contains(t, api.clusters, id)
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+			assert.NotContains(t, api.clusters, id)
+		})
+	}
 }
@@ -0,0 +1,506 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/replicatedhq/replicated/pkg/kotsclient"
+	rtypes "github.com/replicatedhq/replicated/pkg/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CustomerDataSource{}
+var _ datasource.DataSourceWithConfigure = &CustomerDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &CustomerDataSource{}
+
+func NewCustomerDataSource() datasource.DataSource {
+	return &CustomerDataSource{}
+}
+
+// CustomerDataSource defines the data source implementation.
+type CustomerDataSource struct {
+	client        VendorAPI
+	appResolver   *appResolver
+	licenseFields *licenseFieldCache
+}
+
+// CustomerDataModel describes a customer read by the customer data sources,
+// with the attributes of the customer resource.
+type CustomerDataModel struct {
+	Id                                types.String `tfsdk:"id"`
+	CustomerId                        types.String `tfsdk:"customer_id"`
+	AppId                             types.String `tfsdk:"app_id"`
+	Name                              types.String `tfsdk:"name"`
+	Email                             types.String `tfsdk:"email"`
+	CustomId                          types.String `tfsdk:"custom_id"`
+	Type                              types.String `tfsdk:"type"`
+	ChannelId                         types.String `tfsdk:"channel_id"`
+	Channels                          types.Set    `tfsdk:"channels"`
+	ExpiresAt                         types.String `tfsdk:"expires_at"`
+	EntitlementValues                 types.Map    `tfsdk:"entitlement_values"`
+	SecretEntitlementValues           types.Map    `tfsdk:"secret_entitlement_values"`
+	DefaultEntitlementValues          types.Map    `tfsdk:"default_entitlement_values"`
+	IsAirgapEnabled                   types.Bool   `tfsdk:"is_airgap_enabled"`
+	IsDevModeEnabled                  types.Bool   `tfsdk:"is_dev_mode_enabled"`
+	IsDisasterRecoverySupported       types.Bool   `tfsdk:"is_disaster_recovery_supported"`
+	IsEmbeddedClusterDownloadEnabled  types.Bool   `tfsdk:"is_embedded_cluster_download_enabled"`
+	IsEmbeddedClusterMultiNodeEnabled types.Bool   `tfsdk:"is_embedded_cluster_multinode_enabled"`
+	IsGeoaxisSupported                types.Bool   `tfsdk:"is_geoaxis_supported"`
+	IsGitopsSupported                 types.Bool   `tfsdk:"is_gitops_supported"`
+	IsHelmInstallEnabled              types.Bool   `tfsdk:"is_helm_install_enabled"`
+	IsHelmVMDownloadEnabled           types.Bool   `tfsdk:"is_helm_vm_download_enabled"`
+	IsIdentityServiceSupported        types.Bool   `tfsdk:"is_identity_service_supported"`
+	IsInstallerSupportEnabled         types.Bool   `tfsdk:"is_installer_support_enabled"`
+	IsKotsInstallEnabled              types.Bool   `tfsdk:"is_kots_install_enabled"`
+	IsKurlInstallEnabled              types.Bool   `tfsdk:"is_kurl_install_enabled"`
+	IsSnapshotSupported               types.Bool   `tfsdk:"is_snapshot_supported"`
+	IsSupportBundleUploadEnabled      types.Bool   `tfsdk:"is_support_bundle_upload_enabled"`
+	LicenseId                         types.String `tfsdk:"license_id"`
+	InstallationId                    types.String `tfsdk:"installation_id"`
+	CreatedAt                         types.String `tfsdk:"created_at"`
+	UpdatedAt                         types.String `tfsdk:"updated_at"`
+	RegistryCredentials               types.Object `tfsdk:"registry_credentials"`
+	Archived                          types.Bool   `tfsdk:"archived"`
+}
+
+var customerDataAttrTypes = map[string]attr.Type{
+	"id":                                    types.StringType,
+	"customer_id":                           types.StringType,
+	"app_id":                                types.StringType,
+	"name":                                  types.StringType,
+	"email":                                 types.StringType,
+	"custom_id":                             types.StringType,
+	"type":                                  types.StringType,
+	"channel_id":                            types.StringType,
+	"channels":                              types.SetType{ElemType: types.ObjectType{AttrTypes: customerChannelAttrTypes}},
+	"expires_at":                            types.StringType,
+	"entitlement_values":                    types.MapType{ElemType: types.StringType},
+	"secret_entitlement_values":             types.MapType{ElemType: types.StringType},
+	"default_entitlement_values":            types.MapType{ElemType: types.StringType},
+	"is_airgap_enabled":                     types.BoolType,
+	"is_dev_mode_enabled":                   types.BoolType,
+	"is_disaster_recovery_supported":        types.BoolType,
+	"is_embedded_cluster_download_enabled":  types.BoolType,
+	"is_embedded_cluster_multinode_enabled": types.BoolType,
+	"is_geoaxis_supported":                  types.BoolType,
+	"is_gitops_supported":                   types.BoolType,
+	"is_helm_install_enabled":               types.BoolType,
+	"is_helm_vm_download_enabled":           types.BoolType,
+	"is_identity_service_supported":         types.BoolType,
+	"is_installer_support_enabled":          types.BoolType,
+	"is_kots_install_enabled":               types.BoolType,
+	"is_kurl_install_enabled":               types.BoolType,
+	"is_snapshot_supported":                 types.BoolType,
+	"is_support_bundle_upload_enabled":      types.BoolType,
+	"license_id":                            types.StringType,
+	"installation_id":                       types.StringType,
+	"created_at":                            types.StringType,
+	"updated_at":                            types.StringType,
+	"registry_credentials":                  types.ObjectType{AttrTypes: registryCredentialsAttrTypes},
+	"archived":                              types.BoolType,
+}
+
+// customerDataAttributes returns the schema of a customer read by the
+// customer data sources, all attributes are computed.
+func customerDataAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "ID of the customer, in the same format as the ID of `replicated_customer`: `app/<app_id>/customer/<customer_id>`",
+			Computed:            true,
+		},
+		"customer_id": schema.StringAttribute{
+			MarkdownDescription: "ID of the customer in the vendor API",
+			Computed:            true,
+		},
+		"app_id": schema.StringAttribute{
+			MarkdownDescription: "ID of the app to which the customer belongs",
+			Computed:            true,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "Name of the customer",
+			Computed:            true,
+		},
+		"email": schema.StringAttribute{
+			MarkdownDescription: "Email of the customer",
+			Computed:            true,
+		},
+		"custom_id": schema.StringAttribute{
+			MarkdownDescription: "Custom ID of the customer",
+			Computed:            true,
+		},
+		"type": schema.StringAttribute{
+			MarkdownDescription: "Type of the customer",
+			Computed:            true,
+		},
+		"channel_id": schema.StringAttribute{
+			MarkdownDescription: "Default channel of the customer license",
+			Computed:            true,
+		},
+		"channels": schema.SetNestedAttribute{
+			MarkdownDescription: "Channels the customer license has access to",
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id": schema.StringAttribute{
+						MarkdownDescription: "ID of the channel",
+						Computed:            true,
+					},
+					"is_default": schema.BoolAttribute{
+						MarkdownDescription: "Is this the default channel of the customer license",
+						Computed:            true,
+					},
+					"pinned_release_sequence": schema.Int64Attribute{
+						MarkdownDescription: "Channel sequence the customer license is pinned to, not returned by the Vendor API",
+						Computed:            true,
+					},
+				},
+			},
+		},
+		"expires_at": schema.StringAttribute{
+			MarkdownDescription: "Expiration date of the customer license, not set when the license does not expire",
+			Computed:            true,
+		},
+		"entitlement_values": schema.MapAttribute{
+			MarkdownDescription: "Values of the app's license fields set for the customer",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+		"secret_entitlement_values": schema.MapAttribute{
+			MarkdownDescription: "Values of the app's secret license fields set for the customer",
+			ElementType:         types.StringType,
+			Computed:            true,
+			Sensitive:           true,
+		},
+		"default_entitlement_values": schema.MapAttribute{
+			MarkdownDescription: "Values of the license fields that are not set for the customer, as defined by the app. Secret fields are omitted",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+		"is_airgap_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Is airgap enabled for the customer license",
+			Computed:            true,
+		},
+		"is_dev_mode_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Is dev mode enabled for the customer license",
+			Computed:            true,
+		},
+		"is_disaster_recovery_supported": schema.BoolAttribute{
+			MarkdownDescription: "Is disaster recovery supported for the customer license",
+			Computed:            true,
+		},
+		"is_embedded_cluster_download_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Is embedded cluster download enabled for the customer license",
+			Computed:            true,
+		},
+		"is_embedded_cluster_multinode_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Is embedded cluster multinode enabled for the customer license",
+			Computed:            true,
+		},
+		"is_geoaxis_supported": schema.BoolAttribute{
+			MarkdownDescription: "Is geoaxis supported for the customer license",
+			Computed:            true,
+		},
+		"is_gitops_supported": schema.BoolAttribute{
+			MarkdownDescription: "Is gitops supported for the customer license",
+			Computed:            true,
+		},
+		"is_helm_install_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Is helm install enabled for the customer license",
+			Computed:            true,
+		},
+		"is_helm_vm_download_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Is helm vm download enabled for the customer license",
+			Computed:            true,
+		},
+		"is_identity_service_supported": schema.BoolAttribute{
+			MarkdownDescription: "Is identity service supported for the customer license",
+			Computed:            true,
+		},
+		"is_installer_support_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Is installer support enabled for the customer license",
+			Computed:            true,
+		},
+		"is_kots_install_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Is kots install enabled for the customer license",
+			Computed:            true,
+		},
+		"is_kurl_install_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Is kurl install enabled for the customer license",
+			Computed:            true,
+		},
+		"is_snapshot_supported": schema.BoolAttribute{
+			MarkdownDescription: "Is snapshot supported for the customer license",
+			Computed:            true,
+		},
+		"is_support_bundle_upload_enabled": schema.BoolAttribute{
+			MarkdownDescription: "Is support bundle upload enabled for the customer license",
+			Computed:            true,
+		},
+		"license_id": schema.StringAttribute{
+			MarkdownDescription: "ID of the customer license",
+			Computed:            true,
+			Sensitive:           true,
+		},
+		"installation_id": schema.StringAttribute{
+			MarkdownDescription: "Installation ID of the customer license, currently the same as `license_id`",
+			Computed:            true,
+			Sensitive:           true,
+		},
+		"created_at": schema.StringAttribute{
+			MarkdownDescription: "Creation time of the customer",
+			Computed:            true,
+		},
+		"updated_at": schema.StringAttribute{
+			MarkdownDescription: "Last update time of the customer",
+			Computed:            true,
+		},
+		"registry_credentials": schema.SingleNestedAttribute{
+			MarkdownDescription: "Credentials of the customer for the Replicated registry and proxy, e.g. for `helm registry login`",
+			Computed:            true,
+			Attributes: map[string]schema.Attribute{
+				"username": schema.StringAttribute{
+					MarkdownDescription: "Username, the email of the customer",
+					Computed:            true,
+				},
+				"password": schema.StringAttribute{
+					MarkdownDescription: "Password, the license ID of the customer",
+					Computed:            true,
+					Sensitive:           true,
+				},
+			},
+		},
+		"archived": schema.BoolAttribute{
+			MarkdownDescription: "Is the customer archived",
+			Computed:            true,
+		},
+	}
+}
+
+// readCustomerData returns the data source model of a customer of the app
+// from the customer, its details and the license fields of the app.
+func readCustomerData(ctx context.Context, appID string, customer *rtypes.Customer, details *CustomerDetails, fields []LicenseField, archived bool) (CustomerDataModel, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	m := getCustomerResourceModelFromCustomer(appID, customer)
+	m.applyEntitlementValues(customer, fields, types.MapNull(types.StringType), types.MapNull(types.StringType))
+	diags.Append(m.applyDetails(ctx, details)...)
+	if diags.HasError() {
+		return CustomerDataModel{}, diags
+	}
+
+	expiresAt := types.StringNull()
+	if !m.ExpiresAt.IsNull() {
+		expiresAt = types.StringValue(m.ExpiresAt.ValueString())
+	}
+
+	return CustomerDataModel{
+		Id:                                m.Id,
+		CustomerId:                        types.StringValue(customer.ID),
+		AppId:                             types.StringValue(appID),
+		Name:                              m.Name,
+		Email:                             m.Email,
+		CustomId:                          m.CustomId,
+		Type:                              m.Type,
+		ChannelId:                         m.ChannelId,
+		Channels:                          m.Channels,
+		ExpiresAt:                         expiresAt,
+		EntitlementValues:                 m.EntitlementValues,
+		SecretEntitlementValues:           m.SecretEntitlementValues,
+		DefaultEntitlementValues:          m.DefaultEntitlementValues,
+		IsAirgapEnabled:                   m.IsAirgapEnabled,
+		IsDevModeEnabled:                  m.IsDevModeEnabled,
+		IsDisasterRecoverySupported:       m.IsDisasterRecoverySupported,
+		IsEmbeddedClusterDownloadEnabled:  m.IsEmbeddedClusterDownloadEnabled,
+		IsEmbeddedClusterMultiNodeEnabled: m.IsEmbeddedClusterMultiNodeEnabled,
+		IsGeoaxisSupported:                m.IsGeoaxisSupported,
+		IsGitopsSupported:                 m.IsGitopsSupported,
+		IsHelmInstallEnabled:              m.IsHelmInstallEnabled,
+		IsHelmVMDownloadEnabled:           m.IsHelmVMDownloadEnabled,
+		IsIdentityServiceSupported:        m.IsIdentityServiceSupported,
+		IsInstallerSupportEnabled:         m.IsInstallerSupportEnabled,
+		IsKotsInstallEnabled:              m.IsKotsInstallEnabled,
+		IsKurlInstallEnabled:              m.IsKurlInstallEnabled,
+		IsSnapshotSupported:               m.IsSnapshotSupported,
+		IsSupportBundleUploadEnabled:      m.IsSupportBundleUploadEnabled,
+		LicenseId:                         m.LicenseId,
+		InstallationId:                    m.InstallationId,
+		CreatedAt:                         m.CreatedAt,
+		UpdatedAt:                         m.UpdatedAt,
+		RegistryCredentials:               m.RegistryCredentials,
+		Archived:                          types.BoolValue(archived),
+	}, diags
+}
+
+func (d *CustomerDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_customer"
+}
+
+func (d *CustomerDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := customerDataAttributes()
+	attributes["app_id"] = schema.StringAttribute{
+		MarkdownDescription: "ID or slug of the app to which the customer belongs",
+		Required:            true,
+	}
+	attributes["customer_id"] = schema.StringAttribute{
+		MarkdownDescription: "ID of the customer to look up, or the ID of a `replicated_customer`. Exactly one of `customer_id`, `name` and `email` must be set",
+		Optional:            true,
+		Computed:            true,
+	}
+	attributes["name"] = schema.StringAttribute{
+		MarkdownDescription: "Name of the customer to look up",
+		Optional:            true,
+		Computed:            true,
+	}
+	attributes["email"] = schema.StringAttribute{
+		MarkdownDescription: "Email of the customer to look up",
+		Optional:            true,
+		Computed:            true,
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up an active customer of an app by its ID, name or email",
+		Attributes:          attributes,
+	}
+}
+
+func (d *CustomerDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("customer_id"),
+			path.MatchRoot("name"),
+			path.MatchRoot("email"),
+		),
+	}
+}
+
+func (d *CustomerDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*ReplicatedProviderClients)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ReplicatedProviderClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = clients.vendorAPI
+	d.appResolver = clients.appResolver
+	d.licenseFields = clients.licenseFields
+}
+
+func (d *CustomerDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CustomerDataModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appID, err := d.appResolver.resolveAppID(data.AppId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("app_id"), "Invalid App", fmt.Sprintf("Unable to resolve app, got error: %s", err))
+		return
+	}
+
+	var customer *rtypes.Customer
+	var lookup string
+	switch {
+	case !data.Email.IsNull():
+		lookup = data.Email.ValueString()
+		customer, err = d.findCustomerByEmail(appID, lookup)
+	case !data.Name.IsNull():
+		lookup = data.Name.ValueString()
+		customer, err = d.client.GetCustomerByNameOrId(appID, lookup)
+	default:
+		lookup = data.CustomerId.ValueString()
+		customerID := lookup
+		// accept the id of a replicated_customer resource as well
+		if strings.HasPrefix(customerID, "app/") {
+			_, resourceCustomerID, diags := parseCustomerResourceID(customerID)
+			if diags.HasError() {
+				resp.Diagnostics.AddAttributeError(path.Root("customer_id"), "Invalid Customer ID", fmt.Sprintf("Expected a customer id or a customer resource id, got: %q", customerID))
+				return
+			}
+			customerID = resourceCustomerID
+		}
+		customer, err = d.client.GetCustomerByNameOrId(appID, customerID)
+	}
+	if err != nil {
+		if errors.As(err, &kotsclient.ErrCustomerNotFound{}) {
+			resp.Diagnostics.AddError("Customer Not Found", fmt.Sprintf("No active customer of app %s matches %q.", data.AppId.ValueString(), lookup))
+			return
+		}
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to get customer, got error: %s", err))
+		return
+	}
+
+	fields, err := d.licenseFields.listLicenseFields(appID)
+	if err != nil {
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to list license fields, got error: %s", err))
+		return
+	}
+
+	details, err := d.client.GetCustomerDetails(customer.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to get customer, got error: %s", err))
+		return
+	}
+
+	configuredAppID, configuredCustomerID := data.AppId, data.CustomerId
+	data, diags := readCustomerData(ctx, appID, customer, details, fields, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.AppId = configuredAppID
+	if !configuredCustomerID.IsNull() {
+		data.CustomerId = configuredCustomerID
+	}
+
+	tflog.Trace(ctx, "read a customer")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// findCustomerByEmail returns the only active customer of the app with the
+// given email.
+func (d *CustomerDataSource) findCustomerByEmail(appID string, email string) (*rtypes.Customer, error) {
+	customers, err := d.client.ListCustomers(appID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var match *rtypes.Customer
+	for i := range customers {
+		if customers[i].Email != email {
+			continue
+		}
+		if match != nil {
+			return nil, fmt.Errorf("more than one customer has the email %q, look the customer up by id instead", email)
+		}
+		match = &customers[i]
+	}
+	if match == nil {
+		return nil, kotsclient.ErrCustomerNotFound{Name: email}
+	}
+
+	return match, nil
+}
@@ -0,0 +1,283 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/replicatedhq/replicated/pkg/kotsclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccCustomerDataSource(t *testing.T) {
+	testAccVendorAPI(t)
+	rName := acctest.RandomWithPrefix(testAccResourcePrefix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCustomerResourceConfig(rName) + `
+					data "replicated_customer" "test" {
+						app_id = replicated_customer.test.app_id
+						name   = replicated_customer.test.name
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.replicated_customer.test", "email", "replicated_customer.test", "email"),
+					resource.TestCheckResourceAttrPair("data.replicated_customer.test", "license_id", "replicated_customer.test", "license_id"),
+					resource.TestCheckResourceAttr("data.replicated_customer.test", "channel_id", testAccChannelID),
+					resource.TestCheckResourceAttr("data.replicated_customer.test", "archived", "false"),
+				),
+			},
+		},
+	})
+}
+
+// testCustomerDataModel returns a customer data source configuration with
+// every computed attribute unknown.
+func testCustomerDataModel() CustomerDataModel {
+	return CustomerDataModel{
+		Id:                                types.StringUnknown(),
+		CustomerId:                        types.StringNull(),
+		AppId:                             types.StringValue("test-app"),
+		Name:                              types.StringNull(),
+		Email:                             types.StringNull(),
+		CustomId:                          types.StringUnknown(),
+		Type:                              types.StringUnknown(),
+		ChannelId:                         types.StringUnknown(),
+		Channels:                          types.SetUnknown(types.ObjectType{AttrTypes: customerChannelAttrTypes}),
+		ExpiresAt:                         types.StringUnknown(),
+		EntitlementValues:                 types.MapUnknown(types.StringType),
+		SecretEntitlementValues:           types.MapUnknown(types.StringType),
+		DefaultEntitlementValues:          types.MapUnknown(types.StringType),
+		IsAirgapEnabled:                   types.BoolUnknown(),
+		IsDevModeEnabled:                  types.BoolUnknown(),
+		IsDisasterRecoverySupported:       types.BoolUnknown(),
+		IsEmbeddedClusterDownloadEnabled:  types.BoolUnknown(),
+		IsEmbeddedClusterMultiNodeEnabled: types.BoolUnknown(),
+		IsHelmInstallEnabled:              types.BoolUnknown(),
+		IsKurlInstallEnabled:              types.BoolUnknown(),
+		IsGeoaxisSupported:                types.BoolUnknown(),
+		IsGitopsSupported:                 types.BoolUnknown(),
+		IsHelmVMDownloadEnabled:           types.BoolUnknown(),
+		IsIdentityServiceSupported:        types.BoolUnknown(),
+		IsInstallerSupportEnabled:         types.BoolUnknown(),
+		IsKotsInstallEnabled:              types.BoolUnknown(),
+		IsSnapshotSupported:               types.BoolUnknown(),
+		IsSupportBundleUploadEnabled:      types.BoolUnknown(),
+		LicenseId:                         types.StringUnknown(),
+		InstallationId:                    types.StringUnknown(),
+		CreatedAt:                         types.StringUnknown(),
+		UpdatedAt:                         types.StringUnknown(),
+		RegistryCredentials:               types.ObjectUnknown(registryCredentialsAttrTypes),
+		Archived:                          types.BoolUnknown(),
+	}
+}
+
+func TestCustomerDataSourceRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  func(m *CustomerDataModel, customerID string)
+		setup   func(api *fakeVendorAPI)
+		wantErr string
+	}{
+		{
+			name: "customer id",
+			config: func(m *CustomerDataModel, customerID string) {
+				m.CustomerId = types.StringValue(customerID)
+			},
+		},
+		{
+			name: "customer resource id",
+			config: func(m *CustomerDataModel, customerID string) {
+				m.CustomerId = types.StringValue(formatCustomerResourceID("test-app", customerID))
+			},
+		},
+		{
+			name: "malformed customer resource id",
+			config: func(m *CustomerDataModel, customerID string) {
+				m.CustomerId = types.StringValue("app/test-app/customers/" + customerID)
+			},
+			wantErr: "Invalid Customer ID",
+		},
+		{
+			name: "name",
+			config: func(m *CustomerDataModel, customerID string) {
+				m.Name = types.StringValue("acme")
+			},
+		},
+		{
+			name: "email",
+			config: func(m *CustomerDataModel, customerID string) {
+				m.Email = types.StringValue("customer@example.com")
+			},
+		},
+		{
+			name: "ambiguous email",
+			config: func(m *CustomerDataModel, customerID string) {
+				m.Email = types.StringValue("customer@example.com")
+			},
+			setup: func(api *fakeVendorAPI) {
+				_, err := api.CreateCustomer(CreateCustomerOpts{CreateCustomerOpts: kotsclient.CreateCustomerOpts{Name: "acme-2", Email: "customer@example.com"}})
+				require.NoError(t, err)
+			},
+			wantErr: "Server Error",
+		},
+		{
+			name: "unknown name",
+			config: func(m *CustomerDataModel, customerID string) {
+				m.Name = types.StringValue("globex")
+			},
+			wantErr: "Customer Not Found",
+		},
+		{
+			name: "unknown email",
+			config: func(m *CustomerDataModel, customerID string) {
+				m.Email = types.StringValue("other@example.com")
+			},
+			wantErr: "Customer Not Found",
+		},
+		{
+			name: "archived customer",
+			config: func(m *CustomerDataModel, customerID string) {
+				m.CustomerId = types.StringValue(customerID)
+			},
+			setup: func(api *fakeVendorAPI) {
+				for id := range api.customers {
+					api.archived[id] = true
+				}
+			},
+			wantErr: "Customer Not Found",
+		},
+		{
+			name: "unknown app",
+			config: func(m *CustomerDataModel, customerID string) {
+				m.AppId = types.StringValue("other-app")
+				m.Name = types.StringValue("acme")
+			},
+			wantErr: "Invalid App",
+		},
+		{
+			name: "api error",
+			config: func(m *CustomerDataModel, customerID string) {
+				m.Name = types.StringValue("acme")
+			},
+			setup: func(api *fakeVendorAPI) {
+				api.errs["GetCustomerByNameOrId"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+		{
+			name: "details api error",
+			config: func(m *CustomerDataModel, customerID string) {
+				m.Name = types.StringValue("acme")
+			},
+			setup: func(api *fakeVendorAPI) {
+				api.errs["GetCustomerDetails"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			customer := testCustomerState(t, api)
+			_, customerID, _ := parseCustomerResourceID(customer.Id.ValueString())
+			if tt.setup != nil {
+				tt.setup(api)
+			}
+			d := NewCustomerDataSource()
+			testConfiguredDataSource(t, d, api)
+			s := testDataSourceSchema(t, d)
+
+			config := testCustomerDataModel()
+			tt.config(&config, customerID)
+
+			c, state := testDataSourceConfig(t, s, &config)
+			resp := datasource.ReadResponse{State: state}
+			d.Read(context.Background(), datasource.ReadRequest{Config: c}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var got CustomerDataModel
+			require.False(t, resp.State.Get(context.Background(), &got).HasError())
+			assert.Equal(t, customer.Id, got.Id)
+			if config.CustomerId.IsNull() {
+				assert.Equal(t, customerID, got.CustomerId.ValueString())
+			} else {
+				assert.Equal(t, config.CustomerId, got.CustomerId)
+			}
+			assert.Equal(t, "test-app", got.AppId.ValueString())
+			assert.Equal(t, "acme", got.Name.ValueString())
+			assert.Equal(t, "customer@example.com", got.Email.ValueString())
+			assert.Equal(t, "trial", got.Type.ValueString())
+			assert.Equal(t, "channel-1", got.ChannelId.ValueString())
+			assert.Equal(t, "2030-01-30T15:04:05Z", got.ExpiresAt.ValueString())
+			assert.Equal(t, customer.LicenseId, got.LicenseId)
+			assert.Equal(t, customer.CreatedAt, got.CreatedAt)
+			assert.Equal(t, customer.RegistryCredentials, got.RegistryCredentials)
+			assert.True(t, got.IsInstallerSupportEnabled.ValueBool())
+			assert.False(t, got.Archived.ValueBool())
+			assert.Equal(t, types.MapValueMust(types.StringType, map[string]attr.Value{
+				"seats": types.StringValue("10"),
+			}), got.EntitlementValues)
+		})
+	}
+}
+
+func TestCustomerDataSourceConfigValidators(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  func(m *CustomerDataModel)
+		wantErr bool
+	}{
+		{
+			name: "customer id",
+			config: func(m *CustomerDataModel) {
+				m.CustomerId = types.StringValue("customer-1")
+			},
+		},
+		{
+			name:    "nothing",
+			config:  func(m *CustomerDataModel) {},
+			wantErr: true,
+		},
+		{
+			name: "name and email",
+			config: func(m *CustomerDataModel) {
+				m.Name = types.StringValue("acme")
+				m.Email = types.StringValue("customer@example.com")
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &CustomerDataSource{}
+			s := testDataSourceSchema(t, d)
+
+			config := testCustomerDataModel()
+			tt.config(&config)
+			c, _ := testDataSourceConfig(t, s, &config)
+
+			resp := datasource.ValidateConfigResponse{}
+			for _, v := range d.ConfigValidators(context.Background()) {
+				v.ValidateDataSource(context.Background(), datasource.ValidateConfigRequest{Config: c}, &resp)
+			}
+			assert.Equal(t, tt.wantErr, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+		})
+	}
+}
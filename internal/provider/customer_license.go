@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// CustomerLicenseModel describes the data model shared by the customer
+// license data source and ephemeral resource.
+type CustomerLicenseModel struct {
+	AppId       types.String `tfsdk:"app_id"`
+	CustomerId  types.String `tfsdk:"customer_id"`
+	OutputPath  types.String `tfsdk:"output_path"`
+	License     types.String `tfsdk:"license"`
+	LicenseId   types.String `tfsdk:"license_id"`
+	ChannelId   types.String `tfsdk:"channel_id"`
+	ChannelName types.String `tfsdk:"channel_name"`
+	ExpiresAt   types.String `tfsdk:"expires_at"`
+}
+
+// licenseFile is the part of a kots.io/v1beta1 License the provider reads.
+type licenseFile struct {
+	Kind string `yaml:"kind"`
+	Spec struct {
+		LicenseID    string `yaml:"licenseID"`
+		ChannelID    string `yaml:"channelID"`
+		ChannelName  string `yaml:"channelName"`
+		Entitlements map[string]struct {
+			Value interface{} `yaml:"value"`
+		} `yaml:"entitlements"`
+	} `yaml:"spec"`
+}
+
+func parseLicense(data []byte) (*licenseFile, error) {
+	var license licenseFile
+	if err := yaml.Unmarshal(data, &license); err != nil {
+		return nil, errors.Wrap(err, "unmarshal license")
+	}
+
+	if license.Kind != "License" {
+		return nil, fmt.Errorf("expected a License, got kind %q", license.Kind)
+	}
+	if license.Spec.LicenseID == "" {
+		return nil, errors.New("license has no license id")
+	}
+
+	return &license, nil
+}
+
+// expiresAt returns the value of the expires_at entitlement, which is empty
+// for licenses that do not expire.
+func (l *licenseFile) expiresAt() string {
+	entitlement, ok := l.Spec.Entitlements["expires_at"]
+	if !ok || entitlement.Value == nil {
+		return ""
+	}
+	return fmt.Sprint(entitlement.Value)
+}
+
+// readCustomerLicense downloads the license of the customer in data and sets
+// the license attributes, writing the license to output_path when set.
+func readCustomerLicense(client VendorAPI, resolver *appResolver, data *CustomerLicenseModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	appIDOrSlug := data.AppId.ValueString()
+	customerID := data.CustomerId.ValueString()
+
+	// accept the id of a replicated_customer resource as well
+	if strings.HasPrefix(customerID, "app/") {
+		resourceAppID, resourceCustomerID, d := parseCustomerResourceID(customerID)
+		if d.HasError() {
+			diags.AddAttributeError(path.Root("customer_id"), "Invalid Customer ID", fmt.Sprintf("Expected a customer id or a customer resource id, got: %q", customerID))
+			return diags
+		}
+		customerID = resourceCustomerID
+		if appIDOrSlug == "" {
+			appIDOrSlug = resourceAppID
+		}
+	}
+
+	appID, err := resolver.resolveAppID(appIDOrSlug)
+	if err != nil {
+		diags.AddAttributeError(path.Root("app_id"), "Invalid App", fmt.Sprintf("Unable to resolve app, got error: %s", err))
+		return diags
+	}
+
+	licenseData, err := client.DownloadLicense(appID, customerID)
+	if err != nil {
+		diags.AddError("Server Error", fmt.Sprintf("Unable to download license, got error: %s", err))
+		return diags
+	}
+
+	license, err := parseLicense(licenseData)
+	if err != nil {
+		diags.AddError("Invalid License", fmt.Sprintf("Unable to parse the license of customer %s, got error: %s", customerID, err))
+		return diags
+	}
+
+	data.License = types.StringValue(string(licenseData))
+	data.LicenseId = types.StringValue(license.Spec.LicenseID)
+	data.ChannelId = types.StringValue(license.Spec.ChannelID)
+	data.ChannelName = types.StringValue(license.Spec.ChannelName)
+	data.ExpiresAt = types.StringNull()
+	if expiresAt := license.expiresAt(); expiresAt != "" {
+		data.ExpiresAt = types.StringValue(expiresAt)
+	}
+
+	if outputPath := data.OutputPath.ValueString(); outputPath != "" {
+		if err := os.WriteFile(outputPath, licenseData, 0600); err != nil {
+			diags.AddAttributeError(path.Root("output_path"), "Unable to Write License", fmt.Sprintf("Unable to write the license to %s, got error: %s", outputPath, err))
+			return diags
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CustomerLicenseDataSource{}
+var _ datasource.DataSourceWithConfigure = &CustomerLicenseDataSource{}
+
+func NewCustomerLicenseDataSource() datasource.DataSource {
+	return &CustomerLicenseDataSource{}
+}
+
+// CustomerLicenseDataSource defines the data source implementation.
+type CustomerLicenseDataSource struct {
+	client      VendorAPI
+	appResolver *appResolver
+}
+
+func (d *CustomerLicenseDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_customer_license"
+}
+
+func (d *CustomerLicenseDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Downloads the signed license file of a customer. Use the `replicated_customer_license` ephemeral resource instead to keep the license out of the Terraform state",
+
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
+				MarkdownDescription: "ID or slug of the app to which the customer belongs, defaults to the app of `customer_id` when it is a customer resource id",
+				Optional:            true,
+			},
+			"customer_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the customer, or ID of a `replicated_customer` resource",
+				Required:            true,
+			},
+			"output_path": schema.StringAttribute{
+				MarkdownDescription: "Path of a file the license is written to",
+				Optional:            true,
+			},
+			"license": schema.StringAttribute{
+				MarkdownDescription: "License file in YAML",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"license_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the license",
+				Computed:            true,
+			},
+			"channel_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the channel of the license",
+				Computed:            true,
+			},
+			"channel_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the channel of the license",
+				Computed:            true,
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "Expiration date of the license, not set when the license does not expire",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CustomerLicenseDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*ReplicatedProviderClients)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ReplicatedProviderClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = clients.vendorAPI
+	d.appResolver = clients.appResolver
+}
+
+func (d *CustomerLicenseDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CustomerLicenseModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(readCustomerLicense(d.client, d.appResolver, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "read a customer license")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccCustomerLicenseDataSource(t *testing.T) {
+	testAccVendorAPI(t)
+	rName := acctest.RandomWithPrefix(testAccResourcePrefix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCustomerResourceConfig(rName) + `
+					data "replicated_customer_license" "test" {
+						customer_id = replicated_customer.test.id
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.replicated_customer_license.test", "license_id"),
+					resource.TestCheckResourceAttr("data.replicated_customer_license.test", "channel_id", testAccChannelID),
+					resource.TestMatchResourceAttr("data.replicated_customer_license.test", "license", regexp.MustCompile("kind: License")),
+				),
+			},
+		},
+	})
+}
+
+// testCustomerLicenseModel returns the configuration of a customer license
+// for customerID.
+func testCustomerLicenseModel(customerID string) CustomerLicenseModel {
+	return CustomerLicenseModel{
+		AppId:       types.StringValue("test-app"),
+		CustomerId:  types.StringValue(customerID),
+		OutputPath:  types.StringNull(),
+		License:     types.StringUnknown(),
+		LicenseId:   types.StringUnknown(),
+		ChannelId:   types.StringUnknown(),
+		ChannelName: types.StringUnknown(),
+		ExpiresAt:   types.StringUnknown(),
+	}
+}
+
+func TestCustomerLicenseDataSourceRead(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     func(m *CustomerLicenseModel, customer CustomerResourceModel)
+		setup      func(api *fakeVendorAPI)
+		wantOutput bool
+		wantErr    string
+	}{
+		{
+			name: "customer id",
+		},
+		{
+			name: "customer resource id",
+			config: func(m *CustomerLicenseModel, customer CustomerResourceModel) {
+				m.AppId = types.StringNull()
+				m.CustomerId = customer.Id
+			},
+		},
+		{
+			name: "output path",
+			config: func(m *CustomerLicenseModel, customer CustomerResourceModel) {
+				m.OutputPath = types.StringValue(filepath.Join(t.TempDir(), "license.yaml"))
+			},
+			wantOutput: true,
+		},
+		{
+			name: "unwritable output path",
+			config: func(m *CustomerLicenseModel, customer CustomerResourceModel) {
+				m.OutputPath = types.StringValue(filepath.Join(t.TempDir(), "missing", "license.yaml"))
+			},
+			wantErr: "Unable to Write License",
+		},
+		{
+			name: "malformed customer resource id",
+			config: func(m *CustomerLicenseModel, customer CustomerResourceModel) {
+				m.CustomerId = types.StringValue("app/test-app/customers/acme")
+			},
+			wantErr: "Invalid Customer ID",
+		},
+		{
+			name: "unknown app",
+			config: func(m *CustomerLicenseModel, customer CustomerResourceModel) {
+				m.AppId = types.StringValue("other-app")
+			},
+			wantErr: "Invalid App",
+		},
+		{
+			name: "unknown customer",
+			config: func(m *CustomerLicenseModel, customer CustomerResourceModel) {
+				m.CustomerId = types.StringValue("customer-404")
+			},
+			wantErr: "Server Error",
+		},
+		{
+			name: "api error",
+			setup: func(api *fakeVendorAPI) {
+				api.errs["DownloadLicense"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			customer := testCustomerState(t, api)
+			if tt.setup != nil {
+				tt.setup(api)
+			}
+			d := NewCustomerLicenseDataSource()
+			testConfiguredDataSource(t, d, api)
+			s := testDataSourceSchema(t, d)
+
+			_, customerID, _ := parseCustomerResourceID(customer.Id.ValueString())
+			config := testCustomerLicenseModel(customerID)
+			if tt.config != nil {
+				tt.config(&config, customer)
+			}
+
+			c, state := testDataSourceConfig(t, s, &config)
+			resp := datasource.ReadResponse{State: state}
+			d.Read(context.Background(), datasource.ReadRequest{Config: c}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var got CustomerLicenseModel
+			require.False(t, resp.State.Get(context.Background(), &got).HasError())
+			assert.Equal(t, string(testLicenseYAML(api.customers[customerID])), got.License.ValueString())
+			assert.Equal(t, api.customers[customerID].InstallationID, got.LicenseId.ValueString())
+			assert.Equal(t, "channel-1", got.ChannelId.ValueString())
+			assert.Equal(t, "2030-01-30T15:04:05Z", got.ExpiresAt.ValueString())
+
+			if tt.wantOutput {
+				written, err := os.ReadFile(config.OutputPath.ValueString())
+				require.NoError(t, err)
+				assert.Equal(t, got.License.ValueString(), string(written))
+
+				info, err := os.Stat(config.OutputPath.ValueString())
+				require.NoError(t, err)
+				assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+			}
+		})
+	}
+}
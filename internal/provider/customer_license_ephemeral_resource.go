@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &CustomerLicenseEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &CustomerLicenseEphemeralResource{}
+
+func NewCustomerLicenseEphemeralResource() ephemeral.EphemeralResource {
+	return &CustomerLicenseEphemeralResource{}
+}
+
+// CustomerLicenseEphemeralResource defines the ephemeral resource
+// implementation. Unlike the data source, the license is never stored in the
+// Terraform state or plan.
+type CustomerLicenseEphemeralResource struct {
+	client      VendorAPI
+	appResolver *appResolver
+}
+
+func (e *CustomerLicenseEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_customer_license"
+}
+
+func (e *CustomerLicenseEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Downloads the signed license file of a customer without storing it in the Terraform state. Requires Terraform 1.10 or later",
+
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
+				MarkdownDescription: "ID or slug of the app to which the customer belongs, defaults to the app of `customer_id` when it is a customer resource id",
+				Optional:            true,
+			},
+			"customer_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the customer, or ID of a `replicated_customer` resource",
+				Required:            true,
+			},
+			"output_path": schema.StringAttribute{
+				MarkdownDescription: "Path of a file the license is written to",
+				Optional:            true,
+			},
+			"license": schema.StringAttribute{
+				MarkdownDescription: "License file in YAML",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"license_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the license",
+				Computed:            true,
+			},
+			"channel_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the channel of the license",
+				Computed:            true,
+			},
+			"channel_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the channel of the license",
+				Computed:            true,
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "Expiration date of the license, not set when the license does not expire",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (e *CustomerLicenseEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*ReplicatedProviderClients)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *ReplicatedProviderClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	e.client = clients.vendorAPI
+	e.appResolver = clients.appResolver
+}
+
+func (e *CustomerLicenseEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data CustomerLicenseModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(readCustomerLicense(e.client, e.appResolver, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "opened a customer license")
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
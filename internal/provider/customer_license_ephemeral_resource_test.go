@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomerLicenseEphemeralResourceOpen(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(api *fakeVendorAPI)
+		wantErr string
+	}{
+		{
+			name: "download",
+		},
+		{
+			name: "api error",
+			setup: func(api *fakeVendorAPI) {
+				api.errs["DownloadLicense"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			customer := testCustomerState(t, api)
+			if tt.setup != nil {
+				tt.setup(api)
+			}
+			e := NewCustomerLicenseEphemeralResource()
+			testConfiguredEphemeralResource(t, e, api)
+			s := testEphemeralResourceSchema(t, e)
+
+			_, customerID, _ := parseCustomerResourceID(customer.Id.ValueString())
+			config := testCustomerLicenseModel(customerID)
+
+			c, result := testEphemeralResourceConfig(t, s, &config)
+			resp := ephemeral.OpenResponse{Result: result}
+			e.Open(context.Background(), ephemeral.OpenRequest{Config: c}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var got CustomerLicenseModel
+			require.False(t, resp.Result.Get(context.Background(), &got).HasError())
+			assert.Equal(t, api.customers[customerID].InstallationID, got.LicenseId.ValueString())
+			assert.Equal(t, "channel-1", got.ChannelId.ValueString())
+			assert.Contains(t, got.License.ValueString(), "kind: License")
+		})
+	}
+}
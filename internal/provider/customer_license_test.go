@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLicense(t *testing.T) {
+	tests := []struct {
+		name          string
+		license       string
+		wantLicenseID string
+		wantChannelID string
+		wantExpiresAt string
+		wantErr       bool
+	}{
+		{
+			name: "expiring license",
+			license: `apiVersion: kots.io/v1beta1
+kind: License
+metadata:
+  name: acme
+spec:
+  licenseID: 2fvVJdHGMgS1fPFHYRYKKM3bLaV
+  channelID: 2fvVIfi3WTTAt3GpiKP8Fz86WuA
+  channelName: Stable
+  entitlements:
+    expires_at:
+      title: Expiration
+      value: "2030-01-30T15:04:05Z"
+      valueType: String
+    seats:
+      value: 10
+      valueType: Integer
+`,
+			wantLicenseID: "2fvVJdHGMgS1fPFHYRYKKM3bLaV",
+			wantChannelID: "2fvVIfi3WTTAt3GpiKP8Fz86WuA",
+			wantExpiresAt: "2030-01-30T15:04:05Z",
+		},
+		{
+			name: "license without expiration",
+			license: `apiVersion: kots.io/v1beta1
+kind: License
+spec:
+  licenseID: 2fvVJdHGMgS1fPFHYRYKKM3bLaV
+  channelID: 2fvVIfi3WTTAt3GpiKP8Fz86WuA
+  entitlements:
+    expires_at:
+      title: Expiration
+      valueType: String
+`,
+			wantLicenseID: "2fvVJdHGMgS1fPFHYRYKKM3bLaV",
+			wantChannelID: "2fvVIfi3WTTAt3GpiKP8Fz86WuA",
+		},
+		{
+			name:    "not a license",
+			license: "apiVersion: kots.io/v1beta1\nkind: Config\n",
+			wantErr: true,
+		},
+		{
+			name:    "missing license id",
+			license: "apiVersion: kots.io/v1beta1\nkind: License\nspec: {}\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid yaml",
+			license: "{",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			license, err := parseLicense([]byte(tt.license))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLicenseID, license.Spec.LicenseID)
+			assert.Equal(t, tt.wantChannelID, license.Spec.ChannelID)
+			assert.Equal(t, tt.wantExpiresAt, license.expiresAt())
+		})
+	}
+}
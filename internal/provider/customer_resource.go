@@ -2,14 +2,24 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/replicatedhq/replicated/pkg/kotsclient"
@@ -18,33 +28,90 @@ import (
 
 var _ resource.Resource = &CustomerResource{}
 var _ resource.ResourceWithImportState = &CustomerResource{}
+var _ resource.ResourceWithValidateConfig = &CustomerResource{}
+var _ resource.ResourceWithModifyPlan = &CustomerResource{}
 
 func NewCustomerResource() resource.Resource {
 	return &CustomerResource{}
 }
 
 type CustomerResource struct {
-	kotsClient *kotsclient.VendorV3Client
+	kotsClient    VendorAPI
+	appResolver   *appResolver
+	licenseFields *licenseFieldCache
 }
 
 type CustomerResourceModel struct {
-	Id                               types.String `tfsdk:"id"`
-	AppId                            types.String `tfsdk:"app_id"`
-	ChannelId                        types.String `tfsdk:"channel_id"`
-	Email                            types.String `tfsdk:"email"`
-	EntitlementValues                types.Map    `tfsdk:"entitlement_values"`
-	ExpiresAt                        types.String `tfsdk:"expires_at"`
-	IsAirgapEnabled                  types.Bool   `tfsdk:"is_airgap_enabled"`
-	IsEmbeddedClusterDownloadEnabled types.Bool   `tfsdk:"is_embedded_cluster_download_enabled"`
-	IsGeoaxisSupported               types.Bool   `tfsdk:"is_geoaxis_supported"`
-	IsGitopsSupported                types.Bool   `tfsdk:"is_gitops_supported"`
-	IsIdentityServiceSupported       types.Bool   `tfsdk:"is_identity_service_supported"`
-	IsInstallerSupportEnabled        types.Bool   `tfsdk:"is_installer_support_enabled"`
-	IsKotsInstallEnabled             types.Bool   `tfsdk:"is_kots_install_enabled"`
-	IsSnapshotSupported              types.Bool   `tfsdk:"is_snapshot_supported"`
-	IsSupportBundleUploadEnabled     types.Bool   `tfsdk:"is_support_bundle_upload_enabled"`
-	Name                             types.String `tfsdk:"name"`
-	Type                             types.String `tfsdk:"type"`
+	Id                                types.String   `tfsdk:"id"`
+	AppId                             types.String   `tfsdk:"app_id"`
+	ChannelId                         types.String   `tfsdk:"channel_id"`
+	Channels                          types.Set      `tfsdk:"channels"`
+	Email                             types.String   `tfsdk:"email"`
+	EntitlementValues                 types.Map      `tfsdk:"entitlement_values"`
+	SecretEntitlementValues           types.Map      `tfsdk:"secret_entitlement_values"`
+	DefaultEntitlementValues          types.Map      `tfsdk:"default_entitlement_values"`
+	ExpiresAt                         TimestampValue `tfsdk:"expires_at"`
+	ResolvedExpiresAt                 types.String   `tfsdk:"resolved_expires_at"`
+	ExpiryWarningDays                 types.Int64    `tfsdk:"expiry_warning_days"`
+	IsAirgapEnabled                   types.Bool     `tfsdk:"is_airgap_enabled"`
+	IsDevModeEnabled                  types.Bool     `tfsdk:"is_dev_mode_enabled"`
+	IsDisasterRecoverySupported       types.Bool     `tfsdk:"is_disaster_recovery_supported"`
+	IsEmbeddedClusterDownloadEnabled  types.Bool     `tfsdk:"is_embedded_cluster_download_enabled"`
+	IsEmbeddedClusterMultiNodeEnabled types.Bool     `tfsdk:"is_embedded_cluster_multinode_enabled"`
+	IsGeoaxisSupported                types.Bool     `tfsdk:"is_geoaxis_supported"`
+	IsGitopsSupported                 types.Bool     `tfsdk:"is_gitops_supported"`
+	IsHelmInstallEnabled              types.Bool     `tfsdk:"is_helm_install_enabled"`
+	IsHelmVMDownloadEnabled           types.Bool     `tfsdk:"is_helm_vm_download_enabled"`
+	IsIdentityServiceSupported        types.Bool     `tfsdk:"is_identity_service_supported"`
+	IsInstallerSupportEnabled         types.Bool     `tfsdk:"is_installer_support_enabled"`
+	IsKotsInstallEnabled              types.Bool     `tfsdk:"is_kots_install_enabled"`
+	IsKurlInstallEnabled              types.Bool     `tfsdk:"is_kurl_install_enabled"`
+	IsSnapshotSupported               types.Bool     `tfsdk:"is_snapshot_supported"`
+	IsSupportBundleUploadEnabled      types.Bool     `tfsdk:"is_support_bundle_upload_enabled"`
+	Name                              types.String   `tfsdk:"name"`
+	CustomId                          types.String   `tfsdk:"custom_id"`
+	Type                              types.String   `tfsdk:"type"`
+	LicenseId                         types.String   `tfsdk:"license_id"`
+	InstallationId                    types.String   `tfsdk:"installation_id"`
+	CreatedAt                         types.String   `tfsdk:"created_at"`
+	UpdatedAt                         types.String   `tfsdk:"updated_at"`
+	RegistryCredentials               types.Object   `tfsdk:"registry_credentials"`
+	DeletionPolicy                    types.String   `tfsdk:"deletion_policy"`
+	UnarchiveOnCreate                 types.Bool     `tfsdk:"unarchive_on_create"`
+	RequireUniqueName                 types.Bool     `tfsdk:"require_unique_name"`
+}
+
+const (
+	// customerDeletionPolicyArchive archives the customer on destroy.
+	customerDeletionPolicyArchive = "archive"
+	// customerDeletionPolicyRetain only removes the customer from the state.
+	customerDeletionPolicyRetain = "retain"
+)
+
+// customerExpiryWarningDays is the default of expiry_warning_days.
+const customerExpiryWarningDays = 30
+
+// customerTypes are the license types of a customer.
+var customerTypes = []string{"dev", "trial", "paid", "community", "test"}
+
+// emailRegexp only catches obvious mistakes, the vendor api has the final say.
+var emailRegexp = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+type CustomerChannelModel struct {
+	Id                    types.String `tfsdk:"id"`
+	IsDefault             types.Bool   `tfsdk:"is_default"`
+	PinnedReleaseSequence types.Int64  `tfsdk:"pinned_release_sequence"`
+}
+
+var registryCredentialsAttrTypes = map[string]attr.Type{
+	"username": types.StringType,
+	"password": types.StringType,
+}
+
+var customerChannelAttrTypes = map[string]attr.Type{
+	"id":                      types.StringType,
+	"is_default":              types.BoolType,
+	"pinned_release_sequence": types.Int64Type,
 }
 
 func (r *CustomerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -60,35 +127,120 @@ func (r *CustomerResource) Schema(ctx context.Context, req resource.SchemaReques
 				MarkdownDescription: "ID of the customer",
 				Computed:            true,
 			},
+			"license_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the customer license",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"installation_id": schema.StringAttribute{
+				MarkdownDescription: "Installation ID of the customer license, currently the same as `license_id`",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "Creation time of the customer",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				MarkdownDescription: "Last update time of the customer",
+				Computed:            true,
+			},
+			"registry_credentials": schema.SingleNestedAttribute{
+				MarkdownDescription: "Credentials of the customer for the Replicated registry and proxy, e.g. for `helm registry login`",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"username": schema.StringAttribute{
+						MarkdownDescription: "Username, the email of the customer",
+						Computed:            true,
+					},
+					"password": schema.StringAttribute{
+						MarkdownDescription: "Password, the license ID of the customer",
+						Computed:            true,
+						Sensitive:           true,
+					},
+				},
+			},
 			"app_id": schema.StringAttribute{
-				MarkdownDescription: "App to which the channel is associated",
+				MarkdownDescription: "ID or slug of the app to which the customer belongs",
 				Required:            true,
 			},
 			"email": schema.StringAttribute{
 				MarkdownDescription: "Email of the customer",
 				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(emailRegexp, "must be an email address"),
+				},
 			},
 			"entitlement_values": schema.MapAttribute{
-				MarkdownDescription: "Entitlement values of the customer",
+				MarkdownDescription: "Values of the app's license fields for the customer, keyed by field name. Values are validated against the type of the field, fields that are not set keep the default of the app",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"secret_entitlement_values": schema.MapAttribute{
+				MarkdownDescription: "Values of the app's secret license fields for the customer, keyed by field name",
 				ElementType:         types.StringType,
 				Optional:            true,
+				Sensitive:           true,
+			},
+			"default_entitlement_values": schema.MapAttribute{
+				MarkdownDescription: "Values of the license fields that are not set for the customer, as defined by the app. Secret fields are omitted",
+				ElementType:         types.StringType,
+				Computed:            true,
 			},
 			"expires_at": schema.StringAttribute{
-				MarkdownDescription: "Expiration date of the customer license",
+				MarkdownDescription: "Expiration date of the customer license: an RFC3339 timestamp, a date (`YYYY-MM-DD`) or a time from now such as `+365d` or `+12h`. A relative time is resolved when the customer is created or `expires_at` is changed. It must be in the future when the customer is created",
+				CustomType:          TimestampType{},
 				Optional:            true,
 			},
+			"resolved_expires_at": schema.StringAttribute{
+				MarkdownDescription: "Expiration date of the customer license as an RFC3339 timestamp, as stored by the Vendor API. A relative `expires_at` is kept as configured as long as the Vendor API still has the expiration it was resolved to",
+				Computed:            true,
+			},
+			"expiry_warning_days": schema.Int64Attribute{
+				MarkdownDescription: "Warn on refresh when the customer license expires within this many days, 0 disables the warning",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(customerExpiryWarningDays),
+			},
 			"is_airgap_enabled": schema.BoolAttribute{
 				MarkdownDescription: "Is airgap enabled for the customer license",
 				Optional:            true,
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 			},
+			"is_dev_mode_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Is dev mode enabled for the customer license",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"is_disaster_recovery_supported": schema.BoolAttribute{
+				MarkdownDescription: "Is disaster recovery supported for the customer license",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 			"is_embedded_cluster_download_enabled": schema.BoolAttribute{
 				MarkdownDescription: "Is embedded cluster download enabled for the customer license",
 				Optional:            true,
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 			},
+			"is_embedded_cluster_multinode_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Is embedded cluster multinode enabled for the customer license",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 			"is_geoaxis_supported": schema.BoolAttribute{
 				MarkdownDescription: "Is geoaxis supported for the customer license",
 				Optional:            true,
@@ -101,6 +253,18 @@ func (r *CustomerResource) Schema(ctx context.Context, req resource.SchemaReques
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 			},
+			"is_helm_install_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Is helm install enabled for the customer license",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"is_helm_vm_download_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Is helm vm download enabled for the customer license",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 			"is_identity_service_supported": schema.BoolAttribute{
 				MarkdownDescription: "Is identity service supported for the customer license",
 				Optional:            true,
@@ -119,6 +283,12 @@ func (r *CustomerResource) Schema(ctx context.Context, req resource.SchemaReques
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 			},
+			"is_kurl_install_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Is kurl install enabled for the customer license",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 			"is_snapshot_supported": schema.BoolAttribute{
 				MarkdownDescription: "Is snapshot supported for the customer license",
 				Optional:            true,
@@ -131,19 +301,68 @@ func (r *CustomerResource) Schema(ctx context.Context, req resource.SchemaReques
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 			},
+			"deletion_policy": schema.StringAttribute{
+				MarkdownDescription: "What happens to the customer on destroy: `archive` archives it, `retain` keeps it and only removes it from the state. Customers cannot be deleted through the Vendor API",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(customerDeletionPolicyArchive),
+			},
+			"unarchive_on_create": schema.BoolAttribute{
+				MarkdownDescription: "Unarchive and update an archived customer of the app with the same name instead of creating a new customer",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
 			"channel_id": schema.StringAttribute{
-				MarkdownDescription: "Channel to which the customer license is associated",
-				Required:            true,
+				MarkdownDescription: "Default channel of the customer license. Use `channels` to give the customer access to more than one channel",
+				Optional:            true,
+				Computed:            true,
+			},
+			"channels": schema.SetNestedAttribute{
+				MarkdownDescription: "Channels the customer license has access to, exactly one of them must be the default channel. Conflicts with `channel_id`",
+				Optional:            true,
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "ID of the channel",
+							Required:            true,
+						},
+						"is_default": schema.BoolAttribute{
+							MarkdownDescription: "Is this the default channel of the customer license",
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(false),
+						},
+						"pinned_release_sequence": schema.Int64Attribute{
+							MarkdownDescription: "Channel sequence the customer license is pinned to",
+							Optional:            true,
+						},
+					},
+				},
 			},
 			"name": schema.StringAttribute{
 				MarkdownDescription: "Name of the customer",
 				Required:            true,
 			},
+			"custom_id": schema.StringAttribute{
+				MarkdownDescription: "Custom ID of the customer, e.g. its ID in a CRM",
+				Optional:            true,
+			},
 			"type": schema.StringAttribute{
-				MarkdownDescription: "Type of the customer",
+				MarkdownDescription: "Type of the customer, one of `dev`, `trial`, `paid`, `community` or `test`",
 				Optional:            true,
 				Computed:            true,
 				Default:             stringdefault.StaticString("trial"),
+				Validators: []validator.String{
+					stringvalidator.OneOf(customerTypes...),
+				},
+			},
+			"require_unique_name": schema.BoolAttribute{
+				MarkdownDescription: "Fail the plan when another customer of the app already has the name of the customer",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
 			},
 		},
 	}
@@ -159,13 +378,15 @@ func (r *CustomerResource) Configure(ctx context.Context, req resource.Configure
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ReplicatedProviderClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.kotsClient = &clients.kotsVendorV3Client
+	r.kotsClient = clients.vendorAPI
+	r.appResolver = clients.appResolver
+	r.licenseFields = clients.licenseFields
 }
 
 func (r *CustomerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -177,56 +398,93 @@ func (r *CustomerResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	entitlementValuesMap := make(map[string]types.String, len(data.EntitlementValues.Elements()))
-	diags := data.EntitlementValues.ElementsAs(ctx, &entitlementValuesMap, false)
-	if diags.HasError() {
-		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to create customer, got error: %s", diags))
+	appID, err := r.appResolver.resolveAppID(data.AppId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("app_id"), "Invalid App", fmt.Sprintf("Unable to resolve app, got error: %s", err))
 		return
 	}
 
-	var entitlementValues []kotsclient.EntitlementValue
-
-	for name, value := range entitlementValuesMap {
-		entitlementValues = append(entitlementValues, kotsclient.EntitlementValue{Name: name, Value: value.ValueString()})
+	entitlementValues, diags := entitlementValuesFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	opts := kotsclient.CreateCustomerOpts{
-		AppID:                            data.AppId.ValueString(),
-		Email:                            data.Email.ValueString(),
-		EntitlementValues:                entitlementValues,
-		ExpiresAt:                        data.ExpiresAt.ValueString(),
-		IsAirgapEnabled:                  data.IsAirgapEnabled.ValueBool(),
-		IsEmbeddedClusterDownloadEnabled: data.IsEmbeddedClusterDownloadEnabled.ValueBool(),
-		IsGeoaxisSupported:               data.IsGeoaxisSupported.ValueBool(),
-		IsGitopsSupported:                data.IsGitopsSupported.ValueBool(),
-		IsIdentityServiceSupported:       data.IsIdentityServiceSupported.ValueBool(),
-		IsInstallerSupportEnabled:        data.IsInstallerSupportEnabled.ValueBool(),
-		IsKotsInstallEnabled:             data.IsKotsInstallEnabled.ValueBool(),
-		IsSnapshotSupported:              data.IsSnapshotSupported.ValueBool(),
-		IsSupportBundleUploadEnabled:     data.IsSupportBundleUploadEnabled.ValueBool(),
-		Name:                             data.Name.ValueString(),
-		LicenseType:                      data.Type.ValueString(),
+	fields, err := r.licenseFields.listLicenseFields(appID)
+	if err != nil {
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to list license fields, got error: %s", err))
+		return
 	}
 
-	channels := []kotsclient.CustomerChannel{
-		{
-			ID:        data.ChannelId.ValueString(),
-			IsDefault: true,
+	opts := CreateCustomerOpts{
+		CreateCustomerOpts: kotsclient.CreateCustomerOpts{
+			AppID:                            appID,
+			Email:                            data.Email.ValueString(),
+			EntitlementValues:                entitlementValues,
+			IsAirgapEnabled:                  data.IsAirgapEnabled.ValueBool(),
+			IsEmbeddedClusterDownloadEnabled: data.IsEmbeddedClusterDownloadEnabled.ValueBool(),
+			IsGeoaxisSupported:               data.IsGeoaxisSupported.ValueBool(),
+			IsGitopsSupported:                data.IsGitopsSupported.ValueBool(),
+			IsHelmVMDownloadEnabled:          data.IsHelmVMDownloadEnabled.ValueBool(),
+			IsIdentityServiceSupported:       data.IsIdentityServiceSupported.ValueBool(),
+			IsInstallerSupportEnabled:        data.IsInstallerSupportEnabled.ValueBool(),
+			IsKotsInstallEnabled:             data.IsKotsInstallEnabled.ValueBool(),
+			IsSnapshotSupported:              data.IsSnapshotSupported.ValueBool(),
+			IsSupportBundleUploadEnabled:     data.IsSupportBundleUploadEnabled.ValueBool(),
+			Name:                             data.Name.ValueString(),
+			CustomID:                         data.CustomId.ValueString(),
+			LicenseType:                      data.Type.ValueString(),
 		},
+		CustomerLicenseOptions: data.licenseOptions(),
+	}
+
+	channels, diags := customerChannelsFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 	opts.Channels = channels
 
-	customer, err := r.kotsClient.CreateCustomer(opts)
+	opts.ExpiresAt, err = apiExpiresAt(data.ExpiresAt, time.Now())
 	if err != nil {
-		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to create customer, got error: %s", err))
+		resp.Diagnostics.AddAttributeError(path.Root("expires_at"), "Invalid Timestamp", err.Error())
 		return
 	}
 
-	if resp.Diagnostics.HasError() {
-		return
+	var customer *rtypes.Customer
+	if data.UnarchiveOnCreate.ValueBool() {
+		customer, err = r.unarchiveCustomer(appID, opts)
+		if err != nil {
+			resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to unarchive customer, got error: %s", err))
+			return
+		}
+	}
+
+	if customer == nil {
+		customer, err = r.kotsClient.CreateCustomer(opts)
+		if err != nil {
+			resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to create customer, got error: %s", err))
+			return
+		}
 	}
 
-	data = getCustomerResourceModelFromCustomer(data.AppId.ValueString(), customer)
+	// keep app_id as configured so that slugs do not produce a diff, the id
+	// always carries the canonical app id
+	planned := data
+	data = getCustomerResourceModelFromCustomer(appID, customer)
+	data.AppId = planned.AppId
+	data.applySettings(planned)
+	data.keepRelativeExpiresAt(planned.ExpiresAt, data.ResolvedExpiresAt)
+	data.applyEntitlementValues(customer, fields, planned.EntitlementValues, planned.SecretEntitlementValues)
+
+	// the customer exists now and must end up in the state, otherwise the next
+	// apply creates it again
+	details, err := r.kotsClient.GetCustomerDetails(customer.ID)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Incomplete Customer", fmt.Sprintf("Unable to get the timestamps and channel settings of the customer, they are read again on the next refresh. Got error: %s", err))
+		details = customerDetailsFromOpts(opts.Channels, opts.CustomerLicenseOptions)
+	}
+	resp.Diagnostics.Append(data.applyDetails(ctx, details)...)
 
 	tflog.Trace(ctx, "created a customer")
 
@@ -234,20 +492,30 @@ func (r *CustomerResource) Create(ctx context.Context, req resource.CreateReques
 }
 
 func (r *CustomerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var resourceId string
+	// after an import only id and app_id are set
+	var prior CustomerResourceModel
 
-	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &resourceId)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	appIDOrSlug, id, diags := parseCustomerResourceID(prior.Id.ValueString())
+	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	appId := strings.Split(resourceId, "/")[1]
-	id := strings.Split(resourceId, "/")[3]
+	appId, err := r.appResolver.resolveAppID(appIDOrSlug)
+	if err != nil {
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to resolve app, got error: %s", err))
+		return
+	}
 
 	customer, err := r.kotsClient.GetCustomerByNameOrId(appId, id)
 	if err != nil {
-		if err.Error() == "Customer not found" {
+		if errors.As(err, &kotsclient.ErrCustomerNotFound{}) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
@@ -255,7 +523,28 @@ func (r *CustomerResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	fields, err := r.licenseFields.listLicenseFields(appId)
+	if err != nil {
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to list license fields, got error: %s", err))
+		return
+	}
+
 	data := getCustomerResourceModelFromCustomer(appId, customer)
+	if prior.AppId.ValueString() != "" {
+		data.AppId = prior.AppId
+	}
+	data.applySettings(prior)
+	data.keepRelativeExpiresAt(prior.ExpiresAt, prior.ResolvedExpiresAt)
+
+	resp.Diagnostics.Append(customerExpiryWarning(customer, data.ExpiryWarningDays.ValueInt64(), time.Now())...)
+	data.applyEntitlementValues(customer, fields, prior.EntitlementValues, prior.SecretEntitlementValues)
+
+	details, err := r.kotsClient.GetCustomerDetails(customer.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to get customer, got error: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(data.applyDetails(ctx, details)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -271,44 +560,75 @@ func (r *CustomerResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	var entitlementValues []kotsclient.EntitlementValue
+	_, customerId, diags := parseCustomerResourceID(oldData.Id.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	entitlementValuesMap := make(map[string]types.String, len(updatedData.EntitlementValues.Elements()))
-	diags := updatedData.EntitlementValues.ElementsAs(ctx, &entitlementValuesMap, false)
-	if diags.HasError() {
-		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to update customer, got error: %s", diags))
+	appID, err := r.appResolver.resolveAppID(updatedData.AppId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("app_id"), "Invalid App", fmt.Sprintf("Unable to resolve app, got error: %s", err))
+		return
+	}
+
+	entitlementValues, diags := entitlementValuesFromModel(ctx, updatedData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	for name, value := range entitlementValuesMap {
-		entitlementValues = append(entitlementValues, kotsclient.EntitlementValue{Name: name, Value: value.ValueString()})
+	fields, err := r.licenseFields.listLicenseFields(appID)
+	if err != nil {
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to list license fields, got error: %s", err))
+		return
 	}
 
-	var opts kotsclient.UpdateCustomerOpts
+	var opts UpdateCustomerOpts
 
-	opts.AppID = updatedData.AppId.ValueString()
-	opts.Channels = []kotsclient.CustomerChannel{
-		{
-			ID:        updatedData.ChannelId.ValueString(),
-			IsDefault: true,
-		},
+	channels, diags := customerChannelsFromModel(ctx, updatedData)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+
+	opts.AppID = appID
+	opts.Channels = channels
 	opts.Email = updatedData.Email.ValueString()
 	opts.EntitlementValues = entitlementValues
-	opts.ExpiresAt = updatedData.ExpiresAt.ValueString()
+	// an unchanged relative expiration was resolved before, resolving it
+	// again would move the expiration on every update
+	if updatedData.ExpiresAt.IsRelative() && updatedData.ExpiresAt.Equal(oldData.ExpiresAt) {
+		current, err := r.kotsClient.GetCustomerByNameOrId(appID, customerId)
+		if err != nil {
+			resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to get customer, got error: %s", err))
+			return
+		}
+		if current.Expires != nil {
+			opts.ExpiresAt = current.Expires.UTC().Format(time.RFC3339)
+		}
+	} else {
+		opts.ExpiresAt, err = apiExpiresAt(updatedData.ExpiresAt, time.Now())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("expires_at"), "Invalid Timestamp", err.Error())
+			return
+		}
+	}
+	opts.CustomerLicenseOptions = updatedData.licenseOptions()
 	opts.IsAirgapEnabled = updatedData.IsAirgapEnabled.ValueBool()
 	opts.IsEmbeddedClusterDownloadEnabled = updatedData.IsEmbeddedClusterDownloadEnabled.ValueBool()
 	opts.IsGeoaxisSupported = updatedData.IsGeoaxisSupported.ValueBool()
 	opts.IsGitopsSupported = updatedData.IsGitopsSupported.ValueBool()
+	opts.IsHelmVMDownloadEnabled = updatedData.IsHelmVMDownloadEnabled.ValueBool()
 	opts.IsIdentityServiceSupported = updatedData.IsIdentityServiceSupported.ValueBool()
+	opts.IsInstallerSupportEnabled = updatedData.IsInstallerSupportEnabled.ValueBool()
 	opts.IsKotsInstallEnabled = updatedData.IsKotsInstallEnabled.ValueBool()
 	opts.IsSnapshotSupported = updatedData.IsSnapshotSupported.ValueBool()
 	opts.IsSupportBundleUploadEnabled = updatedData.IsSupportBundleUploadEnabled.ValueBool()
 	opts.Name = updatedData.Name.ValueString()
+	opts.CustomID = updatedData.CustomId.ValueString()
 	opts.LicenseType = updatedData.Type.ValueString()
 
-	customerId := strings.Split(oldData.Id.ValueString(), "/")[3]
-
 	customer, err := r.kotsClient.UpdateCustomer(customerId, opts)
 
 	if err != nil {
@@ -319,7 +639,22 @@ func (r *CustomerResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	updatedData = getCustomerResourceModelFromCustomer(updatedData.AppId.ValueString(), customer)
+	planned := updatedData
+	updatedData = getCustomerResourceModelFromCustomer(appID, customer)
+	updatedData.AppId = planned.AppId
+	updatedData.applySettings(planned)
+	updatedData.keepRelativeExpiresAt(planned.ExpiresAt, updatedData.ResolvedExpiresAt)
+	updatedData.applyEntitlementValues(customer, fields, planned.EntitlementValues, planned.SecretEntitlementValues)
+
+	details, err := r.kotsClient.GetCustomerDetails(customerId)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Incomplete Customer", fmt.Sprintf("Unable to get the timestamps and channel settings of the customer, they are read again on the next refresh. Got error: %s", err))
+		details = customerDetailsFromOpts(opts.Channels, opts.CustomerLicenseOptions)
+	}
+	resp.Diagnostics.Append(updatedData.applyDetails(ctx, details)...)
+	if err != nil {
+		updatedData.CreatedAt = planned.CreatedAt
+	}
 
 	tflog.Trace(ctx, "updated a customer")
 
@@ -337,7 +672,17 @@ func (r *CustomerResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	customerId := strings.Split(data.Id.ValueString(), "/")[3]
+	_, customerId, diags := parseCustomerResourceID(data.Id.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.DeletionPolicy.ValueString() == customerDeletionPolicyRetain {
+		tflog.Info(ctx, "retaining customer on destroy", map[string]interface{}{"customer_id": customerId})
+		return
+	}
+
 	err := r.kotsClient.ArchiveCustomer(customerId)
 	if err != nil {
 		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to archive customer, got error: %s", err))
@@ -345,40 +690,602 @@ func (r *CustomerResource) Delete(ctx context.Context, req resource.DeleteReques
 	}
 }
 
+// ImportState accepts the canonical app/<app>/customer/<customer_id> id,
+// <app_slug>/<customer_name> or a bare <customer_id>, in which case every app
+// is searched for the customer.
 func (r *CustomerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	var appIDOrSlug string
+	var customerNameOrID string
+
+	parts := strings.Split(req.ID, "/")
+	switch {
+	case len(parts) == 4:
+		var diags diag.Diagnostics
+		appIDOrSlug, customerNameOrID, diags = parseCustomerResourceID(req.ID)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		appIDOrSlug, customerNameOrID = parts[0], parts[1]
+	case len(parts) == 1 && parts[0] != "":
+		customerNameOrID = parts[0]
+	default:
+		resp.Diagnostics.AddError("Unexpected Import Identifier", fmt.Sprintf("Expected import identifier with format: app/<app>/customer/<customer_id>, <app_slug>/<customer_name> or <customer_id>. Got: %q", req.ID))
+		return
+	}
+
+	var appID string
+	var customer *rtypes.Customer
+	var err error
+
+	if appIDOrSlug == "" {
+		appID, customer, err = r.findCustomer(customerNameOrID)
+		appIDOrSlug = appID
+	} else {
+		appID, err = r.appResolver.resolveAppID(appIDOrSlug)
+		if err != nil {
+			resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to resolve app, got error: %s", err))
+			return
+		}
+		customer, err = r.kotsClient.GetCustomerByNameOrId(appID, customerNameOrID)
+	}
+	if err != nil {
+		if errors.As(err, &kotsclient.ErrCustomerNotFound{}) {
+			resp.Diagnostics.AddError("Customer Not Found", fmt.Sprintf("Unable to import customer %q, it does not exist or is archived.", req.ID))
+			return
+		}
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to get customer, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), formatCustomerResourceID(appID, customer.ID))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_id"), appIDOrSlug)...)
 }
 
-func getCustomerResourceModelFromCustomer(appID string, customer *rtypes.Customer) CustomerResourceModel {
-	entitlements := make(map[string]string)
+// unarchiveCustomer unarchives the archived customer of the app named like the
+// customer opts describe, and updates it to match opts. It returns nil if the
+// app has no such customer.
+func (r *CustomerResource) unarchiveCustomer(appID string, opts CreateCustomerOpts) (*rtypes.Customer, error) {
+	archived, err := r.kotsClient.ListArchivedCustomers(appID)
+	if err != nil {
+		return nil, fmt.Errorf("list archived customers: %w", err)
+	}
 
-	for _, entitlement := range customer.Entitlements {
-		entitlements[entitlement.Name] = entitlement.Value
+	var match *rtypes.Customer
+	for i := range archived {
+		if archived[i].Name != opts.Name {
+			continue
+		}
+		if match != nil {
+			return nil, fmt.Errorf("more than one archived customer is named %q", opts.Name)
+		}
+		match = &archived[i]
+	}
+	if match == nil {
+		return nil, nil
 	}
 
-	entitlementValues, _ := types.MapValueFrom(context.Background(), types.StringType, entitlements)
+	if err := r.kotsClient.UnarchiveCustomer(match.ID); err != nil {
+		return nil, err
+	}
+
+	return r.kotsClient.UpdateCustomer(match.ID, UpdateCustomerOpts{
+		UpdateCustomerOpts: kotsclient.UpdateCustomerOpts{
+			Name:                             opts.Name,
+			CustomID:                         opts.CustomID,
+			Channels:                         opts.Channels,
+			AppID:                            opts.AppID,
+			ExpiresAt:                        opts.ExpiresAt,
+			IsAirgapEnabled:                  opts.IsAirgapEnabled,
+			IsGitopsSupported:                opts.IsGitopsSupported,
+			IsSnapshotSupported:              opts.IsSnapshotSupported,
+			IsKotsInstallEnabled:             opts.IsKotsInstallEnabled,
+			IsEmbeddedClusterDownloadEnabled: opts.IsEmbeddedClusterDownloadEnabled,
+			IsGeoaxisSupported:               opts.IsGeoaxisSupported,
+			IsHelmVMDownloadEnabled:          opts.IsHelmVMDownloadEnabled,
+			IsIdentityServiceSupported:       opts.IsIdentityServiceSupported,
+			IsSupportBundleUploadEnabled:     opts.IsSupportBundleUploadEnabled,
+			LicenseType:                      opts.LicenseType,
+			Email:                            opts.Email,
+			EntitlementValues:                opts.EntitlementValues,
+		},
+		CustomerLicenseOptions:    opts.CustomerLicenseOptions,
+		IsInstallerSupportEnabled: opts.IsInstallerSupportEnabled,
+	})
+}
+
+// findCustomer returns the customer with the given id together with the id of
+// its app.
+func (r *CustomerResource) findCustomer(customerID string) (string, *rtypes.Customer, error) {
+	customer, appID, err := r.kotsClient.GetCustomer(customerID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return appID, customer, nil
+}
+
+func (r *CustomerResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data CustomerResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch policy := data.DeletionPolicy.ValueString(); policy {
+	case "", customerDeletionPolicyArchive, customerDeletionPolicyRetain:
+	case "delete":
+		resp.Diagnostics.AddAttributeError(path.Root("deletion_policy"), "Invalid Deletion Policy", "The Vendor API cannot delete customers, use archive or retain.")
+	default:
+		resp.Diagnostics.AddAttributeError(path.Root("deletion_policy"), "Invalid Deletion Policy", fmt.Sprintf("Expected archive or retain, got: %q", policy))
+	}
+
+	if !data.ChannelId.IsNull() && !data.Channels.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("channels"), "Conflicting Channel Configuration", "Only one of channel_id and channels can be set.")
+		return
+	}
+
+	if data.ChannelId.IsNull() && data.Channels.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("channel_id"), "Missing Channel Configuration", "One of channel_id and channels must be set.")
+		return
+	}
+
+	if data.Channels.IsNull() || data.Channels.IsUnknown() {
+		return
+	}
+
+	var channels []CustomerChannelModel
+	resp.Diagnostics.Append(data.Channels.ElementsAs(ctx, &channels, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	defaults := 0
+	for _, channel := range channels {
+		if channel.IsDefault.IsUnknown() {
+			return
+		}
+		if channel.IsDefault.ValueBool() {
+			defaults++
+		}
+	}
+	if defaults != 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("channels"), "Invalid Default Channel", fmt.Sprintf("Exactly one channel must have is_default set to true, found %d.", defaults))
+	}
+}
+
+func (r *CustomerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var config CustomerResourceModel
+	var plan CustomerResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// a new customer has to be valid, updates may keep an expired license
+	if req.State.Raw.IsNull() && !plan.ExpiresAt.IsNull() && !plan.ExpiresAt.IsUnknown() {
+		now := time.Now()
+		if expiresAt, err := plan.ExpiresAt.Resolve(now); err == nil && !expiresAt.After(now) {
+			resp.Diagnostics.AddAttributeError(path.Root("expires_at"), "Invalid Expiration", fmt.Sprintf("The customer license must expire in the future, got: %s", plan.ExpiresAt.ValueString()))
+		}
+	}
+
+	// channel_id and channels describe the same thing, derive whichever one
+	// was not configured from the other so that the plan is fully known
+	switch {
+	case !config.Channels.IsNull():
+		if plan.Channels.IsUnknown() {
+			plan.ChannelId = types.StringUnknown()
+			break
+		}
+		var channels []CustomerChannelModel
+		resp.Diagnostics.Append(plan.Channels.ElementsAs(ctx, &channels, false)...)
+		plan.ChannelId = types.StringNull()
+		for _, channel := range channels {
+			if channel.IsDefault.ValueBool() {
+				plan.ChannelId = channel.Id
+			}
+		}
+	case !config.ChannelId.IsNull():
+		if plan.ChannelId.IsUnknown() {
+			plan.Channels = types.SetUnknown(types.ObjectType{AttrTypes: customerChannelAttrTypes})
+			break
+		}
+		channels, diags := types.SetValueFrom(ctx, types.ObjectType{AttrTypes: customerChannelAttrTypes}, []CustomerChannelModel{
+			{
+				Id:                    plan.ChannelId,
+				IsDefault:             types.BoolValue(true),
+				PinnedReleaseSequence: types.Int64Null(),
+			},
+		})
+		resp.Diagnostics.Append(diags...)
+		plan.Channels = channels
+	}
+
+	// the registry credentials only change with the email and the license id
+	if !plan.Email.IsUnknown() && !plan.LicenseId.IsUnknown() {
+		plan.RegistryCredentials = registryCredentialsValue(plan.Email.ValueString(), plan.LicenseId.ValueString())
+	}
+
+	resp.Diagnostics.Append(r.validateEntitlementValues(plan)...)
+
+	var state CustomerResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	resp.Diagnostics.Append(r.validateUniqueName(plan, state)...)
+
+	// the resolved expiration only changes with expires_at
+	if !req.State.Raw.IsNull() && plan.ExpiresAt.Equal(state.ExpiresAt) {
+		plan.ResolvedExpiresAt = state.ResolvedExpiresAt
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// validateEntitlementValues checks the entitlement values against the license
+// fields defined by the app, as soon as the app is known.
+func (r *CustomerResource) validateEntitlementValues(data CustomerResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// the provider is not configured when the plan is validated offline
+	if r.kotsClient == nil || data.AppId.IsUnknown() || data.EntitlementValues.IsUnknown() || data.SecretEntitlementValues.IsUnknown() {
+		return diags
+	}
+	if len(data.EntitlementValues.Elements()) == 0 && len(data.SecretEntitlementValues.Elements()) == 0 {
+		return diags
+	}
+
+	appID, err := r.appResolver.resolveAppID(data.AppId.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("app_id"), "Invalid App", fmt.Sprintf("Unable to resolve app, got error: %s", err))
+		return diags
+	}
+
+	fields, err := r.licenseFields.listLicenseFields(appID)
+	if err != nil {
+		diags.AddError("Server Error", fmt.Sprintf("Unable to list license fields, got error: %s", err))
+		return diags
+	}
+
+	diags.Append(validateLicenseFieldValues(path.Root("entitlement_values"), data.EntitlementValues, fields, false)...)
+	diags.Append(validateLicenseFieldValues(path.Root("secret_entitlement_values"), data.SecretEntitlementValues, fields, true)...)
+
+	return diags
+}
+
+// validateUniqueName checks that no other customer of the app has the name of
+// a new or renamed customer, if the customer requires a unique name.
+func (r *CustomerResource) validateUniqueName(plan CustomerResourceModel, state CustomerResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// the provider is not configured when the plan is validated offline
+	if r.kotsClient == nil || !plan.RequireUniqueName.ValueBool() || plan.AppId.IsUnknown() || plan.Name.IsUnknown() {
+		return diags
+	}
+	if plan.Name.Equal(state.Name) {
+		return diags
+	}
+
+	var customerID string
+	if !state.Id.IsNull() {
+		_, customerID, diags = parseCustomerResourceID(state.Id.ValueString())
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	appID, err := r.appResolver.resolveAppID(plan.AppId.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("app_id"), "Invalid App", fmt.Sprintf("Unable to resolve app, got error: %s", err))
+		return diags
+	}
+
+	customers, err := r.kotsClient.ListCustomers(appID, true)
+	if err != nil {
+		diags.AddError("Server Error", fmt.Sprintf("Unable to list customers, got error: %s", err))
+		return diags
+	}
+
+	for _, customer := range customers {
+		if customer.Name == plan.Name.ValueString() && customer.ID != customerID {
+			diags.AddAttributeError(path.Root("name"), "Duplicate Customer Name", fmt.Sprintf("Customer %s of the app is already named %q, set require_unique_name to false to allow duplicate names.", customer.ID, customer.Name))
+			break
+		}
+	}
+
+	return diags
+}
+
+// customerChannelsFromModel returns the channels of the customer as expected
+// by the vendor api.
+func customerChannelsFromModel(ctx context.Context, data CustomerResourceModel) ([]kotsclient.CustomerChannel, diag.Diagnostics) {
+	var channelModels []CustomerChannelModel
+
+	diags := data.Channels.ElementsAs(ctx, &channelModels, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	channels := make([]kotsclient.CustomerChannel, 0, len(channelModels))
+	for _, channel := range channelModels {
+		customerChannel := kotsclient.CustomerChannel{
+			ID:        channel.Id.ValueString(),
+			IsDefault: channel.IsDefault.ValueBool(),
+		}
+		if !channel.PinnedReleaseSequence.IsNull() && !channel.PinnedReleaseSequence.IsUnknown() {
+			sequence := channel.PinnedReleaseSequence.ValueInt64()
+			customerChannel.PinnedChannelSequence = &sequence
+		}
+		channels = append(channels, customerChannel)
+	}
+
+	return channels, diags
+}
+
+// applyChannelDetails sets which channel is the default channel of the
+// customer and the channel sequences it is pinned to.
+func (m *CustomerResourceModel) applyChannelDetails(ctx context.Context, details []CustomerChannelDetails) diag.Diagnostics {
+	var channels []CustomerChannelModel
+	diags := m.Channels.ElementsAs(ctx, &channels, false)
+	if diags.HasError() {
+		return diags
+	}
+
+	detailsByID := map[string]CustomerChannelDetails{}
+	for _, channel := range details {
+		detailsByID[channel.ID] = channel
+	}
+
+	m.ChannelId = types.StringNull()
+	for i, channel := range channels {
+		d := detailsByID[channel.Id.ValueString()]
+		channels[i].IsDefault = types.BoolValue(d.IsDefault)
+		channels[i].PinnedReleaseSequence = types.Int64PointerValue(d.PinnedChannelSequence)
+		if d.IsDefault {
+			m.ChannelId = channel.Id
+		}
+	}
+
+	channelsValue, d := types.SetValueFrom(ctx, types.ObjectType{AttrTypes: customerChannelAttrTypes}, channels)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	m.Channels = channelsValue
+
+	return diags
+}
+
+// applySettings copies the attributes that only change the behaviour of the
+// provider, which the vendor api does not store. They are null after an import,
+// which leaves them at their defaults.
+func (m *CustomerResourceModel) applySettings(known CustomerResourceModel) {
+	if !known.DeletionPolicy.IsNull() {
+		m.DeletionPolicy = known.DeletionPolicy
+	}
+	if !known.UnarchiveOnCreate.IsNull() {
+		m.UnarchiveOnCreate = known.UnarchiveOnCreate
+	}
+	if !known.ExpiryWarningDays.IsNull() {
+		m.ExpiryWarningDays = known.ExpiryWarningDays
+	}
+	if !known.RequireUniqueName.IsNull() {
+		m.RequireUniqueName = known.RequireUniqueName
+	}
+}
+
+// apiExpiresAt returns the expiration the way the vendor api expects it,
+// resolving relative expirations against now. Licenses that do not expire have
+// an empty expiration.
+func apiExpiresAt(expiresAt TimestampValue, now time.Time) (string, error) {
+	if expiresAt.IsNull() || expiresAt.ValueString() == "" {
+		return "", nil
+	}
+
+	t, err := expiresAt.Resolve(now)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse expires_at %q: %w", expiresAt.ValueString(), err)
+	}
+
+	return t.UTC().Format(time.RFC3339), nil
+}
+
+// customerExpiryWarning warns about a customer license that has expired or
+// expires within the given number of days.
+func customerExpiryWarning(customer *rtypes.Customer, days int64, now time.Time) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if customer.Expires == nil || days <= 0 {
+		return diags
+	}
+
+	remaining := customer.Expires.Sub(now)
+	switch {
+	case remaining <= 0:
+		diags.AddAttributeWarning(path.Root("expires_at"), "Customer License Expired", fmt.Sprintf("The license of customer %q expired at %s.", customer.Name, customer.Expires.UTC().Format(time.RFC3339)))
+	case remaining < time.Duration(days)*24*time.Hour:
+		diags.AddAttributeWarning(path.Root("expires_at"), "Customer License Expiring Soon", fmt.Sprintf("The license of customer %q expires at %s, in less than %d days.", customer.Name, customer.Expires.UTC().Format(time.RFC3339), days))
+	}
+
+	return diags
+}
+
+// applyDetails sets the creation and update times of the customer and the
+// settings of its channels.
+func (m *CustomerResourceModel) applyDetails(ctx context.Context, details *CustomerDetails) diag.Diagnostics {
+	m.CreatedAt = types.StringNull()
+	if details.CreatedAt != nil {
+		m.CreatedAt = types.StringValue(details.CreatedAt.UTC().Format(time.RFC3339))
+	}
+
+	m.UpdatedAt = types.StringNull()
+	if details.UpdatedAt != nil {
+		m.UpdatedAt = types.StringValue(details.UpdatedAt.UTC().Format(time.RFC3339))
+	}
+
+	options := details.licenseOptions()
+	m.IsDevModeEnabled = types.BoolValue(options.IsDevModeEnabled)
+	m.IsDisasterRecoverySupported = types.BoolValue(options.IsDisasterRecoverySupported)
+	m.IsEmbeddedClusterMultiNodeEnabled = types.BoolValue(options.IsEmbeddedClusterMultiNodeEnabled)
+	m.IsHelmInstallEnabled = types.BoolValue(options.IsHelmInstallEnabled)
+	m.IsKurlInstallEnabled = types.BoolValue(options.IsKurlInstallEnabled)
+
+	return m.applyChannelDetails(ctx, details.Channels)
+}
+
+// keepRelativeExpiresAt keeps the relative expires_at of known, e.g. +365d,
+// while the vendor api still has the expiration it was resolved to. Otherwise
+// m keeps the absolute expiration of the api, so that an expiration changed
+// outside of terraform shows up as a diff. States written before
+// resolved_expires_at existed have no resolved expiration to compare with.
+func (m *CustomerResourceModel) keepRelativeExpiresAt(known TimestampValue, knownResolved types.String) {
+	if !known.IsRelative() {
+		return
+	}
+	if knownResolved.IsNull() || m.ResolvedExpiresAt.Equal(knownResolved) {
+		m.ExpiresAt = known
+	}
+}
+
+// licenseOptions returns the license options of m that the client library
+// does not send.
+func (m *CustomerResourceModel) licenseOptions() CustomerLicenseOptions {
+	return CustomerLicenseOptions{
+		IsHelmInstallEnabled:              m.IsHelmInstallEnabled.ValueBool(),
+		IsDisasterRecoverySupported:       m.IsDisasterRecoverySupported.ValueBool(),
+		IsDevModeEnabled:                  m.IsDevModeEnabled.ValueBool(),
+		IsEmbeddedClusterMultiNodeEnabled: m.IsEmbeddedClusterMultiNodeEnabled.ValueBool(),
+		IsKurlInstallEnabled:              m.IsKurlInstallEnabled.ValueBool(),
+	}
+}
+
+// customerDetailsFromOpts returns the channel settings and license options
+// sent to the vendor api in the form it returns them, without timestamps.
+func customerDetailsFromOpts(channels []kotsclient.CustomerChannel, options CustomerLicenseOptions) *CustomerDetails {
+	details := &CustomerDetails{
+		Channels:                          make([]CustomerChannelDetails, 0, len(channels)),
+		IsHelmInstallEnabled:              options.IsHelmInstallEnabled,
+		IsDisasterRecoverySupported:       options.IsDisasterRecoverySupported,
+		IsDevModeEnabled:                  options.IsDevModeEnabled,
+		IsEmbeddedClusterMultiNodeEnabled: options.IsEmbeddedClusterMultiNodeEnabled,
+		IsKurlInstallEnabled:              options.IsKurlInstallEnabled,
+	}
+	for _, channel := range channels {
+		details.Channels = append(details.Channels, CustomerChannelDetails{
+			ID:                    channel.ID,
+			IsDefault:             channel.IsDefault,
+			PinnedChannelSequence: channel.PinnedChannelSequence,
+		})
+	}
+	return details
+}
+
+// registryCredentialsValue returns the credentials of a customer for the
+// Replicated registry and proxy, which accept the email of the customer and
+// its license id.
+func registryCredentialsValue(email string, licenseID string) types.Object {
+	return types.ObjectValueMust(registryCredentialsAttrTypes, map[string]attr.Value{
+		"username": types.StringValue(email),
+		"password": types.StringValue(licenseID),
+	})
+}
+
+// formatCustomerResourceID returns the id of the customer resource, which
+// holds both the app id and the customer id.
+func formatCustomerResourceID(appID string, customerID string) string {
+	return fmt.Sprintf("app/%s/customer/%s", appID, customerID)
+}
+
+// parseCustomerResourceID splits the id of the customer resource into the app
+// id and the customer id.
+func parseCustomerResourceID(id string) (string, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	parts := strings.Split(id, "/")
+	if len(parts) != 4 || parts[0] != "app" || parts[1] == "" || parts[2] != "customer" || parts[3] == "" {
+		diags.AddAttributeError(path.Root("id"), "Invalid Resource ID", fmt.Sprintf("Expected a customer id with format: app/<app_id>/customer/<customer_id>. Got: %q", id))
+		return "", "", diags
+	}
+
+	return parts[1], parts[3], diags
+}
+
+func getCustomerResourceModelFromCustomer(appID string, customer *rtypes.Customer) CustomerResourceModel {
+	// rtypes.Customer does not tell which channel is the default one nor the
+	// options of CustomerLicenseOptions, those are set by applyDetails
+	channelModels := make([]CustomerChannelModel, 0, len(customer.Channels))
+	for _, channel := range customer.Channels {
+		channelModels = append(channelModels, CustomerChannelModel{
+			Id:                    types.StringValue(channel.ID),
+			IsDefault:             types.BoolValue(false),
+			PinnedReleaseSequence: types.Int64Null(),
+		})
+	}
+
+	channels, _ := types.SetValueFrom(context.Background(), types.ObjectType{AttrTypes: customerChannelAttrTypes}, channelModels)
 
 	customerResourceModel := CustomerResourceModel{
-		Id:                               types.StringValue(fmt.Sprintf("app/%s/customer/%s", appID, customer.ID)),
-		AppId:                            types.StringValue(appID),
-		ChannelId:                        types.StringValue(customer.Channels[0].ID),
-		Email:                            types.StringValue(customer.Email),
-		EntitlementValues:                entitlementValues,
-		IsAirgapEnabled:                  types.BoolValue(customer.IsAirgapEnabled),
-		IsEmbeddedClusterDownloadEnabled: types.BoolValue(customer.IsEmbeddedClusterDownloadEnabled),
-		IsGeoaxisSupported:               types.BoolValue(customer.IsGeoaxisSupported),
-		IsGitopsSupported:                types.BoolValue(customer.IsGitopsSupported),
-		IsIdentityServiceSupported:       types.BoolValue(customer.IsIdentityServiceSupported),
-		IsInstallerSupportEnabled:        types.BoolValue(customer.IsInstallerSupportEnabled),
-		IsKotsInstallEnabled:             types.BoolValue(customer.IsKotsInstallEnabled),
-		IsSnapshotSupported:              types.BoolValue(customer.IsSnapshotSupported),
-		IsSupportBundleUploadEnabled:     types.BoolValue(customer.IsSupportBundleUploadEnabled),
-		Name:                             types.StringValue(customer.Name),
-		Type:                             types.StringValue(customer.Type),
+		Id:                                types.StringValue(formatCustomerResourceID(appID, customer.ID)),
+		AppId:                             types.StringValue(appID),
+		ChannelId:                         types.StringNull(),
+		Channels:                          channels,
+		Email:                             types.StringValue(customer.Email),
+		IsAirgapEnabled:                   types.BoolValue(customer.IsAirgapEnabled),
+		IsDevModeEnabled:                  types.BoolValue(false),
+		IsDisasterRecoverySupported:       types.BoolValue(false),
+		IsEmbeddedClusterDownloadEnabled:  types.BoolValue(customer.IsEmbeddedClusterDownloadEnabled),
+		IsEmbeddedClusterMultiNodeEnabled: types.BoolValue(false),
+		IsGeoaxisSupported:                types.BoolValue(customer.IsGeoaxisSupported),
+		IsGitopsSupported:                 types.BoolValue(customer.IsGitopsSupported),
+		IsHelmInstallEnabled:              types.BoolValue(false),
+		IsHelmVMDownloadEnabled:           types.BoolValue(customer.IsHelmVMDownloadEnabled),
+		IsIdentityServiceSupported:        types.BoolValue(customer.IsIdentityServiceSupported),
+		IsInstallerSupportEnabled:         types.BoolValue(customer.IsInstallerSupportEnabled),
+		IsKotsInstallEnabled:              types.BoolValue(customer.IsKotsInstallEnabled),
+		IsKurlInstallEnabled:              types.BoolValue(false),
+		IsSnapshotSupported:               types.BoolValue(customer.IsSnapshotSupported),
+		IsSupportBundleUploadEnabled:      types.BoolValue(customer.IsSupportBundleUploadEnabled),
+		Name:                              types.StringValue(customer.Name),
+		CustomId:                          types.StringNull(),
+		Type:                              types.StringValue(customer.Type),
+		DeletionPolicy:                    types.StringValue(customerDeletionPolicyArchive),
+		UnarchiveOnCreate:                 types.BoolValue(false),
+		RequireUniqueName:                 types.BoolValue(false),
+		ExpiresAt:                         NewTimestampNull(),
+		ResolvedExpiresAt:                 types.StringNull(),
+		ExpiryWarningDays:                 types.Int64Value(customerExpiryWarningDays),
+		LicenseId:                         types.StringValue(customer.InstallationID),
+		InstallationId:                    types.StringValue(customer.InstallationID),
+		CreatedAt:                         types.StringNull(),
+		UpdatedAt:                         types.StringNull(),
+		RegistryCredentials:               registryCredentialsValue(customer.Email, customer.InstallationID),
 	}
 
+	if customer.CustomID != "" {
+		customerResourceModel.CustomId = types.StringValue(customer.CustomID)
+	}
+
+	customerResourceModel.applyEntitlementValues(customer, nil, types.MapNull(types.StringType), types.MapNull(types.StringType))
+
 	if customer.Expires != nil {
-		customerResourceModel.ExpiresAt = types.StringValue(customer.Expires.Format("2006-01-02T15:04:05Z"))
+		customerResourceModel.ExpiresAt = NewTimestampValue(customer.Expires.UTC().Format(time.RFC3339))
+		customerResourceModel.ResolvedExpiresAt = types.StringValue(customer.Expires.UTC().Format(time.RFC3339))
 	}
 	return customerResourceModel
 }
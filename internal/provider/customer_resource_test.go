@@ -1,26 +1,45 @@
 package provider
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/replicatedhq/replicated/pkg/kotsclient"
 	rtypes "github.com/replicatedhq/replicated/pkg/types"
 	"github.com/replicatedhq/replicated/pkg/util"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccCustomerResource(t *testing.T) {
+	testAccVendorAPI(t)
+	rName := acctest.RandomWithPrefix(testAccResourcePrefix)
+
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
-				Config: testAccCustomerResourceConfig("test"),
+				Config: testAccCustomerResourceConfig(rName),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("replicated_customer.test", "name", "test"),
+					resource.TestCheckResourceAttr("replicated_customer.test", "name", rName),
 				),
 			},
 			// ImportState testing
@@ -29,11 +48,18 @@ func TestAccCustomerResource(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName:            "replicated_customer.test",
+				ImportState:             true,
+				ImportStateId:           testAccAppSlug + "/" + rName,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"app_id"},
+			},
 			// Update and Read testing
 			{
-				Config: testAccCustomerResourceConfig("test_updated"),
+				Config: testAccCustomerResourceConfig(rName + "-updated"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("replicated_customer.test", "name", "test_updated"),
+					resource.TestCheckResourceAttr("replicated_customer.test", "name", rName+"-updated"),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
@@ -41,21 +67,48 @@ func TestAccCustomerResource(t *testing.T) {
 	})
 }
 
+func TestAccCustomerResource_serverError(t *testing.T) {
+	server := testAccVendorAPI(t)
+	if server == nil {
+		t.Skip("errors can only be injected into the mock vendor api")
+	}
+	server.injectError("POST", "/v3/customer", http.StatusInternalServerError, "database unavailable", 1)
+	rName := acctest.RandomWithPrefix(testAccResourcePrefix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCustomerResourceConfig(rName),
+				ExpectError: regexp.MustCompile("database unavailable"),
+			},
+			// the error was only injected once, so a retry succeeds
+			{
+				Config: testAccCustomerResourceConfig(rName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("replicated_customer.test", "name", rName),
+				),
+			},
+		},
+	})
+}
+
 func testAccCustomerResourceConfig(name string) string {
 	return fmt.Sprintf(`
 		resource "replicated_customer" "test" {
 			name                       = %[1]q
 			email                      = "test_resource@mm.mm"
-			app_id                     = "2fvVIbMQtNBwMzeTJt2yJrEKEFN"
-			channel_id                 = "2fvVIfi3WTTAt3GpiKP8Fz86WuA"
-			expires_at                 = "2025-01-30T15:04:05Z"
+			app_id                     = %[2]q
+			channel_id                 = %[3]q
+			expires_at                 = "2035-01-30T15:04:05Z"
 			is_kots_install_enabled    = true
 
 			entitlement_values = {
 				testEntitlement	= "test_value"
 			}
 		}
-	`, name)
+	`, name, testAccAppID, testAccChannelID)
 }
 
 func TestGetCustomerResourceModelFromCustomer(t *testing.T) {
@@ -86,7 +139,7 @@ func TestGetCustomerResourceModelFromCustomer(t *testing.T) {
 				AppId:     types.StringValue("123456789012"),
 				Id:        types.StringValue("app/123456789012/customer/test_id"),
 				Name:      types.StringValue("test_name"),
-				ExpiresAt: types.StringValue("2025-01-30T15:04:05Z"),
+				ExpiresAt: NewTimestampValue("2025-01-30T15:04:05Z"),
 			},
 		},
 		{
@@ -118,3 +171,2087 @@ func TestGetCustomerResourceModelFromCustomer(t *testing.T) {
 		})
 	}
 }
+
+// testCustomerChannels returns the value of the channels attribute holding
+// channels.
+func testCustomerChannels(t *testing.T, channels ...CustomerChannelModel) types.Set {
+	t.Helper()
+
+	v, diags := types.SetValueFrom(context.Background(), types.ObjectType{AttrTypes: customerChannelAttrTypes}, channels)
+	require.False(t, diags.HasError(), "%v", diags)
+	return v
+}
+
+func testCustomerChannel(id string, isDefault bool, pinnedReleaseSequence *int64) CustomerChannelModel {
+	return CustomerChannelModel{
+		Id:                    types.StringValue(id),
+		IsDefault:             types.BoolValue(isDefault),
+		PinnedReleaseSequence: types.Int64PointerValue(pinnedReleaseSequence),
+	}
+}
+
+func testCustomerPlanModel(t *testing.T) CustomerResourceModel {
+	entitlementValues, _ := types.MapValueFrom(context.Background(), types.StringType, map[string]string{"seats": "10"})
+
+	return CustomerResourceModel{
+		Id:                                types.StringUnknown(),
+		AppId:                             types.StringValue("test-app"),
+		ChannelId:                         types.StringValue("channel-1"),
+		Channels:                          testCustomerChannels(t, testCustomerChannel("channel-1", true, nil)),
+		Email:                             types.StringValue("customer@example.com"),
+		EntitlementValues:                 entitlementValues,
+		SecretEntitlementValues:           types.MapNull(types.StringType),
+		DefaultEntitlementValues:          types.MapUnknown(types.StringType),
+		ExpiresAt:                         NewTimestampValue("2030-01-30T15:04:05Z"),
+		ResolvedExpiresAt:                 types.StringUnknown(),
+		ExpiryWarningDays:                 types.Int64Value(30),
+		IsAirgapEnabled:                   types.BoolValue(false),
+		IsDevModeEnabled:                  types.BoolValue(false),
+		IsDisasterRecoverySupported:       types.BoolValue(false),
+		IsEmbeddedClusterDownloadEnabled:  types.BoolValue(false),
+		IsEmbeddedClusterMultiNodeEnabled: types.BoolValue(false),
+		IsGeoaxisSupported:                types.BoolValue(false),
+		IsGitopsSupported:                 types.BoolValue(false),
+		IsHelmInstallEnabled:              types.BoolValue(false),
+		IsHelmVMDownloadEnabled:           types.BoolValue(false),
+		IsIdentityServiceSupported:        types.BoolValue(false),
+		IsInstallerSupportEnabled:         types.BoolValue(true),
+		IsKotsInstallEnabled:              types.BoolValue(true),
+		IsKurlInstallEnabled:              types.BoolValue(false),
+		IsSnapshotSupported:               types.BoolValue(false),
+		IsSupportBundleUploadEnabled:      types.BoolValue(false),
+		Name:                              types.StringValue("acme"),
+		CustomId:                          types.StringNull(),
+		Type:                              types.StringValue("trial"),
+		LicenseId:                         types.StringUnknown(),
+		InstallationId:                    types.StringUnknown(),
+		CreatedAt:                         types.StringUnknown(),
+		UpdatedAt:                         types.StringUnknown(),
+		RegistryCredentials:               types.ObjectUnknown(registryCredentialsAttrTypes),
+		DeletionPolicy:                    types.StringValue("archive"),
+		UnarchiveOnCreate:                 types.BoolValue(false),
+		RequireUniqueName:                 types.BoolValue(false),
+	}
+}
+
+// testCustomerState creates the customer of testCustomerPlanModel through the
+// fake and returns the resulting resource state model.
+func testCustomerState(t *testing.T, api *fakeVendorAPI) CustomerResourceModel {
+	t.Helper()
+
+	customer, err := api.CreateCustomer(CreateCustomerOpts{
+		CreateCustomerOpts: kotsclient.CreateCustomerOpts{
+			Name:                      "acme",
+			Email:                     "customer@example.com",
+			AppID:                     "2fvVIbMQtNBwMzeTJt2yJrEKEFN",
+			Channels:                  []kotsclient.CustomerChannel{{ID: "channel-1", IsDefault: true}},
+			ExpiresAt:                 "2030-01-30T15:04:05Z",
+			IsInstallerSupportEnabled: true,
+			IsKotsInstallEnabled:      true,
+			LicenseType:               "trial",
+			EntitlementValues:         []kotsclient.EntitlementValue{{Name: "seats", Value: "10"}},
+		},
+	})
+	require.NoError(t, err)
+
+	details, err := api.GetCustomerDetails(customer.ID)
+	require.NoError(t, err)
+
+	m := getCustomerResourceModelFromCustomer("2fvVIbMQtNBwMzeTJt2yJrEKEFN", customer)
+	require.False(t, m.applyDetails(context.Background(), details).HasError())
+	m.AppId = types.StringValue("test-app")
+	return m
+}
+
+func TestCustomerResourceCreate(t *testing.T) {
+	sequence := int64(3)
+
+	tests := []struct {
+		name          string
+		plan          func(m *CustomerResourceModel)
+		setup         func(api *fakeVendorAPI)
+		wantChannels  []kotsclient.CustomerChannel
+		wantChannelID string
+		wantValues    []kotsclient.EntitlementValue
+		wantErr       string
+	}{
+		{
+			name: "app slug",
+		},
+		{
+			name: "secret entitlement values",
+			plan: func(m *CustomerResourceModel) {
+				m.SecretEntitlementValues = types.MapValueMust(types.StringType, map[string]attr.Value{"api_key": types.StringValue("s3cr3t")})
+			},
+			wantValues: []kotsclient.EntitlementValue{{Name: "api_key", Value: "s3cr3t"}, {Name: "seats", Value: "10"}},
+		},
+		{
+			name: "list license fields error",
+			setup: func(api *fakeVendorAPI) {
+				api.errs["ListLicenseFields"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+		{
+			name: "multiple channels",
+			plan: func(m *CustomerResourceModel) {
+				m.ChannelId = types.StringValue("channel-2")
+				m.Channels = testCustomerChannels(t,
+					testCustomerChannel("channel-1", false, &sequence),
+					testCustomerChannel("channel-2", true, nil),
+				)
+			},
+			wantChannels: []kotsclient.CustomerChannel{
+				{ID: "channel-1", PinnedChannelSequence: &sequence},
+				{ID: "channel-2", IsDefault: true},
+			},
+			wantChannelID: "channel-2",
+		},
+		{
+			name: "app id",
+			plan: func(m *CustomerResourceModel) {
+				m.AppId = types.StringValue("2fvVIbMQtNBwMzeTJt2yJrEKEFN")
+			},
+		},
+		{
+			name: "custom id",
+			plan: func(m *CustomerResourceModel) {
+				m.CustomId = types.StringValue("crm-1234")
+			},
+		},
+		{
+			name: "expiration with offset",
+			plan: func(m *CustomerResourceModel) {
+				m.ExpiresAt = NewTimestampValue("2030-01-30T16:04:05+01:00")
+			},
+		},
+		{
+			name: "unknown app",
+			plan: func(m *CustomerResourceModel) {
+				m.AppId = types.StringValue("other-app")
+			},
+			wantErr: "Invalid App",
+		},
+		{
+			name: "list apps error",
+			setup: func(api *fakeVendorAPI) {
+				api.errs["ListApps"] = errors.New("boom")
+			},
+			wantErr: "Invalid App",
+		},
+		{
+			name: "api error",
+			setup: func(api *fakeVendorAPI) {
+				api.errs["CreateCustomer"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			if tt.setup != nil {
+				tt.setup(api)
+			}
+			r := NewCustomerResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			plan := testCustomerPlanModel(t)
+			if tt.plan != nil {
+				tt.plan(&plan)
+			}
+
+			resp := fwresource.CreateResponse{State: testState(t, s, nil)}
+			r.Create(context.Background(), fwresource.CreateRequest{Plan: testPlan(t, s, &plan)}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			opts := api.lastCreateCustomerOpts
+			assert.Equal(t, "2fvVIbMQtNBwMzeTJt2yJrEKEFN", opts.AppID)
+			assert.Equal(t, "acme", opts.Name)
+			assert.Equal(t, "customer@example.com", opts.Email)
+			assert.Equal(t, "trial", opts.LicenseType)
+			assert.Equal(t, plan.CustomId.ValueString(), opts.CustomID)
+			assert.Equal(t, "2030-01-30T15:04:05Z", opts.ExpiresAt)
+			assert.True(t, opts.IsKotsInstallEnabled)
+			assert.True(t, opts.IsInstallerSupportEnabled)
+			wantChannels := tt.wantChannels
+			if wantChannels == nil {
+				wantChannels = []kotsclient.CustomerChannel{{ID: "channel-1", IsDefault: true}}
+			}
+			assert.ElementsMatch(t, wantChannels, opts.Channels)
+			wantValues := tt.wantValues
+			if wantValues == nil {
+				wantValues = []kotsclient.EntitlementValue{{Name: "seats", Value: "10"}}
+			}
+			assert.Equal(t, wantValues, opts.EntitlementValues)
+
+			var got CustomerResourceModel
+			require.False(t, resp.State.Get(context.Background(), &got).HasError())
+			assert.Regexp(t, `^app/2fvVIbMQtNBwMzeTJt2yJrEKEFN/customer/customer-\d+$`, got.Id.ValueString())
+			assert.Equal(t, plan.AppId, got.AppId)
+			assert.Equal(t, "acme", got.Name.ValueString())
+			assert.Equal(t, plan.CustomId, got.CustomId)
+			wantChannelID := tt.wantChannelID
+			if wantChannelID == "" {
+				wantChannelID = "channel-1"
+			}
+			assert.Equal(t, wantChannelID, got.ChannelId.ValueString())
+			assert.Equal(t, plan.Channels, got.Channels)
+			assert.Equal(t, plan.EntitlementValues, got.EntitlementValues)
+			assert.Equal(t, plan.SecretEntitlementValues, got.SecretEntitlementValues)
+			assert.Equal(t, types.MapValueMust(types.StringType, map[string]attr.Value{
+				"environment": types.StringValue("production"),
+				"sso_enabled": types.StringValue("false"),
+			}), got.DefaultEntitlementValues)
+
+			customer := api.customers[strings.Split(got.Id.ValueString(), "/")[3]]
+			assert.Equal(t, customer.InstallationID, got.LicenseId.ValueString())
+			assert.Equal(t, customer.InstallationID, got.InstallationId.ValueString())
+			assert.Equal(t, "2024-07-01T12:00:00Z", got.CreatedAt.ValueString())
+			assert.Equal(t, "2024-07-01T12:00:00Z", got.UpdatedAt.ValueString())
+			assert.Equal(t, registryCredentialsValue("customer@example.com", customer.InstallationID), got.RegistryCredentials)
+		})
+	}
+}
+
+func TestCustomerResourceCreateUnarchive(t *testing.T) {
+	tests := []struct {
+		name          string
+		setup         func(api *fakeVendorAPI) string
+		wantUnarchive bool
+		wantErr       string
+	}{
+		{
+			name: "archived customer",
+			setup: func(api *fakeVendorAPI) string {
+				customer, err := api.CreateCustomer(CreateCustomerOpts{CreateCustomerOpts: kotsclient.CreateCustomerOpts{Name: "acme", LicenseType: "dev"}})
+				require.NoError(t, err)
+				require.NoError(t, api.ArchiveCustomer(customer.ID))
+				return customer.ID
+			},
+			wantUnarchive: true,
+		},
+		{
+			name: "no archived customer",
+			setup: func(api *fakeVendorAPI) string {
+				customer, err := api.CreateCustomer(CreateCustomerOpts{CreateCustomerOpts: kotsclient.CreateCustomerOpts{Name: "other"}})
+				require.NoError(t, err)
+				require.NoError(t, api.ArchiveCustomer(customer.ID))
+				return customer.ID
+			},
+		},
+		{
+			name: "ambiguous archived customers",
+			setup: func(api *fakeVendorAPI) string {
+				for i := 0; i < 2; i++ {
+					customer, err := api.CreateCustomer(CreateCustomerOpts{CreateCustomerOpts: kotsclient.CreateCustomerOpts{Name: "acme"}})
+					require.NoError(t, err)
+					require.NoError(t, api.ArchiveCustomer(customer.ID))
+				}
+				return ""
+			},
+			wantErr: "Server Error",
+		},
+		{
+			name: "list archived customers error",
+			setup: func(api *fakeVendorAPI) string {
+				api.errs["ListArchivedCustomers"] = errors.New("boom")
+				return ""
+			},
+			wantErr: "Server Error",
+		},
+		{
+			name: "unarchive error",
+			setup: func(api *fakeVendorAPI) string {
+				customer, err := api.CreateCustomer(CreateCustomerOpts{CreateCustomerOpts: kotsclient.CreateCustomerOpts{Name: "acme"}})
+				require.NoError(t, err)
+				require.NoError(t, api.ArchiveCustomer(customer.ID))
+				api.errs["UnarchiveCustomer"] = errors.New("boom")
+				return customer.ID
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			archivedID := tt.setup(api)
+			r := NewCustomerResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			plan := testCustomerPlanModel(t)
+			plan.UnarchiveOnCreate = types.BoolValue(true)
+
+			resp := fwresource.CreateResponse{State: testState(t, s, nil)}
+			r.Create(context.Background(), fwresource.CreateRequest{Plan: testPlan(t, s, &plan)}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var got CustomerResourceModel
+			require.False(t, resp.State.Get(context.Background(), &got).HasError())
+			customerID := strings.Split(got.Id.ValueString(), "/")[3]
+			assert.Equal(t, tt.wantUnarchive, customerID == archivedID)
+			assert.False(t, api.archived[customerID])
+			assert.Equal(t, types.BoolValue(true), got.UnarchiveOnCreate)
+
+			// the unarchived customer is updated to match the configuration
+			assert.Equal(t, "trial", api.customers[customerID].Type)
+			assert.Equal(t, "customer@example.com", api.customers[customerID].Email)
+		})
+	}
+}
+
+func TestCustomerResourceFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		flag func(m *CustomerResourceModel) *types.Bool
+		opt  func(opts *CreateCustomerOpts) bool
+	}{
+		{
+			name: "is_airgap_enabled",
+			flag: func(m *CustomerResourceModel) *types.Bool { return &m.IsAirgapEnabled },
+			opt:  func(opts *CreateCustomerOpts) bool { return opts.IsAirgapEnabled },
+		},
+		{
+			name: "is_dev_mode_enabled",
+			flag: func(m *CustomerResourceModel) *types.Bool { return &m.IsDevModeEnabled },
+			opt:  func(opts *CreateCustomerOpts) bool { return opts.IsDevModeEnabled },
+		},
+		{
+			name: "is_disaster_recovery_supported",
+			flag: func(m *CustomerResourceModel) *types.Bool { return &m.IsDisasterRecoverySupported },
+			opt:  func(opts *CreateCustomerOpts) bool { return opts.IsDisasterRecoverySupported },
+		},
+		{
+			name: "is_embedded_cluster_download_enabled",
+			flag: func(m *CustomerResourceModel) *types.Bool { return &m.IsEmbeddedClusterDownloadEnabled },
+			opt:  func(opts *CreateCustomerOpts) bool { return opts.IsEmbeddedClusterDownloadEnabled },
+		},
+		{
+			name: "is_embedded_cluster_multinode_enabled",
+			flag: func(m *CustomerResourceModel) *types.Bool { return &m.IsEmbeddedClusterMultiNodeEnabled },
+			opt:  func(opts *CreateCustomerOpts) bool { return opts.IsEmbeddedClusterMultiNodeEnabled },
+		},
+		{
+			name: "is_geoaxis_supported",
+			flag: func(m *CustomerResourceModel) *types.Bool { return &m.IsGeoaxisSupported },
+			opt:  func(opts *CreateCustomerOpts) bool { return opts.IsGeoaxisSupported },
+		},
+		{
+			name: "is_gitops_supported",
+			flag: func(m *CustomerResourceModel) *types.Bool { return &m.IsGitopsSupported },
+			opt:  func(opts *CreateCustomerOpts) bool { return opts.IsGitopsSupported },
+		},
+		{
+			name: "is_helm_install_enabled",
+			flag: func(m *CustomerResourceModel) *types.Bool { return &m.IsHelmInstallEnabled },
+			opt:  func(opts *CreateCustomerOpts) bool { return opts.IsHelmInstallEnabled },
+		},
+		{
+			name: "is_helm_vm_download_enabled",
+			flag: func(m *CustomerResourceModel) *types.Bool { return &m.IsHelmVMDownloadEnabled },
+			opt:  func(opts *CreateCustomerOpts) bool { return opts.IsHelmVMDownloadEnabled },
+		},
+		{
+			name: "is_identity_service_supported",
+			flag: func(m *CustomerResourceModel) *types.Bool { return &m.IsIdentityServiceSupported },
+			opt:  func(opts *CreateCustomerOpts) bool { return opts.IsIdentityServiceSupported },
+		},
+		{
+			name: "is_installer_support_enabled",
+			flag: func(m *CustomerResourceModel) *types.Bool { return &m.IsInstallerSupportEnabled },
+			opt:  func(opts *CreateCustomerOpts) bool { return opts.IsInstallerSupportEnabled },
+		},
+		{
+			name: "is_kots_install_enabled",
+			flag: func(m *CustomerResourceModel) *types.Bool { return &m.IsKotsInstallEnabled },
+			opt:  func(opts *CreateCustomerOpts) bool { return opts.IsKotsInstallEnabled },
+		},
+		{
+			name: "is_kurl_install_enabled",
+			flag: func(m *CustomerResourceModel) *types.Bool { return &m.IsKurlInstallEnabled },
+			opt:  func(opts *CreateCustomerOpts) bool { return opts.IsKurlInstallEnabled },
+		},
+		{
+			name: "is_snapshot_supported",
+			flag: func(m *CustomerResourceModel) *types.Bool { return &m.IsSnapshotSupported },
+			opt:  func(opts *CreateCustomerOpts) bool { return opts.IsSnapshotSupported },
+		},
+		{
+			name: "is_support_bundle_upload_enabled",
+			flag: func(m *CustomerResourceModel) *types.Bool { return &m.IsSupportBundleUploadEnabled },
+			opt:  func(opts *CreateCustomerOpts) bool { return opts.IsSupportBundleUploadEnabled },
+		},
+	}
+	for _, tt := range tests {
+		for _, value := range []bool{true, false} {
+			t.Run(fmt.Sprintf("%s=%t", tt.name, value), func(t *testing.T) {
+				api := newFakeVendorAPI()
+				r := NewCustomerResource()
+				testConfiguredResource(t, r, api)
+				s := testResourceSchema(t, r)
+
+				// every other flag holds the opposite value, so that a flag
+				// mapped to the wrong field cannot go unnoticed
+				plan := testCustomerPlanModel(t)
+				for _, other := range tests {
+					*other.flag(&plan) = types.BoolValue(!value)
+				}
+				*tt.flag(&plan) = types.BoolValue(value)
+
+				createResp := fwresource.CreateResponse{State: testState(t, s, nil)}
+				r.Create(context.Background(), fwresource.CreateRequest{Plan: testPlan(t, s, &plan)}, &createResp)
+				require.False(t, createResp.Diagnostics.HasError(), "%v", createResp.Diagnostics)
+				assert.Equal(t, value, tt.opt(api.lastCreateCustomerOpts))
+
+				readResp := fwresource.ReadResponse{State: createResp.State}
+				r.Read(context.Background(), fwresource.ReadRequest{State: createResp.State}, &readResp)
+				require.False(t, readResp.Diagnostics.HasError(), "%v", readResp.Diagnostics)
+
+				var created, read CustomerResourceModel
+				require.False(t, createResp.State.Get(context.Background(), &created).HasError())
+				require.False(t, readResp.State.Get(context.Background(), &read).HasError())
+				assert.Equal(t, types.BoolValue(value), *tt.flag(&created))
+				assert.Equal(t, types.BoolValue(value), *tt.flag(&read))
+				for _, other := range tests {
+					assert.Equal(t, *other.flag(&created), *other.flag(&read), other.name)
+				}
+			})
+		}
+	}
+}
+
+func TestCustomerResourceCreateDetailsError(t *testing.T) {
+	api := newFakeVendorAPI()
+	api.errs["GetCustomerDetails"] = errors.New("boom")
+	r := NewCustomerResource()
+	testConfiguredResource(t, r, api)
+	s := testResourceSchema(t, r)
+
+	plan := testCustomerPlanModel(t)
+	resp := fwresource.CreateResponse{State: testState(t, s, nil)}
+	r.Create(context.Background(), fwresource.CreateRequest{Plan: testPlan(t, s, &plan)}, &resp)
+
+	// the customer was created, so it is saved with what is known about it
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	require.Len(t, resp.Diagnostics.Warnings(), 1)
+	assert.Equal(t, "Incomplete Customer", resp.Diagnostics.Warnings()[0].Summary())
+
+	var got CustomerResourceModel
+	require.False(t, resp.State.Get(context.Background(), &got).HasError())
+	assert.Regexp(t, `^app/2fvVIbMQtNBwMzeTJt2yJrEKEFN/customer/customer-\d+$`, got.Id.ValueString())
+	assert.True(t, got.CreatedAt.IsNull())
+	assert.True(t, got.UpdatedAt.IsNull())
+	assert.Equal(t, types.StringValue("channel-1"), got.ChannelId)
+	assert.Equal(t, testCustomerChannels(t, testCustomerChannel("channel-1", true, nil)), got.Channels)
+}
+
+func TestCustomerResourceRead(t *testing.T) {
+	tests := []struct {
+		name        string
+		setup       func(api *fakeVendorAPI, m *CustomerResourceModel)
+		wantErr     string
+		wantRemoved bool
+	}{
+		{
+			name: "existing customer",
+		},
+		{
+			name: "changed outside terraform",
+			setup: func(api *fakeVendorAPI, m *CustomerResourceModel) {
+				for _, customer := range api.customers {
+					customer.Name = "acme-renamed"
+				}
+			},
+		},
+		{
+			name: "archived customer",
+			setup: func(api *fakeVendorAPI, m *CustomerResourceModel) {
+				for id := range api.customers {
+					api.archived[id] = true
+				}
+			},
+			wantRemoved: true,
+		},
+		{
+			name: "imported customer",
+			setup: func(api *fakeVendorAPI, m *CustomerResourceModel) {
+				m.DeletionPolicy = types.StringNull()
+				m.UnarchiveOnCreate = types.BoolNull()
+			},
+		},
+		{
+			name: "api error",
+			setup: func(api *fakeVendorAPI, m *CustomerResourceModel) {
+				api.errs["GetCustomerByNameOrId"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+		{
+			name: "list apps error",
+			setup: func(api *fakeVendorAPI, m *CustomerResourceModel) {
+				api.errs["ListApps"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+		{
+			name: "malformed id",
+			setup: func(api *fakeVendorAPI, m *CustomerResourceModel) {
+				m.Id = types.StringValue("customer-1")
+			},
+			wantErr: "Invalid Resource ID",
+		},
+		{
+			name: "get details error",
+			setup: func(api *fakeVendorAPI, m *CustomerResourceModel) {
+				api.errs["GetCustomerDetails"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			prior := testCustomerState(t, api)
+			if tt.setup != nil {
+				tt.setup(api, &prior)
+			}
+			r := NewCustomerResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			state := testState(t, s, &prior)
+			resp := fwresource.ReadResponse{State: state}
+			r.Read(context.Background(), fwresource.ReadRequest{State: state}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			if tt.wantRemoved {
+				assert.True(t, resp.State.Raw.IsNull())
+				return
+			}
+
+			var got CustomerResourceModel
+			require.False(t, resp.State.Get(context.Background(), &got).HasError())
+			assert.Equal(t, prior.Id, got.Id)
+			assert.Equal(t, prior.AppId, got.AppId)
+
+			customer := api.customers[strings.Split(prior.Id.ValueString(), "/")[3]]
+			assert.Equal(t, customer.Name, got.Name.ValueString())
+			assert.Equal(t, prior.LicenseId, got.LicenseId)
+			assert.Equal(t, prior.CreatedAt, got.CreatedAt)
+			assert.Equal(t, prior.RegistryCredentials, got.RegistryCredentials)
+			assert.Equal(t, types.StringValue("archive"), got.DeletionPolicy)
+			assert.Equal(t, types.BoolValue(false), got.UnarchiveOnCreate)
+		})
+	}
+}
+
+func TestCustomerResourceReadChannels(t *testing.T) {
+	api := newFakeVendorAPI()
+	customer, err := api.CreateCustomer(CreateCustomerOpts{
+		CreateCustomerOpts: kotsclient.CreateCustomerOpts{
+			Name:     "acme",
+			AppID:    testFakeAppID,
+			Channels: []kotsclient.CustomerChannel{{ID: "channel-1", IsDefault: true}, {ID: "channel-2"}},
+		},
+	})
+	require.NoError(t, err)
+	details, err := api.GetCustomerDetails(customer.ID)
+	require.NoError(t, err)
+	prior := getCustomerResourceModelFromCustomer(testFakeAppID, customer)
+	require.False(t, prior.applyDetails(context.Background(), details).HasError())
+
+	// the default channel and the pin are changed in the vendor portal
+	sequence := int64(4)
+	api.details[customer.ID].Channels = []CustomerChannelDetails{
+		{ID: "channel-1", PinnedChannelSequence: &sequence},
+		{ID: "channel-2", IsDefault: true},
+	}
+
+	r := NewCustomerResource()
+	testConfiguredResource(t, r, api)
+	s := testResourceSchema(t, r)
+
+	state := testState(t, s, &prior)
+	resp := fwresource.ReadResponse{State: state}
+	r.Read(context.Background(), fwresource.ReadRequest{State: state}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	var got CustomerResourceModel
+	require.False(t, resp.State.Get(context.Background(), &got).HasError())
+	assert.Equal(t, types.StringValue("channel-2"), got.ChannelId)
+	assert.Equal(t, testCustomerChannels(t,
+		testCustomerChannel("channel-1", false, &sequence),
+		testCustomerChannel("channel-2", true, nil),
+	), got.Channels)
+}
+
+func TestCustomerResourceUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		plan    func(m *CustomerResourceModel)
+		setup   func(api *fakeVendorAPI)
+		wantErr string
+	}{
+		{
+			name: "rename",
+			plan: func(m *CustomerResourceModel) {
+				m.Name = types.StringValue("acme-renamed")
+			},
+		},
+		{
+			name: "unknown app",
+			plan: func(m *CustomerResourceModel) {
+				m.AppId = types.StringValue("other-app")
+			},
+			wantErr: "Invalid App",
+		},
+		{
+			name: "api error",
+			setup: func(api *fakeVendorAPI) {
+				api.errs["UpdateCustomer"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			prior := testCustomerState(t, api)
+			if tt.setup != nil {
+				tt.setup(api)
+			}
+			r := NewCustomerResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			plan := testCustomerPlanModel(t)
+			plan.Id = prior.Id
+			if tt.plan != nil {
+				tt.plan(&plan)
+			}
+
+			resp := fwresource.UpdateResponse{State: testState(t, s, &prior)}
+			r.Update(context.Background(), fwresource.UpdateRequest{
+				State: testState(t, s, &prior),
+				Plan:  testPlan(t, s, &plan),
+			}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			assert.Equal(t, "2fvVIbMQtNBwMzeTJt2yJrEKEFN", api.lastUpdateCustomerOpts.AppID)
+			assert.Equal(t, plan.Name.ValueString(), api.lastUpdateCustomerOpts.Name)
+
+			var got CustomerResourceModel
+			require.False(t, resp.State.Get(context.Background(), &got).HasError())
+			assert.Equal(t, prior.Id, got.Id)
+			assert.Equal(t, plan.AppId, got.AppId)
+			assert.Equal(t, plan.Name, got.Name)
+			assert.Equal(t, prior.LicenseId, got.LicenseId)
+			assert.Equal(t, prior.CreatedAt, got.CreatedAt)
+			assert.Equal(t, "2024-07-01T13:00:00Z", got.UpdatedAt.ValueString())
+		})
+	}
+}
+
+func TestCustomerResourceUpdateDetailsError(t *testing.T) {
+	api := newFakeVendorAPI()
+	prior := testCustomerState(t, api)
+	api.errs["GetCustomerDetails"] = errors.New("boom")
+	r := NewCustomerResource()
+	testConfiguredResource(t, r, api)
+	s := testResourceSchema(t, r)
+
+	plan := testCustomerPlanModel(t)
+	plan.Id = prior.Id
+	plan.CreatedAt = prior.CreatedAt
+	plan.Name = types.StringValue("acme-renamed")
+
+	resp := fwresource.UpdateResponse{State: testState(t, s, &prior)}
+	r.Update(context.Background(), fwresource.UpdateRequest{
+		State: testState(t, s, &prior),
+		Plan:  testPlan(t, s, &plan),
+	}, &resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	require.Len(t, resp.Diagnostics.Warnings(), 1)
+	assert.Equal(t, "Incomplete Customer", resp.Diagnostics.Warnings()[0].Summary())
+
+	var got CustomerResourceModel
+	require.False(t, resp.State.Get(context.Background(), &got).HasError())
+	assert.Equal(t, types.StringValue("acme-renamed"), got.Name)
+	assert.Equal(t, prior.CreatedAt, got.CreatedAt)
+	assert.True(t, got.UpdatedAt.IsNull())
+	assert.Equal(t, types.StringValue("channel-1"), got.ChannelId)
+}
+
+func TestCustomerResourceUpdatePayload(t *testing.T) {
+	tests := []struct {
+		name     string
+		plan     func(m *CustomerResourceModel)
+		wantOpts func(opts *UpdateCustomerOpts)
+	}{
+		{
+			name: "no change",
+		},
+		{
+			name:     "name",
+			plan:     func(m *CustomerResourceModel) { m.Name = types.StringValue("acme-renamed") },
+			wantOpts: func(opts *UpdateCustomerOpts) { opts.Name = "acme-renamed" },
+		},
+		{
+			name:     "custom_id",
+			plan:     func(m *CustomerResourceModel) { m.CustomId = types.StringValue("crm-1234") },
+			wantOpts: func(opts *UpdateCustomerOpts) { opts.CustomID = "crm-1234" },
+		},
+		{
+			name:     "email",
+			plan:     func(m *CustomerResourceModel) { m.Email = types.StringValue("other@example.com") },
+			wantOpts: func(opts *UpdateCustomerOpts) { opts.Email = "other@example.com" },
+		},
+		{
+			name:     "type",
+			plan:     func(m *CustomerResourceModel) { m.Type = types.StringValue("paid") },
+			wantOpts: func(opts *UpdateCustomerOpts) { opts.LicenseType = "paid" },
+		},
+		{
+			name:     "expires_at",
+			plan:     func(m *CustomerResourceModel) { m.ExpiresAt = NewTimestampValue("2031-01-30T15:04:05Z") },
+			wantOpts: func(opts *UpdateCustomerOpts) { opts.ExpiresAt = "2031-01-30T15:04:05Z" },
+		},
+		{
+			name:     "expires_at removed",
+			plan:     func(m *CustomerResourceModel) { m.ExpiresAt = NewTimestampNull() },
+			wantOpts: func(opts *UpdateCustomerOpts) { opts.ExpiresAt = "" },
+		},
+		{
+			name: "channel_id",
+			plan: func(m *CustomerResourceModel) {
+				m.ChannelId = types.StringValue("channel-2")
+				m.Channels = testCustomerChannels(t, testCustomerChannel("channel-2", true, nil))
+			},
+			wantOpts: func(opts *UpdateCustomerOpts) {
+				opts.Channels = []kotsclient.CustomerChannel{{ID: "channel-2", IsDefault: true}}
+			},
+		},
+		{
+			name: "entitlement_values",
+			plan: func(m *CustomerResourceModel) {
+				m.EntitlementValues = types.MapValueMust(types.StringType, map[string]attr.Value{"seats": types.StringValue("20")})
+			},
+			wantOpts: func(opts *UpdateCustomerOpts) {
+				opts.EntitlementValues = []kotsclient.EntitlementValue{{Name: "seats", Value: "20"}}
+			},
+		},
+		{
+			name:     "is_airgap_enabled",
+			plan:     func(m *CustomerResourceModel) { m.IsAirgapEnabled = types.BoolValue(true) },
+			wantOpts: func(opts *UpdateCustomerOpts) { opts.IsAirgapEnabled = true },
+		},
+		{
+			name:     "is_dev_mode_enabled",
+			plan:     func(m *CustomerResourceModel) { m.IsDevModeEnabled = types.BoolValue(true) },
+			wantOpts: func(opts *UpdateCustomerOpts) { opts.IsDevModeEnabled = true },
+		},
+		{
+			name:     "is_disaster_recovery_supported",
+			plan:     func(m *CustomerResourceModel) { m.IsDisasterRecoverySupported = types.BoolValue(true) },
+			wantOpts: func(opts *UpdateCustomerOpts) { opts.IsDisasterRecoverySupported = true },
+		},
+		{
+			name:     "is_embedded_cluster_download_enabled",
+			plan:     func(m *CustomerResourceModel) { m.IsEmbeddedClusterDownloadEnabled = types.BoolValue(true) },
+			wantOpts: func(opts *UpdateCustomerOpts) { opts.IsEmbeddedClusterDownloadEnabled = true },
+		},
+		{
+			name:     "is_embedded_cluster_multinode_enabled",
+			plan:     func(m *CustomerResourceModel) { m.IsEmbeddedClusterMultiNodeEnabled = types.BoolValue(true) },
+			wantOpts: func(opts *UpdateCustomerOpts) { opts.IsEmbeddedClusterMultiNodeEnabled = true },
+		},
+		{
+			name:     "is_geoaxis_supported",
+			plan:     func(m *CustomerResourceModel) { m.IsGeoaxisSupported = types.BoolValue(true) },
+			wantOpts: func(opts *UpdateCustomerOpts) { opts.IsGeoaxisSupported = true },
+		},
+		{
+			name:     "is_gitops_supported",
+			plan:     func(m *CustomerResourceModel) { m.IsGitopsSupported = types.BoolValue(true) },
+			wantOpts: func(opts *UpdateCustomerOpts) { opts.IsGitopsSupported = true },
+		},
+		{
+			name:     "is_helm_install_enabled",
+			plan:     func(m *CustomerResourceModel) { m.IsHelmInstallEnabled = types.BoolValue(true) },
+			wantOpts: func(opts *UpdateCustomerOpts) { opts.IsHelmInstallEnabled = true },
+		},
+		{
+			name:     "is_helm_vm_download_enabled",
+			plan:     func(m *CustomerResourceModel) { m.IsHelmVMDownloadEnabled = types.BoolValue(true) },
+			wantOpts: func(opts *UpdateCustomerOpts) { opts.IsHelmVMDownloadEnabled = true },
+		},
+		{
+			name:     "is_identity_service_supported",
+			plan:     func(m *CustomerResourceModel) { m.IsIdentityServiceSupported = types.BoolValue(true) },
+			wantOpts: func(opts *UpdateCustomerOpts) { opts.IsIdentityServiceSupported = true },
+		},
+		{
+			name:     "is_installer_support_enabled",
+			plan:     func(m *CustomerResourceModel) { m.IsInstallerSupportEnabled = types.BoolValue(false) },
+			wantOpts: func(opts *UpdateCustomerOpts) { opts.IsInstallerSupportEnabled = false },
+		},
+		{
+			name:     "is_kots_install_enabled",
+			plan:     func(m *CustomerResourceModel) { m.IsKotsInstallEnabled = types.BoolValue(false) },
+			wantOpts: func(opts *UpdateCustomerOpts) { opts.IsKotsInstallEnabled = false },
+		},
+		{
+			name:     "is_kurl_install_enabled",
+			plan:     func(m *CustomerResourceModel) { m.IsKurlInstallEnabled = types.BoolValue(true) },
+			wantOpts: func(opts *UpdateCustomerOpts) { opts.IsKurlInstallEnabled = true },
+		},
+		{
+			name:     "is_snapshot_supported",
+			plan:     func(m *CustomerResourceModel) { m.IsSnapshotSupported = types.BoolValue(true) },
+			wantOpts: func(opts *UpdateCustomerOpts) { opts.IsSnapshotSupported = true },
+		},
+		{
+			name:     "is_support_bundle_upload_enabled",
+			plan:     func(m *CustomerResourceModel) { m.IsSupportBundleUploadEnabled = types.BoolValue(true) },
+			wantOpts: func(opts *UpdateCustomerOpts) { opts.IsSupportBundleUploadEnabled = true },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			prior := testCustomerState(t, api)
+			r := NewCustomerResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			plan := testCustomerPlanModel(t)
+			plan.Id = prior.Id
+			if tt.plan != nil {
+				tt.plan(&plan)
+			}
+
+			resp := fwresource.UpdateResponse{State: testState(t, s, &prior)}
+			r.Update(context.Background(), fwresource.UpdateRequest{
+				State: testState(t, s, &prior),
+				Plan:  testPlan(t, s, &plan),
+			}, &resp)
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			// every update sends the whole customer, unchanged fields
+			// included, since the vendor api resets the fields it is not sent
+			wantOpts := UpdateCustomerOpts{
+				UpdateCustomerOpts: kotsclient.UpdateCustomerOpts{
+					Name:                 "acme",
+					Channels:             []kotsclient.CustomerChannel{{ID: "channel-1", IsDefault: true}},
+					AppID:                "2fvVIbMQtNBwMzeTJt2yJrEKEFN",
+					ExpiresAt:            "2030-01-30T15:04:05Z",
+					IsKotsInstallEnabled: true,
+					LicenseType:          "trial",
+					Email:                "customer@example.com",
+					EntitlementValues:    []kotsclient.EntitlementValue{{Name: "seats", Value: "10"}},
+				},
+				IsInstallerSupportEnabled: true,
+			}
+			if tt.wantOpts != nil {
+				tt.wantOpts(&wantOpts)
+			}
+			assert.Equal(t, wantOpts, *api.lastUpdateCustomerOpts)
+
+			// the state matches the plan, so the next plan is empty
+			var got CustomerResourceModel
+			require.False(t, resp.State.Get(context.Background(), &got).HasError())
+			got.LicenseId, got.InstallationId, got.CreatedAt, got.UpdatedAt = plan.LicenseId, plan.InstallationId, plan.CreatedAt, plan.UpdatedAt
+			got.ResolvedExpiresAt = plan.ResolvedExpiresAt
+			got.RegistryCredentials, got.DefaultEntitlementValues = plan.RegistryCredentials, plan.DefaultEntitlementValues
+			assert.Equal(t, plan, got)
+		})
+	}
+}
+
+func TestCustomerResourceTestCustomer(t *testing.T) {
+	api := newFakeVendorAPI()
+	prior := testCustomerState(t, api)
+	_, customerID, diags := parseCustomerResourceID(prior.Id.ValueString())
+	require.False(t, diags.HasError())
+	api.customers[customerID].Type = "test"
+	prior.Type = types.StringValue("test")
+	prior.ExpiresAt = NewTimestampValue("+365d")
+
+	customers, err := api.ListCustomers("2fvVIbMQtNBwMzeTJt2yJrEKEFN", false)
+	require.NoError(t, err)
+	require.Empty(t, customers)
+
+	r := NewCustomerResource()
+	testConfiguredResource(t, r, api)
+	s := testResourceSchema(t, r)
+
+	t.Run("read", func(t *testing.T) {
+		state := testState(t, s, &prior)
+		resp := fwresource.ReadResponse{State: state}
+		r.Read(context.Background(), fwresource.ReadRequest{State: state}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+		require.False(t, resp.State.Raw.IsNull())
+
+		var got CustomerResourceModel
+		require.False(t, resp.State.Get(context.Background(), &got).HasError())
+		assert.Equal(t, prior.Id, got.Id)
+		assert.Equal(t, types.StringValue("test"), got.Type)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		plan := testCustomerPlanModel(t)
+		plan.Id = prior.Id
+		plan.Type = types.StringValue("test")
+		plan.ExpiresAt = prior.ExpiresAt
+
+		resp := fwresource.UpdateResponse{State: testState(t, s, &prior)}
+		r.Update(context.Background(), fwresource.UpdateRequest{
+			State: testState(t, s, &prior),
+			Plan:  testPlan(t, s, &plan),
+		}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+		assert.Equal(t, "2030-01-30T15:04:05Z", api.lastUpdateCustomerOpts.ExpiresAt)
+	})
+
+	t.Run("import", func(t *testing.T) {
+		resp := fwresource.ImportStateResponse{State: testState(t, s, nil)}
+		r.(fwresource.ResourceWithImportState).ImportState(context.Background(), fwresource.ImportStateRequest{ID: "test-app/acme"}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+		var id string
+		require.False(t, resp.State.GetAttribute(context.Background(), path.Root("id"), &id).HasError())
+		assert.Equal(t, prior.Id.ValueString(), id)
+	})
+}
+
+func TestCustomerResourceRelativeExpiration(t *testing.T) {
+	tests := []struct {
+		name          string
+		update        bool
+		priorExpires  string
+		wantExpiresAt func(now time.Time) string
+	}{
+		{
+			name: "create",
+			wantExpiresAt: func(now time.Time) string {
+				return now.AddDate(0, 0, 365).Format(time.RFC3339)
+			},
+		},
+		{
+			name:         "update keeps the resolved expiration",
+			update:       true,
+			priorExpires: "+365d",
+			wantExpiresAt: func(now time.Time) string {
+				return "2030-01-30T15:04:05Z"
+			},
+		},
+		{
+			name:         "update to a relative expiration",
+			update:       true,
+			priorExpires: "2030-01-30T15:04:05Z",
+			wantExpiresAt: func(now time.Time) string {
+				return now.AddDate(0, 0, 365).Format(time.RFC3339)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			r := NewCustomerResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			plan := testCustomerPlanModel(t)
+			plan.ExpiresAt = NewTimestampValue("+365d")
+
+			now := time.Now().UTC()
+			var sent string
+			if tt.update {
+				prior := testCustomerState(t, api)
+				prior.ExpiresAt = NewTimestampValue(tt.priorExpires)
+				plan.Id = prior.Id
+				plan.Name = types.StringValue("acme-renamed")
+
+				resp := fwresource.UpdateResponse{State: testState(t, s, &prior)}
+				r.Update(context.Background(), fwresource.UpdateRequest{
+					State: testState(t, s, &prior),
+					Plan:  testPlan(t, s, &plan),
+				}, &resp)
+				require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+				sent = api.lastUpdateCustomerOpts.ExpiresAt
+			} else {
+				resp := fwresource.CreateResponse{State: testState(t, s, nil)}
+				r.Create(context.Background(), fwresource.CreateRequest{Plan: testPlan(t, s, &plan)}, &resp)
+				require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+				sent = api.lastCreateCustomerOpts.ExpiresAt
+			}
+
+			got, err := time.Parse(time.RFC3339, sent)
+			require.NoError(t, err)
+			want, err := time.Parse(time.RFC3339, tt.wantExpiresAt(now))
+			require.NoError(t, err)
+			assert.WithinDuration(t, want, got, time.Minute)
+		})
+	}
+}
+
+func TestCustomerResourceReadRelativeExpiration(t *testing.T) {
+	tests := []struct {
+		name          string
+		setup         func(api *fakeVendorAPI, customerID string)
+		priorResolved types.String
+		wantExpiresAt TimestampValue
+	}{
+		{
+			name:          "unchanged",
+			priorResolved: types.StringValue("2030-01-30T15:04:05Z"),
+			wantExpiresAt: NewTimestampValue("+365d"),
+		},
+		{
+			name: "changed outside terraform",
+			setup: func(api *fakeVendorAPI, customerID string) {
+				api.customers[customerID].Expires = &util.Time{Time: time.Date(2031, 1, 30, 15, 4, 5, 0, time.UTC)}
+			},
+			priorResolved: types.StringValue("2030-01-30T15:04:05Z"),
+			wantExpiresAt: NewTimestampValue("2031-01-30T15:04:05Z"),
+		},
+		{
+			name:          "state without resolved expiration",
+			priorResolved: types.StringNull(),
+			wantExpiresAt: NewTimestampValue("+365d"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			prior := testCustomerState(t, api)
+			prior.ExpiresAt = NewTimestampValue("+365d")
+			prior.ResolvedExpiresAt = tt.priorResolved
+			if tt.setup != nil {
+				tt.setup(api, strings.Split(prior.Id.ValueString(), "/")[3])
+			}
+			r := NewCustomerResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			state := testState(t, s, &prior)
+			resp := fwresource.ReadResponse{State: state}
+			r.Read(context.Background(), fwresource.ReadRequest{State: state}, &resp)
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var got CustomerResourceModel
+			require.False(t, resp.State.Get(context.Background(), &got).HasError())
+			assert.Equal(t, tt.wantExpiresAt, got.ExpiresAt)
+		})
+	}
+}
+
+func TestCustomerResourceModifyPlanResolvedExpiration(t *testing.T) {
+	tests := []struct {
+		name         string
+		expiresAt    TimestampValue
+		wantResolved types.String
+	}{
+		{
+			name:         "unchanged",
+			expiresAt:    NewTimestampValue("+365d"),
+			wantResolved: types.StringValue("2030-01-30T15:04:05Z"),
+		},
+		{
+			name:         "changed",
+			expiresAt:    NewTimestampValue("+30d"),
+			wantResolved: types.StringUnknown(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewCustomerResource()
+			s := testResourceSchema(t, r)
+
+			prior := testCustomerPlanModel(t)
+			prior.Id = types.StringValue("app/test-app/customer/customer-1")
+			prior.ExpiresAt = NewTimestampValue("+365d")
+			prior.ResolvedExpiresAt = types.StringValue("2030-01-30T15:04:05Z")
+
+			config := testCustomerPlanModel(t)
+			config.ExpiresAt = tt.expiresAt
+			plan := config
+			plan.Id = prior.Id
+
+			resp := fwresource.ModifyPlanResponse{Plan: testPlan(t, s, &plan)}
+			r.(fwresource.ResourceWithModifyPlan).ModifyPlan(context.Background(), fwresource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: s, Raw: testState(t, s, &config).Raw},
+				Plan:   testPlan(t, s, &plan),
+				State:  testState(t, s, &prior),
+			}, &resp)
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var got CustomerResourceModel
+			require.False(t, resp.Plan.Get(context.Background(), &got).HasError())
+			assert.Equal(t, tt.wantResolved, got.ResolvedExpiresAt)
+		})
+	}
+}
+
+func TestCustomerResourceDelete(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       string
+		setup        func(api *fakeVendorAPI)
+		wantArchived bool
+		wantErr      string
+	}{
+		{
+			name:         "archive",
+			policy:       "archive",
+			wantArchived: true,
+		},
+		{
+			name:   "retain",
+			policy: "retain",
+			setup: func(api *fakeVendorAPI) {
+				api.errs["ArchiveCustomer"] = errors.New("must not be called")
+			},
+		},
+		{
+			name:   "api error",
+			policy: "archive",
+			setup: func(api *fakeVendorAPI) {
+				api.errs["ArchiveCustomer"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			prior := testCustomerState(t, api)
+			prior.DeletionPolicy = types.StringValue(tt.policy)
+			if tt.setup != nil {
+				tt.setup(api)
+			}
+			r := NewCustomerResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			state := testState(t, s, &prior)
+			resp := fwresource.DeleteResponse{State: state}
+			r.Delete(context.Background(), fwresource.DeleteRequest{State: state}, &resp)
+
+			customerID := strings.Split(prior.Id.ValueString(), "/")[3]
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				assert.False(t, api.archived[customerID])
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+			assert.Equal(t, tt.wantArchived, api.archived[customerID])
+		})
+	}
+}
+
+func TestCustomerResourceValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  func(m *CustomerResourceModel)
+		wantErr string
+	}{
+		{
+			name: "channel id",
+			config: func(m *CustomerResourceModel) {
+				m.Channels = types.SetNull(types.ObjectType{AttrTypes: customerChannelAttrTypes})
+			},
+		},
+		{
+			name: "channels",
+			config: func(m *CustomerResourceModel) {
+				m.ChannelId = types.StringNull()
+				m.Channels = testCustomerChannels(t,
+					testCustomerChannel("channel-1", true, nil),
+					CustomerChannelModel{Id: types.StringValue("channel-2"), IsDefault: types.BoolNull(), PinnedReleaseSequence: types.Int64Null()},
+				)
+			},
+		},
+		{
+			name:    "both",
+			config:  func(m *CustomerResourceModel) {},
+			wantErr: "Conflicting Channel Configuration",
+		},
+		{
+			name: "retain",
+			config: func(m *CustomerResourceModel) {
+				m.Channels = types.SetNull(types.ObjectType{AttrTypes: customerChannelAttrTypes})
+				m.DeletionPolicy = types.StringValue("retain")
+			},
+		},
+		{
+			name: "delete",
+			config: func(m *CustomerResourceModel) {
+				m.Channels = types.SetNull(types.ObjectType{AttrTypes: customerChannelAttrTypes})
+				m.DeletionPolicy = types.StringValue("delete")
+			},
+			wantErr: "Invalid Deletion Policy",
+		},
+		{
+			name: "unknown deletion policy",
+			config: func(m *CustomerResourceModel) {
+				m.Channels = types.SetNull(types.ObjectType{AttrTypes: customerChannelAttrTypes})
+				m.DeletionPolicy = types.StringValue("destroy")
+			},
+			wantErr: "Invalid Deletion Policy",
+		},
+		{
+			name: "neither",
+			config: func(m *CustomerResourceModel) {
+				m.ChannelId = types.StringNull()
+				m.Channels = types.SetNull(types.ObjectType{AttrTypes: customerChannelAttrTypes})
+			},
+			wantErr: "Missing Channel Configuration",
+		},
+		{
+			name: "no default channel",
+			config: func(m *CustomerResourceModel) {
+				m.ChannelId = types.StringNull()
+				m.Channels = testCustomerChannels(t, testCustomerChannel("channel-1", false, nil))
+			},
+			wantErr: "Invalid Default Channel",
+		},
+		{
+			name: "two default channels",
+			config: func(m *CustomerResourceModel) {
+				m.ChannelId = types.StringNull()
+				m.Channels = testCustomerChannels(t,
+					testCustomerChannel("channel-1", true, nil),
+					testCustomerChannel("channel-2", true, nil),
+				)
+			},
+			wantErr: "Invalid Default Channel",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewCustomerResource()
+			s := testResourceSchema(t, r)
+
+			config := testCustomerPlanModel(t)
+			config.Id = types.StringNull()
+			tt.config(&config)
+
+			resp := fwresource.ValidateConfigResponse{}
+			r.(fwresource.ResourceWithValidateConfig).ValidateConfig(context.Background(), fwresource.ValidateConfigRequest{
+				Config: tfsdk.Config{Schema: s, Raw: testState(t, s, &config).Raw},
+			}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+		})
+	}
+}
+
+func TestCustomerResourceModifyPlan(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        func(m *CustomerResourceModel)
+		wantChannelID types.String
+		wantChannels  types.Set
+	}{
+		{
+			name: "channels from channel id",
+			config: func(m *CustomerResourceModel) {
+				m.Channels = types.SetNull(types.ObjectType{AttrTypes: customerChannelAttrTypes})
+			},
+			wantChannelID: types.StringValue("channel-1"),
+			wantChannels:  testCustomerChannels(t, testCustomerChannel("channel-1", true, nil)),
+		},
+		{
+			name: "channel id from channels",
+			config: func(m *CustomerResourceModel) {
+				m.ChannelId = types.StringNull()
+				m.Channels = testCustomerChannels(t,
+					testCustomerChannel("channel-1", false, nil),
+					testCustomerChannel("channel-2", true, nil),
+				)
+			},
+			wantChannelID: types.StringValue("channel-2"),
+			wantChannels: testCustomerChannels(t,
+				testCustomerChannel("channel-1", false, nil),
+				testCustomerChannel("channel-2", true, nil),
+			),
+		},
+		{
+			name: "unknown channel id",
+			config: func(m *CustomerResourceModel) {
+				m.ChannelId = types.StringUnknown()
+				m.Channels = types.SetNull(types.ObjectType{AttrTypes: customerChannelAttrTypes})
+			},
+			wantChannelID: types.StringUnknown(),
+			wantChannels:  types.SetUnknown(types.ObjectType{AttrTypes: customerChannelAttrTypes}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewCustomerResource()
+			s := testResourceSchema(t, r)
+
+			config := testCustomerPlanModel(t)
+			config.Id = types.StringNull()
+			tt.config(&config)
+
+			// the framework marks unconfigured computed attributes as unknown
+			plan := config
+			plan.Id = types.StringUnknown()
+			if plan.ChannelId.IsNull() {
+				plan.ChannelId = types.StringUnknown()
+			}
+			if plan.Channels.IsNull() {
+				plan.Channels = types.SetUnknown(types.ObjectType{AttrTypes: customerChannelAttrTypes})
+			}
+
+			resp := fwresource.ModifyPlanResponse{Plan: testPlan(t, s, &plan)}
+			r.(fwresource.ResourceWithModifyPlan).ModifyPlan(context.Background(), fwresource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: s, Raw: testState(t, s, &config).Raw},
+				Plan:   testPlan(t, s, &plan),
+				State:  testState(t, s, nil),
+			}, &resp)
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var got CustomerResourceModel
+			require.False(t, resp.Plan.Get(context.Background(), &got).HasError())
+			assert.Equal(t, tt.wantChannelID, got.ChannelId)
+			assert.Equal(t, tt.wantChannels, got.Channels)
+		})
+	}
+}
+
+func TestCustomerResourceModifyPlanRegistryCredentials(t *testing.T) {
+	tests := []struct {
+		name      string
+		plan      func(m *CustomerResourceModel)
+		wantCreds types.Object
+	}{
+		{
+			name:      "new customer",
+			wantCreds: types.ObjectUnknown(registryCredentialsAttrTypes),
+		},
+		{
+			name: "existing customer",
+			plan: func(m *CustomerResourceModel) {
+				m.LicenseId = types.StringValue("license-1")
+			},
+			wantCreds: registryCredentialsValue("customer@example.com", "license-1"),
+		},
+		{
+			name: "unknown email",
+			plan: func(m *CustomerResourceModel) {
+				m.LicenseId = types.StringValue("license-1")
+				m.Email = types.StringUnknown()
+			},
+			wantCreds: types.ObjectUnknown(registryCredentialsAttrTypes),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewCustomerResource()
+			s := testResourceSchema(t, r)
+
+			config := testCustomerPlanModel(t)
+			plan := config
+			if tt.plan != nil {
+				tt.plan(&plan)
+			}
+
+			resp := fwresource.ModifyPlanResponse{Plan: testPlan(t, s, &plan)}
+			r.(fwresource.ResourceWithModifyPlan).ModifyPlan(context.Background(), fwresource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: s, Raw: testState(t, s, &config).Raw},
+				Plan:   testPlan(t, s, &plan),
+				State:  testState(t, s, nil),
+			}, &resp)
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var got CustomerResourceModel
+			require.False(t, resp.Plan.Get(context.Background(), &got).HasError())
+			assert.Equal(t, tt.wantCreds, got.RegistryCredentials)
+		})
+	}
+}
+
+func TestCustomerResourceModifyPlanExpiresAt(t *testing.T) {
+	tests := []struct {
+		name      string
+		expiresAt TimestampValue
+		update    bool
+		wantErr   string
+	}{
+		{
+			name:      "future",
+			expiresAt: NewTimestampValue("2099-01-30"),
+		},
+		{
+			name:      "relative",
+			expiresAt: NewTimestampValue("+365d"),
+		},
+		{
+			name:      "no expiration",
+			expiresAt: NewTimestampNull(),
+		},
+		{
+			name:      "past",
+			expiresAt: NewTimestampValue("2020-01-30T15:04:05Z"),
+			wantErr:   "Invalid Expiration",
+		},
+		{
+			name:      "past on update",
+			expiresAt: NewTimestampValue("2020-01-30T15:04:05Z"),
+			update:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewCustomerResource()
+			s := testResourceSchema(t, r)
+
+			config := testCustomerPlanModel(t)
+			config.ExpiresAt = tt.expiresAt
+			plan := config
+
+			state := testState(t, s, nil)
+			if tt.update {
+				prior := testCustomerPlanModel(t)
+				prior.Id = types.StringValue("app/test-app/customer/customer-1")
+				state = testState(t, s, &prior)
+			}
+
+			resp := fwresource.ModifyPlanResponse{Plan: testPlan(t, s, &plan)}
+			r.(fwresource.ResourceWithModifyPlan).ModifyPlan(context.Background(), fwresource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: s, Raw: testState(t, s, &config).Raw},
+				Plan:   testPlan(t, s, &plan),
+				State:  state,
+			}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+		})
+	}
+}
+
+func TestCustomerResourceSchemaValidators(t *testing.T) {
+	tests := []struct {
+		attribute string
+		value     string
+		wantErr   bool
+	}{
+		{attribute: "type", value: "dev"},
+		{attribute: "type", value: "trial"},
+		{attribute: "type", value: "paid"},
+		{attribute: "type", value: "community"},
+		{attribute: "type", value: "test"},
+		{attribute: "type", value: "prod", wantErr: true},
+		{attribute: "email", value: "customer@example.com"},
+		{attribute: "email", value: "first.last+tag@sub.example.co.uk"},
+		{attribute: "email", value: "customer", wantErr: true},
+		{attribute: "email", value: "customer@example", wantErr: true},
+		{attribute: "email", value: "customer @example.com", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.attribute+"="+tt.value, func(t *testing.T) {
+			s := testResourceSchema(t, NewCustomerResource())
+			attribute, ok := s.Attributes[tt.attribute].(schema.StringAttribute)
+			require.True(t, ok)
+
+			var diags diag.Diagnostics
+			for _, v := range attribute.Validators {
+				resp := validator.StringResponse{}
+				v.ValidateString(context.Background(), validator.StringRequest{
+					Path:        path.Root(tt.attribute),
+					ConfigValue: types.StringValue(tt.value),
+				}, &resp)
+				diags.Append(resp.Diagnostics...)
+			}
+			assert.Equal(t, tt.wantErr, diags.HasError(), "%v", diags)
+		})
+	}
+}
+
+func TestCustomerResourceModifyPlanUniqueName(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  bool
+		plan    func(m *CustomerResourceModel)
+		setup   func(api *fakeVendorAPI)
+		wantErr string
+	}{
+		{
+			name: "unique name",
+		},
+		{
+			name: "duplicate name",
+			setup: func(api *fakeVendorAPI) {
+				_, err := api.CreateCustomer(CreateCustomerOpts{CreateCustomerOpts: kotsclient.CreateCustomerOpts{Name: "acme"}})
+				require.NoError(t, err)
+			},
+			wantErr: "Duplicate Customer Name",
+		},
+		{
+			name: "duplicate name allowed",
+			plan: func(m *CustomerResourceModel) {
+				m.RequireUniqueName = types.BoolValue(false)
+			},
+			setup: func(api *fakeVendorAPI) {
+				_, err := api.CreateCustomer(CreateCustomerOpts{CreateCustomerOpts: kotsclient.CreateCustomerOpts{Name: "acme"}})
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:   "unchanged name",
+			update: true,
+			setup: func(api *fakeVendorAPI) {
+				_, err := api.CreateCustomer(CreateCustomerOpts{CreateCustomerOpts: kotsclient.CreateCustomerOpts{Name: "acme"}})
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:   "renamed to a duplicate",
+			update: true,
+			plan: func(m *CustomerResourceModel) {
+				m.Name = types.StringValue("other")
+			},
+			setup: func(api *fakeVendorAPI) {
+				_, err := api.CreateCustomer(CreateCustomerOpts{CreateCustomerOpts: kotsclient.CreateCustomerOpts{Name: "other"}})
+				require.NoError(t, err)
+			},
+			wantErr: "Duplicate Customer Name",
+		},
+		{
+			name: "list customers error",
+			setup: func(api *fakeVendorAPI) {
+				api.errs["ListCustomers"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			r := NewCustomerResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			state := testState(t, s, nil)
+			if tt.update {
+				prior := testCustomerState(t, api)
+				prior.RequireUniqueName = types.BoolValue(true)
+				state = testState(t, s, &prior)
+			}
+			if tt.setup != nil {
+				tt.setup(api)
+			}
+
+			config := testCustomerPlanModel(t)
+			config.RequireUniqueName = types.BoolValue(true)
+			if tt.plan != nil {
+				tt.plan(&config)
+			}
+			plan := config
+
+			resp := fwresource.ModifyPlanResponse{Plan: testPlan(t, s, &plan)}
+			r.(fwresource.ResourceWithModifyPlan).ModifyPlan(context.Background(), fwresource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: s, Raw: testState(t, s, &config).Raw},
+				Plan:   testPlan(t, s, &plan),
+				State:  state,
+			}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+		})
+	}
+}
+
+func TestCustomerExpiryWarning(t *testing.T) {
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		expires     *util.Time
+		days        int64
+		wantWarning string
+	}{
+		{
+			name:    "no expiration",
+			expires: nil,
+			days:    30,
+		},
+		{
+			name:    "far from expiry",
+			expires: &util.Time{Time: now.AddDate(0, 2, 0)},
+			days:    30,
+		},
+		{
+			name:        "expiring soon",
+			expires:     &util.Time{Time: now.AddDate(0, 0, 10)},
+			days:        30,
+			wantWarning: "Customer License Expiring Soon",
+		},
+		{
+			name:        "expired",
+			expires:     &util.Time{Time: now.AddDate(0, 0, -1)},
+			days:        30,
+			wantWarning: "Customer License Expired",
+		},
+		{
+			name:    "disabled",
+			expires: &util.Time{Time: now.AddDate(0, 0, -1)},
+			days:    0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := customerExpiryWarning(&rtypes.Customer{Name: "acme", Expires: tt.expires}, tt.days, now)
+			require.False(t, diags.HasError())
+			if tt.wantWarning == "" {
+				assert.Empty(t, diags)
+				return
+			}
+			require.Len(t, diags, 1)
+			assert.Equal(t, tt.wantWarning, diags[0].Summary())
+		})
+	}
+}
+
+func TestCustomerResourceModelApplyChannelDetails(t *testing.T) {
+	sequence := int64(3)
+
+	tests := []struct {
+		name          string
+		channels      []string
+		details       []CustomerChannelDetails
+		wantChannelID types.String
+		wantChannels  types.Set
+	}{
+		{
+			name:     "default and pinned channels",
+			channels: []string{"channel-1", "channel-2"},
+			details: []CustomerChannelDetails{
+				{ID: "channel-1", PinnedChannelSequence: &sequence},
+				{ID: "channel-2", IsDefault: true},
+			},
+			wantChannelID: types.StringValue("channel-2"),
+			wantChannels: testCustomerChannels(t,
+				testCustomerChannel("channel-1", false, &sequence),
+				testCustomerChannel("channel-2", true, nil),
+			),
+		},
+		{
+			name:     "missing details",
+			channels: []string{"channel-1"},
+			details:  nil,
+			// no channel is made the default one without the api saying so
+			wantChannelID: types.StringNull(),
+			wantChannels:  testCustomerChannels(t, testCustomerChannel("channel-1", false, nil)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			customer := &rtypes.Customer{ID: "customer-1"}
+			for _, id := range tt.channels {
+				customer.Channels = append(customer.Channels, rtypes.Channel{ID: id})
+			}
+
+			m := getCustomerResourceModelFromCustomer("app-1", customer)
+			require.False(t, m.applyChannelDetails(context.Background(), tt.details).HasError())
+
+			assert.Equal(t, tt.wantChannelID, m.ChannelId)
+			assert.Equal(t, tt.wantChannels, m.Channels)
+		})
+	}
+}
+
+func TestParseCustomerResourceID(t *testing.T) {
+	tests := []struct {
+		id             string
+		wantAppID      string
+		wantCustomerID string
+		wantErr        bool
+	}{
+		{id: "app/app-1/customer/customer-1", wantAppID: "app-1", wantCustomerID: "customer-1"},
+		{id: "customer-1", wantErr: true},
+		{id: "app-1/customer-1", wantErr: true},
+		{id: "app//customer/customer-1", wantErr: true},
+		{id: "app/app-1/customer/", wantErr: true},
+		{id: "apps/app-1/customers/customer-1", wantErr: true},
+		{id: "app/app-1/customer/customer-1/extra", wantErr: true},
+		{id: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			appID, customerID, diags := parseCustomerResourceID(tt.id)
+			if tt.wantErr {
+				require.True(t, diags.HasError())
+				assert.Equal(t, "Invalid Resource ID", diags.Errors()[0].Summary())
+				return
+			}
+			require.False(t, diags.HasError(), "%v", diags)
+			assert.Equal(t, tt.wantAppID, appID)
+			assert.Equal(t, tt.wantCustomerID, customerID)
+			assert.Equal(t, tt.id, formatCustomerResourceID(appID, customerID))
+		})
+	}
+}
+
+func TestCustomerResourceImportState(t *testing.T) {
+	tests := []struct {
+		name      string
+		id        func(customer CustomerResourceModel) string
+		setup     func(api *fakeVendorAPI)
+		wantAppID string
+		wantErr   string
+	}{
+		{
+			name:      "canonical id",
+			id:        func(customer CustomerResourceModel) string { return customer.Id.ValueString() },
+			wantAppID: "2fvVIbMQtNBwMzeTJt2yJrEKEFN",
+		},
+		{
+			name: "canonical id with app slug",
+			id: func(customer CustomerResourceModel) string {
+				_, customerID, _ := parseCustomerResourceID(customer.Id.ValueString())
+				return "app/test-app/customer/" + customerID
+			},
+			wantAppID: "test-app",
+		},
+		{
+			name:      "app slug and customer name",
+			id:        func(customer CustomerResourceModel) string { return "test-app/acme" },
+			wantAppID: "test-app",
+		},
+		{
+			name: "customer id",
+			id: func(customer CustomerResourceModel) string {
+				_, customerID, _ := parseCustomerResourceID(customer.Id.ValueString())
+				return customerID
+			},
+			wantAppID: "2fvVIbMQtNBwMzeTJt2yJrEKEFN",
+		},
+		{
+			name:    "unknown customer name",
+			id:      func(customer CustomerResourceModel) string { return "test-app/globex" },
+			wantErr: "Customer Not Found",
+		},
+		{
+			name:    "unknown customer id",
+			id:      func(customer CustomerResourceModel) string { return "customer-404" },
+			wantErr: "Customer Not Found",
+		},
+		{
+			name:    "customer name without app",
+			id:      func(customer CustomerResourceModel) string { return "acme" },
+			wantErr: "Customer Not Found",
+		},
+		{
+			name: "archived customer id",
+			id: func(customer CustomerResourceModel) string {
+				_, customerID, _ := parseCustomerResourceID(customer.Id.ValueString())
+				return customerID
+			},
+			setup: func(api *fakeVendorAPI) {
+				for id := range api.customers {
+					api.archived[id] = true
+				}
+			},
+			wantErr: "Customer Not Found",
+		},
+		{
+			name:    "unknown app",
+			id:      func(customer CustomerResourceModel) string { return "other-app/acme" },
+			wantErr: "Server Error",
+		},
+		{
+			name:    "malformed canonical id",
+			id:      func(customer CustomerResourceModel) string { return "app/test-app/customers/acme" },
+			wantErr: "Invalid Resource ID",
+		},
+		{
+			name:    "malformed id",
+			id:      func(customer CustomerResourceModel) string { return "test-app//acme" },
+			wantErr: "Unexpected Import Identifier",
+		},
+		{
+			name: "api error",
+			id:   func(customer CustomerResourceModel) string { return "customer-1" },
+			setup: func(api *fakeVendorAPI) {
+				api.errs["GetCustomer"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			customer := testCustomerState(t, api)
+			if tt.setup != nil {
+				tt.setup(api)
+			}
+			r := NewCustomerResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			resp := fwresource.ImportStateResponse{State: testState(t, s, nil)}
+			r.(fwresource.ResourceWithImportState).ImportState(context.Background(), fwresource.ImportStateRequest{ID: tt.id(customer)}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var id, appID string
+			require.False(t, resp.State.GetAttribute(context.Background(), path.Root("id"), &id).HasError())
+			require.False(t, resp.State.GetAttribute(context.Background(), path.Root("app_id"), &appID).HasError())
+			assert.Equal(t, customer.Id.ValueString(), id)
+			assert.Equal(t, tt.wantAppID, appID)
+		})
+	}
+}
+
+func TestCustomerResourceModifyPlanEntitlementValues(t *testing.T) {
+	tests := []struct {
+		name         string
+		values       map[string]string
+		secretValues map[string]string
+		setup        func(api *fakeVendorAPI)
+		wantErr      string
+		wantErrPath  path.Path
+	}{
+		{
+			name:   "valid values",
+			values: map[string]string{"seats": "10", "environment": "staging", "sso_enabled": "true"},
+		},
+		{
+			name:         "secret value",
+			secretValues: map[string]string{"api_key": "s3cr3t"},
+		},
+		{
+			name:         "non secret value set as secret",
+			secretValues: map[string]string{"environment": "staging"},
+		},
+		{
+			name:        "unknown field",
+			values:      map[string]string{"seat": "10"},
+			wantErr:     "Unknown License Field",
+			wantErrPath: path.Root("entitlement_values").AtMapKey("seat"),
+		},
+		{
+			name:        "invalid integer",
+			values:      map[string]string{"seats": "ten"},
+			wantErr:     "Invalid License Field Value",
+			wantErrPath: path.Root("entitlement_values").AtMapKey("seats"),
+		},
+		{
+			name:        "invalid boolean",
+			values:      map[string]string{"sso_enabled": "yes"},
+			wantErr:     "Invalid License Field Value",
+			wantErrPath: path.Root("entitlement_values").AtMapKey("sso_enabled"),
+		},
+		{
+			name:        "secret field set in plain text",
+			values:      map[string]string{"api_key": "s3cr3t"},
+			wantErr:     "Secret License Field",
+			wantErrPath: path.Root("entitlement_values").AtMapKey("api_key"),
+		},
+		{
+			name:         "unknown secret field",
+			secretValues: map[string]string{"token": "s3cr3t"},
+			wantErr:      "Unknown License Field",
+			wantErrPath:  path.Root("secret_entitlement_values").AtMapKey("token"),
+		},
+		{
+			name:   "list license fields error",
+			values: map[string]string{"seats": "10"},
+			setup: func(api *fakeVendorAPI) {
+				api.errs["ListLicenseFields"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+		{
+			name: "no values",
+			setup: func(api *fakeVendorAPI) {
+				// not called when there is nothing to validate
+				api.errs["ListLicenseFields"] = errors.New("boom")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			if tt.setup != nil {
+				tt.setup(api)
+			}
+			r := NewCustomerResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			config := testCustomerPlanModel(t)
+			config.Id = types.StringNull()
+			config.Channels = types.SetNull(types.ObjectType{AttrTypes: customerChannelAttrTypes})
+			config.DefaultEntitlementValues = types.MapNull(types.StringType)
+			config.EntitlementValues = types.MapNull(types.StringType)
+			if tt.values != nil {
+				config.EntitlementValues = types.MapValueMust(types.StringType, testStringValues(tt.values))
+			}
+			if tt.secretValues != nil {
+				config.SecretEntitlementValues = types.MapValueMust(types.StringType, testStringValues(tt.secretValues))
+			}
+
+			plan := config
+			plan.Id = types.StringUnknown()
+			plan.Channels = types.SetUnknown(types.ObjectType{AttrTypes: customerChannelAttrTypes})
+			plan.DefaultEntitlementValues = types.MapUnknown(types.StringType)
+
+			resp := fwresource.ModifyPlanResponse{Plan: testPlan(t, s, &plan)}
+			r.(fwresource.ResourceWithModifyPlan).ModifyPlan(context.Background(), fwresource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: s, Raw: testState(t, s, &config).Raw},
+				Plan:   testPlan(t, s, &plan),
+				State:  testState(t, s, nil),
+			}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				if withPath, ok := resp.Diagnostics.Errors()[0].(diag.DiagnosticWithPath); ok {
+					assert.Equal(t, tt.wantErrPath, withPath.Path())
+				}
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+		})
+	}
+}
+
+func testStringValues(values map[string]string) map[string]attr.Value {
+	elements := make(map[string]attr.Value, len(values))
+	for name, value := range values {
+		elements[name] = types.StringValue(value)
+	}
+	return elements
+}
+
+func TestCustomerResourceModelApplyEntitlementValues(t *testing.T) {
+	fields := newFakeVendorAPI().licenseFields
+
+	tests := []struct {
+		name              string
+		entitlements      []rtypes.Entitlement
+		known             types.Map
+		knownSecret       types.Map
+		wantValues        types.Map
+		wantSecretValues  types.Map
+		wantDefaultValues types.Map
+	}{
+		{
+			name: "set values and defaults",
+			entitlements: []rtypes.Entitlement{
+				{Name: "seats", Value: "10"},
+				{Name: "environment", Value: "production", IsDefault: true},
+				{Name: "api_key", Value: "", IsDefault: true},
+			},
+			known:             types.MapValueMust(types.StringType, testStringValues(map[string]string{"seats": "10"})),
+			knownSecret:       types.MapNull(types.StringType),
+			wantValues:        types.MapValueMust(types.StringType, testStringValues(map[string]string{"seats": "10"})),
+			wantSecretValues:  types.MapNull(types.StringType),
+			wantDefaultValues: types.MapValueMust(types.StringType, testStringValues(map[string]string{"environment": "production"})),
+		},
+		{
+			name: "value equal to the default",
+			entitlements: []rtypes.Entitlement{
+				{Name: "environment", Value: "production", IsDefault: true},
+			},
+			known:             types.MapValueMust(types.StringType, testStringValues(map[string]string{"environment": "production"})),
+			knownSecret:       types.MapNull(types.StringType),
+			wantValues:        types.MapValueMust(types.StringType, testStringValues(map[string]string{"environment": "production"})),
+			wantSecretValues:  types.MapNull(types.StringType),
+			wantDefaultValues: types.MapValueMust(types.StringType, map[string]attr.Value{}),
+		},
+		{
+			name: "secret values",
+			entitlements: []rtypes.Entitlement{
+				{Name: "api_key", Value: "s3cr3t"},
+				{Name: "environment", Value: "staging"},
+			},
+			known:             types.MapNull(types.StringType),
+			knownSecret:       types.MapValueMust(types.StringType, testStringValues(map[string]string{"api_key": "s3cr3t", "environment": "staging"})),
+			wantValues:        types.MapNull(types.StringType),
+			wantSecretValues:  types.MapValueMust(types.StringType, testStringValues(map[string]string{"api_key": "s3cr3t", "environment": "staging"})),
+			wantDefaultValues: types.MapValueMust(types.StringType, map[string]attr.Value{}),
+		},
+		{
+			name: "values set outside terraform",
+			entitlements: []rtypes.Entitlement{
+				{Name: "seats", Value: "20"},
+				{Name: "api_key", Value: "s3cr3t"},
+			},
+			known:             types.MapNull(types.StringType),
+			knownSecret:       types.MapNull(types.StringType),
+			wantValues:        types.MapValueMust(types.StringType, testStringValues(map[string]string{"seats": "20"})),
+			wantSecretValues:  types.MapValueMust(types.StringType, testStringValues(map[string]string{"api_key": "s3cr3t"})),
+			wantDefaultValues: types.MapValueMust(types.StringType, map[string]attr.Value{}),
+		},
+		{
+			name:              "empty map kept",
+			known:             types.MapValueMust(types.StringType, map[string]attr.Value{}),
+			knownSecret:       types.MapNull(types.StringType),
+			wantValues:        types.MapValueMust(types.StringType, map[string]attr.Value{}),
+			wantSecretValues:  types.MapNull(types.StringType),
+			wantDefaultValues: types.MapValueMust(types.StringType, map[string]attr.Value{}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m CustomerResourceModel
+			m.applyEntitlementValues(&rtypes.Customer{Entitlements: tt.entitlements}, fields, tt.known, tt.knownSecret)
+
+			assert.Equal(t, tt.wantValues, m.EntitlementValues)
+			assert.Equal(t, tt.wantSecretValues, m.SecretEntitlementValues)
+			assert.Equal(t, tt.wantDefaultValues, m.DefaultEntitlementValues)
+		})
+	}
+}
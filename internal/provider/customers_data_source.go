@@ -0,0 +1,241 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	rtypes "github.com/replicatedhq/replicated/pkg/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CustomersDataSource{}
+var _ datasource.DataSourceWithConfigure = &CustomersDataSource{}
+
+func NewCustomersDataSource() datasource.DataSource {
+	return &CustomersDataSource{}
+}
+
+// CustomersDataSource defines the data source implementation.
+type CustomersDataSource struct {
+	client        VendorAPI
+	appResolver   *appResolver
+	licenseFields *licenseFieldCache
+}
+
+// CustomersDataSourceModel describes the data source data model.
+type CustomersDataSourceModel struct {
+	AppId           types.String   `tfsdk:"app_id"`
+	ChannelId       types.String   `tfsdk:"channel_id"`
+	Type            types.String   `tfsdk:"type"`
+	ExpiresBefore   TimestampValue `tfsdk:"expires_before"`
+	ExpiresAfter    TimestampValue `tfsdk:"expires_after"`
+	IncludeArchived types.Bool     `tfsdk:"include_archived"`
+	Customers       types.List     `tfsdk:"customers"`
+}
+
+func (d *CustomersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_customers"
+}
+
+func (d *CustomersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the customers of an app, optionally filtered by channel, type, expiration and archived status",
+
+		Attributes: map[string]schema.Attribute{
+			"app_id": schema.StringAttribute{
+				MarkdownDescription: "ID or slug of the app",
+				Required:            true,
+			},
+			"channel_id": schema.StringAttribute{
+				MarkdownDescription: "Only list customers with access to this channel",
+				Optional:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Only list customers of this type",
+				Optional:            true,
+			},
+			"expires_before": schema.StringAttribute{
+				MarkdownDescription: "Only list customers whose license expires before this time (RFC3339 timestamp, date or relative time such as +30d). Customers without expiration are excluded",
+				CustomType:          TimestampType{},
+				Optional:            true,
+			},
+			"expires_after": schema.StringAttribute{
+				MarkdownDescription: "Only list customers whose license expires after this time (RFC3339 timestamp, date or relative time such as +30d). Customers without expiration are excluded",
+				CustomType:          TimestampType{},
+				Optional:            true,
+			},
+			"include_archived": schema.BoolAttribute{
+				MarkdownDescription: "Also list archived customers (defaults to false)",
+				Optional:            true,
+			},
+			"customers": schema.ListNestedAttribute{
+				MarkdownDescription: "Customers matching the filters, sorted by name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: customerDataAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *CustomersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*ReplicatedProviderClients)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ReplicatedProviderClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = clients.vendorAPI
+	d.appResolver = clients.appResolver
+	d.licenseFields = clients.licenseFields
+}
+
+func (d *CustomersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CustomersDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	now := time.Now()
+	var expiresBefore, expiresAfter *time.Time
+	if !data.ExpiresBefore.IsNull() {
+		t, err := data.ExpiresBefore.Resolve(now)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("expires_before"), "Invalid Timestamp", err.Error())
+			return
+		}
+		expiresBefore = &t
+	}
+	if !data.ExpiresAfter.IsNull() {
+		t, err := data.ExpiresAfter.Resolve(now)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("expires_after"), "Invalid Timestamp", err.Error())
+			return
+		}
+		expiresAfter = &t
+	}
+
+	appID, err := d.appResolver.resolveAppID(data.AppId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("app_id"), "Invalid App", fmt.Sprintf("Unable to resolve app, got error: %s", err))
+		return
+	}
+
+	customers, err := d.client.ListCustomersWithDetails(appID, data.IncludeArchived.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to list customers, got error: %s", err))
+		return
+	}
+
+	filter := customerFilter{
+		channelID:     data.ChannelId.ValueString(),
+		customerType:  data.Type.ValueString(),
+		expiresBefore: expiresBefore,
+		expiresAfter:  expiresAfter,
+	}
+	var matches []CustomerWithDetails
+	for _, customer := range customers {
+		if filter.matches(customer.Customer) {
+			matches = append(matches, customer)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Name != matches[j].Name {
+			return matches[i].Name < matches[j].Name
+		}
+		return matches[i].ID < matches[j].ID
+	})
+
+	var fields []LicenseField
+	if len(matches) > 0 {
+		fields, err = d.licenseFields.listLicenseFields(appID)
+		if err != nil {
+			resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to list license fields, got error: %s", err))
+			return
+		}
+	}
+
+	values := make([]CustomerDataModel, 0, len(matches))
+	for i := range matches {
+		value, diags := readCustomerData(ctx, appID, &matches[i].Customer, &matches[i].Details, fields, matches[i].Archived)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		values = append(values, value)
+	}
+
+	list, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: customerDataAttrTypes}, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Customers = list
+
+	tflog.Trace(ctx, "listed customers", map[string]interface{}{"count": len(values)})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// customerFilter selects customers by channel, type and expiration, unset
+// fields match every customer.
+type customerFilter struct {
+	channelID     string
+	customerType  string
+	expiresBefore *time.Time
+	expiresAfter  *time.Time
+}
+
+func (f customerFilter) matches(customer rtypes.Customer) bool {
+	if f.customerType != "" && customer.Type != f.customerType {
+		return false
+	}
+
+	if f.channelID != "" {
+		found := false
+		for _, channel := range customer.Channels {
+			if channel.ID == f.channelID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if f.expiresBefore != nil || f.expiresAfter != nil {
+		if customer.Expires == nil || customer.Expires.IsZero() {
+			return false
+		}
+		if f.expiresBefore != nil && !customer.Expires.Before(*f.expiresBefore) {
+			return false
+		}
+		if f.expiresAfter != nil && !customer.Expires.After(*f.expiresAfter) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,219 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/replicatedhq/replicated/pkg/kotsclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccCustomersDataSource(t *testing.T) {
+	testAccVendorAPI(t)
+	rName := acctest.RandomWithPrefix(testAccResourcePrefix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCustomerResourceConfig(rName) + `
+					data "replicated_customers" "test" {
+						app_id         = replicated_customer.test.app_id
+						channel_id     = replicated_customer.test.channel_id
+						expires_after  = "2034-12-31"
+						expires_before = "2035-12-31"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.replicated_customers.test", "customers.#"),
+				),
+			},
+		},
+	})
+}
+
+// testCustomersDataSourceModel returns a customers data source configuration
+// without filters.
+func testCustomersDataSourceModel() CustomersDataSourceModel {
+	return CustomersDataSourceModel{
+		AppId:           types.StringValue("test-app"),
+		ChannelId:       types.StringNull(),
+		Type:            types.StringNull(),
+		ExpiresBefore:   NewTimestampNull(),
+		ExpiresAfter:    NewTimestampNull(),
+		IncludeArchived: types.BoolNull(),
+		Customers:       types.ListUnknown(types.ObjectType{AttrTypes: customerDataAttrTypes}),
+	}
+}
+
+func TestCustomersDataSourceRead(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       func(m *CustomersDataSourceModel)
+		setup        func(api *fakeVendorAPI)
+		wantNames    []string
+		wantArchived []bool
+		wantErr      string
+	}{
+		{
+			name:      "all customers",
+			wantNames: []string{"acme", "globex", "initech"},
+		},
+		{
+			name: "channel",
+			config: func(m *CustomersDataSourceModel) {
+				m.ChannelId = types.StringValue("channel-2")
+			},
+			wantNames: []string{"globex"},
+		},
+		{
+			name: "type",
+			config: func(m *CustomersDataSourceModel) {
+				m.Type = types.StringValue("paid")
+			},
+			wantNames: []string{"globex", "initech"},
+		},
+		{
+			name: "expiry window",
+			config: func(m *CustomersDataSourceModel) {
+				m.ExpiresAfter = NewTimestampValue("2029-01-01")
+				m.ExpiresBefore = NewTimestampValue("2031-01-01T00:00:00Z")
+			},
+			wantNames: []string{"acme"},
+		},
+		{
+			name: "relative expiry",
+			config: func(m *CustomersDataSourceModel) {
+				m.ExpiresAfter = NewTimestampValue("+0d")
+			},
+			wantNames: []string{"acme", "globex"},
+		},
+		{
+			name: "include archived",
+			config: func(m *CustomersDataSourceModel) {
+				m.IncludeArchived = types.BoolValue(true)
+			},
+			setup: func(api *fakeVendorAPI) {
+				for id, customer := range api.customers {
+					if customer.Name == "initech" {
+						api.archived[id] = true
+					}
+				}
+			},
+			wantNames:    []string{"acme", "globex", "initech"},
+			wantArchived: []bool{false, false, true},
+		},
+		{
+			name: "exclude archived",
+			setup: func(api *fakeVendorAPI) {
+				for id, customer := range api.customers {
+					if customer.Name == "initech" {
+						api.archived[id] = true
+					}
+				}
+			},
+			wantNames: []string{"acme", "globex"},
+		},
+		{
+			name: "no match",
+			config: func(m *CustomersDataSourceModel) {
+				m.Type = types.StringValue("community")
+			},
+			wantNames: []string{},
+		},
+		{
+			name: "unknown app",
+			config: func(m *CustomersDataSourceModel) {
+				m.AppId = types.StringValue("other-app")
+			},
+			wantErr: "Invalid App",
+		},
+		{
+			name: "api error",
+			setup: func(api *fakeVendorAPI) {
+				api.errs["ListCustomersWithDetails"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+		{
+			name: "details come with the list",
+			setup: func(api *fakeVendorAPI) {
+				api.errs["GetCustomerDetails"] = errors.New("boom")
+			},
+			wantNames: []string{"acme", "globex", "initech"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			testCustomerState(t, api)
+			_, err := api.CreateCustomer(CreateCustomerOpts{
+				CreateCustomerOpts: kotsclient.CreateCustomerOpts{
+					Name:        "globex",
+					Email:       "globex@example.com",
+					Channels:    []kotsclient.CustomerChannel{{ID: "channel-1"}, {ID: "channel-2", IsDefault: true}},
+					ExpiresAt:   "2099-01-01T00:00:00Z",
+					LicenseType: "paid",
+				},
+			})
+			require.NoError(t, err)
+			_, err = api.CreateCustomer(CreateCustomerOpts{
+				CreateCustomerOpts: kotsclient.CreateCustomerOpts{
+					Name:        "initech",
+					Email:       "initech@example.com",
+					Channels:    []kotsclient.CustomerChannel{{ID: "channel-1", IsDefault: true}},
+					LicenseType: "paid",
+				},
+			})
+			require.NoError(t, err)
+			if tt.setup != nil {
+				tt.setup(api)
+			}
+
+			d := NewCustomersDataSource()
+			testConfiguredDataSource(t, d, api)
+			s := testDataSourceSchema(t, d)
+
+			config := testCustomersDataSourceModel()
+			if tt.config != nil {
+				tt.config(&config)
+			}
+
+			c, state := testDataSourceConfig(t, s, &config)
+			resp := datasource.ReadResponse{State: state}
+			d.Read(context.Background(), datasource.ReadRequest{Config: c}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var got CustomersDataSourceModel
+			require.False(t, resp.State.Get(context.Background(), &got).HasError())
+			var customers []CustomerDataModel
+			require.False(t, got.Customers.ElementsAs(context.Background(), &customers, false).HasError())
+
+			names := []string{}
+			archived := []bool{}
+			for _, customer := range customers {
+				names = append(names, customer.Name.ValueString())
+				archived = append(archived, customer.Archived.ValueBool())
+				assert.Equal(t, "2fvVIbMQtNBwMzeTJt2yJrEKEFN", customer.AppId.ValueString())
+				assert.Equal(t, formatCustomerResourceID("2fvVIbMQtNBwMzeTJt2yJrEKEFN", customer.CustomerId.ValueString()), customer.Id.ValueString())
+			}
+			assert.Equal(t, tt.wantNames, names)
+			if tt.wantArchived != nil {
+				assert.Equal(t, tt.wantArchived, archived)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// httpClientConfig holds the provider settings that control how requests
+// reach the vendor api.
+type httpClientConfig struct {
+	caCertFile         string
+	caCertPEM          string
+	insecureSkipVerify bool
+	proxyURL           string
+	requestTimeout     time.Duration
+	userAgent          string
+}
+
+// newHTTPClient builds the http client used for all vendor api requests. When
+// no proxy url is configured the standard HTTPS_PROXY, HTTP_PROXY and NO_PROXY
+// environment variables are honoured. Requests are logged with the logger
+// carried by ctx.
+func newHTTPClient(ctx context.Context, cfg httpClientConfig) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if cfg.proxyURL != "" {
+		proxyURL, err := url.Parse(cfg.proxyURL)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse proxy url")
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	} else {
+		transport.Proxy = http.ProxyFromEnvironment
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.insecureSkipVerify,
+	}
+
+	if cfg.caCertFile != "" || cfg.caCertPEM != "" {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil || rootCAs == nil {
+			rootCAs = x509.NewCertPool()
+		}
+
+		if cfg.caCertFile != "" {
+			pem, err := os.ReadFile(cfg.caCertFile)
+			if err != nil {
+				return nil, errors.Wrap(err, "read ca cert file")
+			}
+			if !rootCAs.AppendCertsFromPEM(pem) {
+				return nil, errors.Errorf("no certificates found in %s", cfg.caCertFile)
+			}
+		}
+
+		if cfg.caCertPEM != "" {
+			if !rootCAs.AppendCertsFromPEM([]byte(cfg.caCertPEM)) {
+				return nil, errors.New("no certificates found in ca cert pem")
+			}
+		}
+
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{
+		Transport: &userAgentTransport{
+			userAgent: cfg.userAgent,
+			next:      newLoggingTransport(ctx, transport),
+		},
+		Timeout: cfg.requestTimeout,
+	}, nil
+}
+
+// userAgentTransport replaces the user agent set by the vendor api client so
+// that provider traffic can be told apart from cli traffic.
+type userAgentTransport struct {
+	userAgent string
+	next      http.RoundTripper
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.userAgent == "" {
+		return t.next.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+
+	return t.next.RoundTrip(req)
+}
+
+// userAgent returns the user agent sent with every vendor api request.
+func userAgent(providerVersion string, terraformVersion string, suffix string) string {
+	ua := fmt.Sprintf("terraform-provider-replicated/%s", providerVersion)
+	if terraformVersion != "" {
+		ua = fmt.Sprintf("%s terraform/%s", ua, terraformVersion)
+	}
+	if suffix != "" {
+		ua = fmt.Sprintf("%s %s", ua, suffix)
+	}
+	return ua
+}
@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHTTPClient(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	serverCAPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	tests := []struct {
+		name          string
+		cfg           httpClientConfig
+		wantConfigErr bool
+		wantGetErr    bool
+	}{
+		{
+			name:       "untrusted certificate",
+			cfg:        httpClientConfig{},
+			wantGetErr: true,
+		},
+		{
+			name: "trusted ca pem",
+			cfg:  httpClientConfig{caCertPEM: serverCAPEM},
+		},
+		{
+			name: "insecure skip verify",
+			cfg:  httpClientConfig{insecureSkipVerify: true},
+		},
+		{
+			name:          "invalid ca pem",
+			cfg:           httpClientConfig{caCertPEM: "not a certificate"},
+			wantConfigErr: true,
+		},
+		{
+			name:          "missing ca file",
+			cfg:           httpClientConfig{caCertFile: "/does/not/exist.pem"},
+			wantConfigErr: true,
+		},
+		{
+			name:          "invalid proxy url",
+			cfg:           httpClientConfig{proxyURL: "http://[::1"},
+			wantConfigErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := newHTTPClient(context.Background(), tt.cfg)
+			if tt.wantConfigErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			resp, err := client.Get(server.URL)
+			if tt.wantGetErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	}
+}
+
+func TestNewHTTPClientProxyAndTimeout(t *testing.T) {
+	client, err := newHTTPClient(context.Background(), httpClientConfig{
+		proxyURL:       "http://proxy.example.com:3128",
+		requestTimeout: 30 * time.Second,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 30*time.Second, client.Timeout)
+
+	ua, ok := client.Transport.(*userAgentTransport)
+	require.True(t, ok)
+	logging, ok := ua.next.(*loggingTransport)
+	require.True(t, ok)
+	transport, ok := logging.next.(*http.Transport)
+	require.True(t, ok)
+
+	req, err := http.NewRequest("GET", "https://api.replicated.com/vendor/v3/apps", nil)
+	require.NoError(t, err)
+
+	proxyURL, err := transport.Proxy(req)
+	require.NoError(t, err)
+	assert.Equal(t, "http://proxy.example.com:3128", proxyURL.String())
+}
+
+func TestUserAgentTransport(t *testing.T) {
+	var gotUserAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUserAgent = r.Header.Get("User-Agent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := newHTTPClient(context.Background(), httpClientConfig{
+		userAgent: userAgent("1.2.3", "1.9.0", "pipeline/nightly"),
+	})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("GET", server.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("User-Agent", "Replicated/0.79.0")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, "terraform-provider-replicated/1.2.3 terraform/1.9.0 pipeline/nightly", gotUserAgent)
+	// the caller's request is left untouched
+	assert.Equal(t, "Replicated/0.79.0", req.Header.Get("User-Agent"))
+}
+
+func TestUserAgent(t *testing.T) {
+	assert.Equal(t, "terraform-provider-replicated/dev", userAgent("dev", "", ""))
+	assert.Equal(t, "terraform-provider-replicated/dev terraform/1.9.0", userAgent("dev", "1.9.0", ""))
+	assert.Equal(t, "terraform-provider-replicated/dev terraform/1.9.0 team/platform", userAgent("dev", "1.9.0", "team/platform"))
+}
+
+func TestProviderConfigurePerInstanceHTTPClient(t *testing.T) {
+	userAgents := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents[r.Header.Get("Authorization")] = r.Header.Get("User-Agent")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"apps":[]}`))
+	}))
+	defer server.Close()
+
+	defaultClient := http.DefaultClient
+
+	configure := func(token string, suffix string) VendorAPI {
+		p := New("test")()
+		schemaResp := provider.SchemaResponse{}
+		p.Schema(context.Background(), provider.SchemaRequest{}, &schemaResp)
+
+		config := tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(context.Background()), nil),
+		}
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}
+		diags := state.Set(context.Background(), &ReplicatedProviderModel{
+			Endpoint:           types.StringValue(server.URL),
+			ApiToken:           types.StringValue(token),
+			CACertFile:         types.StringNull(),
+			CACertPEM:          types.StringNull(),
+			InsecureSkipVerify: types.BoolNull(),
+			ProxyURL:           types.StringNull(),
+			RequestTimeout:     types.StringNull(),
+			UserAgentSuffix:    types.StringValue(suffix),
+		})
+		require.False(t, diags.HasError(), "%v", diags)
+		config.Raw = state.Raw
+
+		resp := provider.ConfigureResponse{}
+		p.Configure(context.Background(), provider.ConfigureRequest{Config: config}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+		return resp.ResourceData.(*ReplicatedProviderClients).vendorAPI
+	}
+
+	first := configure("first-token", "first")
+	second := configure("second-token", "second")
+
+	_, err := first.ListApps(true)
+	require.NoError(t, err)
+	_, err = second.ListApps(true)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"first-token":  "terraform-provider-replicated/test first",
+		"second-token": "terraform-provider-replicated/test second",
+	}, userAgents)
+	assert.Same(t, defaultClient, http.DefaultClient)
+}
+
+func TestVendorTransportRouter(t *testing.T) {
+	var authorization string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// only the client of the test server trusts its certificate
+	origin := vendorTransports.register(server.URL, server.Client())
+
+	resp, err := http.DefaultClient.Get(origin + "/v3/apps")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, authorization, "the user name of the origin was sent")
+
+	// requests to other origins are left to the previous transport
+	_, err = http.DefaultClient.Get(server.URL + "/v3/apps")
+	assert.ErrorContains(t, err, "certificate")
+}
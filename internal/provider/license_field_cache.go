@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"sync"
+)
+
+// licenseFieldCache lists the custom license fields of apps. Lookups are
+// cached per app for the lifetime of the provider instance so that planning
+// and applying many customers of an app only lists its fields once. The
+// provider does not manage license fields, so they do not change during a run.
+type licenseFieldCache struct {
+	client VendorAPI
+
+	mu     sync.Mutex
+	fields map[string][]LicenseField
+}
+
+func newLicenseFieldCache(client VendorAPI) *licenseFieldCache {
+	return &licenseFieldCache{
+		client: client,
+		fields: map[string][]LicenseField{},
+	}
+}
+
+// listLicenseFields returns the custom license fields of the app with id
+// appID. Errors are not cached, so a failed lookup is retried on the next call.
+func (c *licenseFieldCache) listLicenseFields(appID string) ([]LicenseField, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if fields, ok := c.fields[appID]; ok {
+		return fields, nil
+	}
+
+	fields, err := c.client.ListLicenseFields(appID)
+	if err != nil {
+		return nil, err
+	}
+	c.fields[appID] = fields
+
+	return fields, nil
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLicenseFieldCacheListLicenseFields(t *testing.T) {
+	listCalls := map[string]int{}
+	fail := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		listCalls[r.URL.Path]++
+		if r.URL.Path == "/v3/app/other-app/license-fields" && fail {
+			fail = false
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"name":"seats","type":"Integer"}]`))
+	}))
+	defer server.Close()
+
+	client := newVendorAPIClient(server.URL, "token", server.Client())
+	cache := newLicenseFieldCache(client)
+
+	for i := 0; i < 3; i++ {
+		fields, err := cache.listLicenseFields("my-app")
+		require.NoError(t, err)
+		assert.Equal(t, []LicenseField{{Name: "seats", Type: "Integer"}}, fields)
+	}
+
+	// failures are not cached
+	_, err := cache.listLicenseFields("other-app")
+	assert.Error(t, err)
+	_, err = cache.listLicenseFields("other-app")
+	require.NoError(t, err)
+	_, err = cache.listLicenseFields("other-app")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]int{
+		"/v3/app/my-app/license-fields":    1,
+		"/v3/app/other-app/license-fields": 2,
+	}, listCalls)
+}
@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/replicatedhq/replicated/pkg/kotsclient"
+	rtypes "github.com/replicatedhq/replicated/pkg/types"
+)
+
+func licenseFieldsByName(fields []LicenseField) map[string]LicenseField {
+	byName := make(map[string]LicenseField, len(fields))
+	for _, field := range fields {
+		byName[field.Name] = field
+	}
+	return byName
+}
+
+// validLicenseFieldValue reports whether value can be stored in field. Only
+// integer and boolean fields constrain their values.
+func validLicenseFieldValue(field LicenseField, value string) bool {
+	switch strings.ToLower(field.Type) {
+	case "integer":
+		_, err := strconv.ParseInt(value, 10, 64)
+		return err == nil
+	case "boolean":
+		return value == "true" || value == "false"
+	default:
+		return true
+	}
+}
+
+// validateLicenseFieldValues checks that every known value of values names a
+// license field of the app and matches the type of that field. Secret fields
+// may only be set when secret is true.
+func validateLicenseFieldValues(p path.Path, values types.Map, fields []LicenseField, secret bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	byName := licenseFieldsByName(fields)
+	elements := values.Elements()
+
+	names := make([]string, 0, len(elements))
+	for name := range elements {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value, ok := elements[name].(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		field, ok := byName[name]
+		if !ok {
+			diags.AddAttributeError(p.AtMapKey(name), "Unknown License Field", fmt.Sprintf("The app has no license field named %q.", name))
+			continue
+		}
+		if field.IsSecret && !secret {
+			diags.AddAttributeError(p.AtMapKey(name), "Secret License Field", fmt.Sprintf("License field %q is secret, set it in secret_entitlement_values instead.", name))
+			continue
+		}
+		if !validLicenseFieldValue(field, value.ValueString()) {
+			diags.AddAttributeError(p.AtMapKey(name), "Invalid License Field Value", fmt.Sprintf("License field %q is of type %s, got: %q.", name, field.Type, value.ValueString()))
+		}
+	}
+
+	return diags
+}
+
+// entitlementValuesFromModel returns the entitlement values of the customer,
+// secret or not, as expected by the vendor api.
+func entitlementValuesFromModel(ctx context.Context, data CustomerResourceModel) ([]kotsclient.EntitlementValue, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	values := map[string]string{}
+	diags.Append(data.EntitlementValues.ElementsAs(ctx, &values, false)...)
+
+	secretValues := map[string]string{}
+	diags.Append(data.SecretEntitlementValues.ElementsAs(ctx, &secretValues, false)...)
+
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	for name, value := range secretValues {
+		values[name] = value
+	}
+
+	var entitlementValues []kotsclient.EntitlementValue
+	for name, value := range values {
+		entitlementValues = append(entitlementValues, kotsclient.EntitlementValue{Name: name, Value: value})
+	}
+	sort.Slice(entitlementValues, func(i, j int) bool {
+		return entitlementValues[i].Name < entitlementValues[j].Name
+	})
+
+	return entitlementValues, diags
+}
+
+// applyEntitlementValues splits the entitlements of customer into the values
+// set through entitlement_values and secret_entitlement_values, as told by
+// known and knownSecret, and the defaults of the app. Values that are not
+// defaults but were set outside of Terraform are kept too, so that they show
+// up as a difference.
+func (m *CustomerResourceModel) applyEntitlementValues(customer *rtypes.Customer, fields []LicenseField, known types.Map, knownSecret types.Map) {
+	byName := licenseFieldsByName(fields)
+
+	values := map[string]string{}
+	secretValues := map[string]string{}
+	defaultValues := map[string]string{}
+
+	for _, entitlement := range customer.Entitlements {
+		_, isKnown := known.Elements()[entitlement.Name]
+		_, isKnownSecret := knownSecret.Elements()[entitlement.Name]
+		isSecret := byName[entitlement.Name].IsSecret
+
+		switch {
+		case isKnownSecret || (!isKnown && !entitlement.IsDefault && isSecret):
+			secretValues[entitlement.Name] = entitlement.Value
+		case isKnown || !entitlement.IsDefault:
+			values[entitlement.Name] = entitlement.Value
+		case !isSecret:
+			defaultValues[entitlement.Name] = entitlement.Value
+		}
+	}
+
+	m.EntitlementValues = licenseFieldValuesMap(values, known.IsNull())
+	m.SecretEntitlementValues = licenseFieldValuesMap(secretValues, knownSecret.IsNull())
+	m.DefaultEntitlementValues = licenseFieldValuesMap(defaultValues, false)
+}
+
+// licenseFieldValuesMap returns values as a map value, which is null when
+// values is empty and nullIfEmpty is set so that unset attributes stay null.
+func licenseFieldValuesMap(values map[string]string, nullIfEmpty bool) types.Map {
+	if len(values) == 0 && nullIfEmpty {
+		return types.MapNull(types.StringType)
+	}
+
+	elements := make(map[string]attr.Value, len(values))
+	for name, value := range values {
+		elements[name] = types.StringValue(value)
+	}
+
+	return types.MapValueMust(types.StringType, elements)
+}
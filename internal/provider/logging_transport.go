@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/pkg/errors"
+)
+
+const (
+	// redactedValue replaces secrets in logged bodies, it matches the mask
+	// used by tflog for masked fields.
+	redactedValue = "***"
+
+	// maxLoggedBodyBytes caps how much of a request or response body is
+	// written to the trace log.
+	maxLoggedBodyBytes = 64 * 1024
+)
+
+// sensitiveBodyKeys are json keys whose values are never written to the log,
+// wherever they appear in a request or response body.
+var sensitiveBodyKeys = map[string]bool{
+	"authbody":        true,
+	"installationid":  true,
+	"kubeconfig":      true,
+	"license":         true,
+	"licensefile":     true,
+	"password":        true,
+	"secretaccesskey": true,
+	"token":           true,
+}
+
+// sensitiveBodyPaths are api paths whose bodies are not json and are secret
+// in their entirety.
+var sensitiveBodyPaths = []string{
+	"/license-download",
+}
+
+// loggingTransport logs every vendor api request through tflog. Method, path,
+// status and latency are logged at debug level, headers and bodies at trace
+// level with credentials masked.
+type loggingTransport struct {
+	// ctx carries the provider logger, the vendor api client does not pass
+	// a context to its requests.
+	ctx  context.Context
+	next http.RoundTripper
+	// trace is set when trace logs are kept, bodies are only read then.
+	trace bool
+}
+
+func newLoggingTransport(ctx context.Context, next http.RoundTripper) *loggingTransport {
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx,
+		"http_request_header_authorization",
+		"http_request_header_proxy-authorization",
+	)
+
+	return &loggingTransport{
+		ctx:   ctx,
+		next:  next,
+		trace: traceLoggingEnabled(),
+	}
+}
+
+// traceLoggingEnabled tells whether the provider logs are kept at trace
+// level. tflog does not expose the level of its loggers, so it is read from
+// the environment variables terraform and the plugin server read it from.
+func traceLoggingEnabled() bool {
+	for _, env := range []string{"TF_LOG_PROVIDER_REPLICATED", "TF_LOG_PROVIDER", "TF_LOG"} {
+		if level := strings.ToUpper(os.Getenv(env)); level != "" {
+			return level == "TRACE" || level == "JSON"
+		}
+	}
+
+	// the acceptance test framework logs at trace level to this file
+	return os.Getenv("TF_ACC_LOG_PATH") != ""
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fields := map[string]interface{}{
+		"http_method": req.Method,
+		"http_path":   req.URL.Path,
+	}
+
+	traceFields := map[string]interface{}{}
+	if t.trace {
+		for name := range req.Header {
+			traceFields["http_request_header_"+strings.ToLower(name)] = req.Header.Get(name)
+		}
+		if req.GetBody != nil {
+			if body, err := req.GetBody(); err == nil {
+				b, _ := io.ReadAll(body)
+				body.Close()
+				traceFields["http_request_body"] = redactBody(req.URL.Path, b)
+			}
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	fields["http_latency_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(t.ctx, "vendor api request failed", fields)
+		return resp, err
+	}
+
+	fields["http_status"] = resp.StatusCode
+	tflog.Debug(t.ctx, "vendor api request", fields)
+
+	if !t.trace {
+		return resp, nil
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, errors.Wrap(err, "read response body")
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	traceFields["http_response_body"] = redactBody(req.URL.Path, respBody)
+
+	for k, v := range fields {
+		traceFields[k] = v
+	}
+	tflog.Trace(t.ctx, "vendor api request details", traceFields)
+
+	return resp, nil
+}
+
+// redactBody returns a printable version of a request or response body with
+// all secrets masked.
+func redactBody(path string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	for _, p := range sensitiveBodyPaths {
+		if strings.HasSuffix(path, p) {
+			return redactedValue
+		}
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		// bodies that are not json cannot be redacted field by field, and
+		// may be anything, so they are not logged at all
+		return redactedValue
+	}
+
+	redacted, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return redactedValue
+	}
+
+	if len(redacted) > maxLoggedBodyBytes {
+		return string(redacted[:maxLoggedBodyBytes]) + "...(truncated)"
+	}
+	return string(redacted)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, child := range val {
+			if sensitiveBodyKeys[strings.ToLower(k)] {
+				val[k] = redactedValue
+				continue
+			}
+			val[k] = redactValue(child)
+		}
+		return val
+	case []interface{}:
+		for i, child := range val {
+			val[i] = redactValue(child)
+		}
+		return val
+	default:
+		return val
+	}
+}
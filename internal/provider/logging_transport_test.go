@@ -0,0 +1,213 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggingTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kubeconfig":"c2VjcmV0","status":"running"}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("TF_LOG_PROVIDER_REPLICATED", "TRACE")
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	client := &http.Client{Transport: newLoggingTransport(ctx, http.DefaultTransport)}
+
+	req, err := http.NewRequest("POST", server.URL+"/v3/cluster", strings.NewReader(`{"name":"test","password":"hunter2"}`))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "my-api-token")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	// the response body is still readable by the caller
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"kubeconfig":"c2VjcmV0","status":"running"}`, string(body))
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "vendor api request", entries[0]["@message"])
+	assert.Equal(t, "POST", entries[0]["http_method"])
+	assert.Equal(t, "/v3/cluster", entries[0]["http_path"])
+	assert.EqualValues(t, http.StatusOK, entries[0]["http_status"])
+	assert.Contains(t, entries[0], "http_latency_ms")
+
+	assert.Equal(t, "vendor api request details", entries[1]["@message"])
+	assert.Equal(t, "***", entries[1]["http_request_header_authorization"])
+	assert.Equal(t, `{"name":"test","password":"***"}`, entries[1]["http_request_body"])
+	assert.Equal(t, `{"kubeconfig":"***","status":"running"}`, entries[1]["http_response_body"])
+
+	assert.NotContains(t, output.String(), "my-api-token")
+	assert.NotContains(t, output.String(), "hunter2")
+	assert.NotContains(t, output.String(), "c2VjcmV0")
+}
+
+// roundTripFunc is an http.RoundTripper calling the function.
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// errReader fails every read.
+type errReader struct{}
+
+func (errReader) Read(p []byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestLoggingTransportWithoutTrace(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER_REPLICATED", "DEBUG")
+
+	respBody := io.NopCloser(strings.NewReader(`{"status":"running"}`))
+	next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: respBody, Request: req}, nil
+	})
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	transport := newLoggingTransport(ctx, next)
+
+	req, err := http.NewRequest("POST", "https://api.example.com/v3/cluster", strings.NewReader(`{"name":"test"}`))
+	require.NoError(t, err)
+	req.GetBody = func() (io.ReadCloser, error) {
+		t.Error("the request body was read without trace logging")
+		return nil, errors.New("unexpected")
+	}
+
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	// the response body is handed over untouched
+	assert.Equal(t, respBody, resp.Body)
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "vendor api request", entries[0]["@message"])
+}
+
+func TestLoggingTransportResponseReadError(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER_REPLICATED", "TRACE")
+
+	next := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(errReader{}), Request: req}, nil
+	})
+	transport := newLoggingTransport(tflogtest.RootLogger(context.Background(), io.Discard), next)
+
+	req, err := http.NewRequest("GET", "https://api.example.com/v3/apps", nil)
+	require.NoError(t, err)
+
+	resp, err := transport.RoundTrip(req)
+	assert.Nil(t, resp)
+	assert.ErrorContains(t, err, "connection reset")
+}
+
+func TestTraceLoggingEnabled(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want bool
+	}{
+		{
+			name: "not set",
+			want: false,
+		},
+		{
+			name: "tf log trace",
+			env:  map[string]string{"TF_LOG": "trace"},
+			want: true,
+		},
+		{
+			name: "tf log json",
+			env:  map[string]string{"TF_LOG": "JSON"},
+			want: true,
+		},
+		{
+			name: "tf log debug",
+			env:  map[string]string{"TF_LOG": "DEBUG"},
+			want: false,
+		},
+		{
+			name: "provider level wins",
+			env:  map[string]string{"TF_LOG": "TRACE", "TF_LOG_PROVIDER": "INFO"},
+			want: false,
+		},
+		{
+			name: "replicated provider level wins",
+			env:  map[string]string{"TF_LOG_PROVIDER": "INFO", "TF_LOG_PROVIDER_REPLICATED": "TRACE"},
+			want: true,
+		},
+		{
+			name: "acceptance test log",
+			env:  map[string]string{"TF_ACC_LOG_PATH": "/tmp/acc.log"},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, env := range []string{"TF_LOG", "TF_LOG_PROVIDER", "TF_LOG_PROVIDER_REPLICATED", "TF_ACC_LOG_PATH"} {
+				t.Setenv(env, tt.env[env])
+			}
+			assert.Equal(t, tt.want, traceLoggingEnabled())
+		})
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		body string
+		want string
+	}{
+		{
+			name: "empty",
+			path: "/v3/apps",
+			body: "",
+			want: "",
+		},
+		{
+			name: "nested keys",
+			path: "/v3/customer",
+			body: `{"customer":{"name":"acme","installationId":"abc"},"items":[{"token":"x"}]}`,
+			want: `{"customer":{"installationId":"***","name":"acme"},"items":[{"token":"***"}]}`,
+		},
+		{
+			name: "license download",
+			path: "/v3/app/app-id/customer/customer-id/license-download",
+			body: "apiVersion: kots.io/v1beta1\nkind: License\n",
+			want: "***",
+		},
+		{
+			name: "not json",
+			path: "/v3/apps",
+			body: "plain text",
+			want: "***",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, redactBody(tt.path, []byte(tt.body)))
+		})
+	}
+}
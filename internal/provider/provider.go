@@ -2,19 +2,22 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/replicatedhq/replicated/pkg/kotsclient"
-	"github.com/replicatedhq/replicated/pkg/platformclient"
 )
 
 // Ensure ReplicatedProvider satisfies various provider interfaces.
 var _ provider.Provider = &ReplicatedProvider{}
+var _ provider.ProviderWithEphemeralResources = &ReplicatedProvider{}
 
 // ReplicatedProvider defines the provider implementation.
 type ReplicatedProvider struct {
@@ -26,12 +29,20 @@ type ReplicatedProvider struct {
 
 // ReplicatedProviderModel describes the provider data model.
 type ReplicatedProviderModel struct {
-	Endpoint types.String `tfsdk:"endpoint"`
-	ApiToken types.String `tfsdk:"api_token"`
+	Endpoint           types.String `tfsdk:"endpoint"`
+	ApiToken           types.String `tfsdk:"api_token"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+	RequestTimeout     types.String `tfsdk:"request_timeout"`
+	UserAgentSuffix    types.String `tfsdk:"user_agent_suffix"`
 }
 
 type ReplicatedProviderClients struct {
-	kotsVendorV3Client kotsclient.VendorV3Client
+	vendorAPI     VendorAPI
+	appResolver   *appResolver
+	licenseFields *licenseFieldCache
 }
 
 func (p *ReplicatedProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -51,6 +62,30 @@ func (p *ReplicatedProvider) Schema(ctx context.Context, req provider.SchemaRequ
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM encoded CA bundle trusted in addition to the system roots",
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA bundle trusted in addition to the system roots",
+				Optional:            true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip TLS certificate verification of the Vendor API (only intended for local stand-ins of the API)",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "Proxy used for all Vendor API requests (defaults to the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables)",
+				Optional:            true,
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "Timeout of a single Vendor API request (duration, e.g. 30s, no timeout by default)",
+				Optional:            true,
+			},
+			"user_agent_suffix": schema.StringAttribute{
+				MarkdownDescription: "Appended to the User-Agent of every Vendor API request, e.g. to identify the pipeline running Terraform",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -88,30 +123,74 @@ func (p *ReplicatedProvider) Configure(ctx context.Context, req provider.Configu
 		// Not returning early allows the logic to collect all errors.
 	}
 
+	var requestTimeout time.Duration
+	if data.RequestTimeout.ValueString() != "" {
+		t, err := time.ParseDuration(data.RequestTimeout.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("request_timeout"), "Invalid request timeout", fmt.Sprintf("Unable to parse request timeout, got error: %s", err))
+		}
+		requestTimeout = t
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	httpClient := platformclient.NewHTTPClient(apiOrigin, apiToken)
-	kotsAPI := &kotsclient.VendorV3Client{HTTPClient: *httpClient}
+	client, err := newHTTPClient(ctx, httpClientConfig{
+		caCertFile:         data.CACertFile.ValueString(),
+		caCertPEM:          data.CACertPEM.ValueString(),
+		insecureSkipVerify: data.InsecureSkipVerify.ValueBool(),
+		proxyURL:           data.ProxyURL.ValueString(),
+		requestTimeout:     requestTimeout,
+		userAgent:          userAgent(p.version, req.TerraformVersion, data.UserAgentSuffix.ValueString()),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid HTTP Configuration", fmt.Sprintf("Unable to configure the HTTP client, got error: %s", err))
+		return
+	}
+
+	kotsAPI := newVendorAPIClient(apiOrigin, apiToken, client)
 
 	clients := ReplicatedProviderClients{
-		kotsVendorV3Client: *kotsAPI,
+		vendorAPI:     kotsAPI,
+		appResolver:   newAppResolver(kotsAPI),
+		licenseFields: newLicenseFieldCache(kotsAPI),
 	}
 
 	resp.DataSourceData = &clients
 	resp.ResourceData = &clients
+	resp.EphemeralResourceData = &clients
 }
 
 func (p *ReplicatedProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		NewAppResource,
+		NewChannelResource,
+		NewReleaseResource,
+		NewChannelReleaseResource,
 		NewClusterResource,
 		NewCustomerResource,
 	}
 }
 
 func (p *ReplicatedProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewAirgapBundleDataSource,
+		NewAppDataSource,
+		NewAppsDataSource,
+		NewChannelDataSource,
+		NewChannelReleasesDataSource,
+		NewCustomerDataSource,
+		NewCustomerLicenseDataSource,
+		NewCustomersDataSource,
+		NewReleaseLintDataSource,
+	}
+}
+
+func (p *ReplicatedProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewCustomerLicenseEphemeralResource,
+	}
 }
 
 func New(version string) func() provider.Provider {
@@ -1,10 +1,19 @@
 package provider
 
 import (
+	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	ephschema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -20,3 +29,181 @@ func testAccPreCheck(t *testing.T) {
 	// about the appropriate environment variables being set are common to see in a pre-check
 	// function.
 }
+
+// testConfiguredResource configures r with clients backed by api, the same
+// way the provider does.
+func testConfiguredResource(t *testing.T, r resource.Resource, api VendorAPI) {
+	t.Helper()
+
+	rc, ok := r.(resource.ResourceWithConfigure)
+	if !ok {
+		t.Fatalf("%T does not implement resource.ResourceWithConfigure", r)
+	}
+
+	resp := resource.ConfigureResponse{}
+	rc.Configure(context.Background(), resource.ConfigureRequest{
+		ProviderData: &ReplicatedProviderClients{
+			vendorAPI:     api,
+			appResolver:   newAppResolver(api),
+			licenseFields: newLicenseFieldCache(api),
+		},
+	}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("configure resource: %v", resp.Diagnostics)
+	}
+}
+
+// testResourceSchema returns the schema of r.
+func testResourceSchema(t *testing.T, r resource.Resource) schema.Schema {
+	t.Helper()
+
+	resp := resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("resource schema: %v", resp.Diagnostics)
+	}
+
+	return resp.Schema
+}
+
+// testState returns a state of schema s holding model, or a null state when
+// model is nil.
+func testState(t *testing.T, s schema.Schema, model interface{}) tfsdk.State {
+	t.Helper()
+
+	state := tfsdk.State{
+		Schema: s,
+		Raw:    tftypes.NewValue(s.Type().TerraformType(context.Background()), nil),
+	}
+	if model == nil {
+		return state
+	}
+
+	if diags := state.Set(context.Background(), model); diags.HasError() {
+		t.Fatalf("set state: %v", diags)
+	}
+
+	return state
+}
+
+// testPlan returns a plan of schema s holding model.
+func testPlan(t *testing.T, s schema.Schema, model interface{}) tfsdk.Plan {
+	t.Helper()
+
+	state := testState(t, s, model)
+
+	return tfsdk.Plan{
+		Schema: s,
+		Raw:    state.Raw,
+	}
+}
+
+// testConfiguredDataSource configures d with clients backed by api, the same
+// way the provider does.
+func testConfiguredDataSource(t *testing.T, d datasource.DataSource, api VendorAPI) {
+	t.Helper()
+
+	dc, ok := d.(datasource.DataSourceWithConfigure)
+	if !ok {
+		t.Fatalf("%T does not implement datasource.DataSourceWithConfigure", d)
+	}
+
+	resp := datasource.ConfigureResponse{}
+	dc.Configure(context.Background(), datasource.ConfigureRequest{
+		ProviderData: &ReplicatedProviderClients{
+			vendorAPI:     api,
+			appResolver:   newAppResolver(api),
+			licenseFields: newLicenseFieldCache(api),
+		},
+	}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("configure data source: %v", resp.Diagnostics)
+	}
+}
+
+// testDataSourceSchema returns the schema of d.
+func testDataSourceSchema(t *testing.T, d datasource.DataSource) dsschema.Schema {
+	t.Helper()
+
+	resp := datasource.SchemaResponse{}
+	d.Schema(context.Background(), datasource.SchemaRequest{}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("data source schema: %v", resp.Diagnostics)
+	}
+
+	return resp.Schema
+}
+
+// testDataSourceConfig returns a config of schema s holding model, and a null
+// state to read it into.
+func testDataSourceConfig(t *testing.T, s dsschema.Schema, model interface{}) (tfsdk.Config, tfsdk.State) {
+	t.Helper()
+
+	state := tfsdk.State{
+		Schema: s,
+		Raw:    tftypes.NewValue(s.Type().TerraformType(context.Background()), nil),
+	}
+	if diags := state.Set(context.Background(), model); diags.HasError() {
+		t.Fatalf("set config: %v", diags)
+	}
+
+	return tfsdk.Config{Schema: s, Raw: state.Raw}, tfsdk.State{
+		Schema: s,
+		Raw:    tftypes.NewValue(s.Type().TerraformType(context.Background()), nil),
+	}
+}
+
+// testConfiguredEphemeralResource configures e with clients backed by api,
+// the same way the provider does.
+func testConfiguredEphemeralResource(t *testing.T, e ephemeral.EphemeralResource, api VendorAPI) {
+	t.Helper()
+
+	ec, ok := e.(ephemeral.EphemeralResourceWithConfigure)
+	if !ok {
+		t.Fatalf("%T does not implement ephemeral.EphemeralResourceWithConfigure", e)
+	}
+
+	resp := ephemeral.ConfigureResponse{}
+	ec.Configure(context.Background(), ephemeral.ConfigureRequest{
+		ProviderData: &ReplicatedProviderClients{
+			vendorAPI:     api,
+			appResolver:   newAppResolver(api),
+			licenseFields: newLicenseFieldCache(api),
+		},
+	}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("configure ephemeral resource: %v", resp.Diagnostics)
+	}
+}
+
+// testEphemeralResourceSchema returns the schema of e.
+func testEphemeralResourceSchema(t *testing.T, e ephemeral.EphemeralResource) ephschema.Schema {
+	t.Helper()
+
+	resp := ephemeral.SchemaResponse{}
+	e.Schema(context.Background(), ephemeral.SchemaRequest{}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("ephemeral resource schema: %v", resp.Diagnostics)
+	}
+
+	return resp.Schema
+}
+
+// testEphemeralResourceConfig returns a config of schema s holding model, and
+// a null result to open it into.
+func testEphemeralResourceConfig(t *testing.T, s ephschema.Schema, model interface{}) (tfsdk.Config, tfsdk.EphemeralResultData) {
+	t.Helper()
+
+	result := tfsdk.EphemeralResultData{
+		Schema: s,
+		Raw:    tftypes.NewValue(s.Type().TerraformType(context.Background()), nil),
+	}
+	if diags := result.Set(context.Background(), model); diags.HasError() {
+		t.Fatalf("set config: %v", diags)
+	}
+
+	return tfsdk.Config{Schema: s, Raw: result.Raw}, tfsdk.EphemeralResultData{
+		Schema: s,
+		Raw:    tftypes.NewValue(s.Type().TerraformType(context.Background()), nil),
+	}
+}
@@ -0,0 +1,211 @@
+package provider
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/pkg/errors"
+	kotstypes "github.com/replicatedhq/replicated/pkg/kots/release/types"
+)
+
+// releaseFile is a file of a release, with its path relative to the root of
+// the release.
+type releaseFile struct {
+	Path    string
+	Content []byte
+}
+
+// isReleaseFileExt reports whether files with the extension ext are part of a
+// release, the same extensions replicated release create --yaml-dir accepts.
+func isReleaseFileExt(ext string) bool {
+	switch ext {
+	case ".tgz", ".gz", ".yaml", ".yml", ".css", ".woff", ".woff2", ".ttf", ".otf", ".eot", ".svg":
+		return true
+	default:
+		return false
+	}
+}
+
+// readReleaseDir returns the release files in dir and its subdirectories.
+// Hidden files and files with an unsupported extension are skipped, like
+// replicated release create --yaml-dir does.
+func readReleaseDir(dir string) ([]releaseFile, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, errors.Errorf("%s is not a directory", dir)
+	}
+
+	var files []releaseFile
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".") || !isReleaseFileExt(filepath.Ext(info.Name())) {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, releaseFile{Path: filepath.ToSlash(rel), Content: content})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, errors.Errorf("%s does not contain any release files", dir)
+	}
+
+	return files, nil
+}
+
+// readReleaseFiles returns the release files at paths, placed at the root of
+// the release under their base name.
+func readReleaseFiles(paths []string) ([]releaseFile, error) {
+	if len(paths) == 0 {
+		return nil, errors.New("no release files")
+	}
+
+	files := make([]releaseFile, 0, len(paths))
+	seen := map[string]string{}
+	for _, path := range paths {
+		name := filepath.Base(path)
+		if !isReleaseFileExt(filepath.Ext(name)) {
+			return nil, errors.Errorf("%s is not a yaml file or a helm chart archive", path)
+		}
+		if other, ok := seen[name]; ok {
+			return nil, errors.Errorf("%s and %s have the same file name", other, path)
+		}
+		seen[name] = path
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, releaseFile{Path: name, Content: content})
+	}
+
+	return files, nil
+}
+
+// readConfiguredReleaseFiles reads the release files configured through the
+// yaml_dir or the files attribute, and returns the path of the attribute the
+// files were read from.
+func readConfiguredReleaseFiles(ctx context.Context, yamlDir types.String, paths types.List) ([]releaseFile, path.Path, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var files []releaseFile
+	var err error
+	var attr path.Path
+	if !yamlDir.IsNull() {
+		attr = path.Root("yaml_dir")
+		files, err = readReleaseDir(yamlDir.ValueString())
+	} else {
+		attr = path.Root("files")
+		var filePaths []string
+		diags.Append(paths.ElementsAs(ctx, &filePaths, false)...)
+		if diags.HasError() {
+			return nil, attr, diags
+		}
+		files, err = readReleaseFiles(filePaths)
+	}
+	if err != nil {
+		diags.AddAttributeError(attr, "Invalid Release Files", fmt.Sprintf("Unable to read the release files, got error: %s", err))
+		return nil, attr, diags
+	}
+
+	return files, attr, diags
+}
+
+// releaseSpec returns the files encoded as the multi document spec the vendor
+// api expects when creating a release. Binary files are base64 encoded.
+func releaseSpec(files []releaseFile) (string, error) {
+	specs := make([]kotstypes.KotsSingleSpec, 0, len(files))
+	for _, file := range files {
+		content := string(file.Content)
+		switch filepath.Ext(file.Path) {
+		case ".tgz", ".gz", ".woff", ".woff2", ".ttf", ".otf", ".eot", ".svg":
+			content = base64.StdEncoding.EncodeToString(file.Content)
+		}
+
+		specs = append(specs, kotstypes.KotsSingleSpec{
+			Name:     filepath.Base(file.Path),
+			Path:     file.Path,
+			Content:  content,
+			Children: []kotstypes.KotsSingleSpec{},
+		})
+	}
+
+	spec, err := json.Marshal(specs)
+	if err != nil {
+		return "", errors.Wrap(err, "marshal spec")
+	}
+	return string(spec), nil
+}
+
+// releaseTarball returns the files as a tar archive with a single top level
+// directory, the format replicated release lint --yaml-dir sends to the
+// linter.
+func releaseTarball(files []releaseFile) ([]byte, error) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+
+	for _, file := range files {
+		header := &tar.Header{
+			Name:     "release/" + file.Path,
+			Mode:     0o644,
+			Size:     int64(len(file.Content)),
+			Typeflag: tar.TypeReg,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return nil, errors.Wrapf(err, "write header of %s", file.Path)
+		}
+		if _, err := tw.Write(file.Content); err != nil {
+			return nil, errors.Wrapf(err, "write %s", file.Path)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, errors.Wrap(err, "close archive")
+	}
+	return buf.Bytes(), nil
+}
+
+// isHelmChartsOnly reports whether every file is a Helm chart archive, such
+// releases are linted by the builders linter.
+func isHelmChartsOnly(files []releaseFile) bool {
+	for _, file := range files {
+		if filepath.Ext(file.Path) != ".tgz" {
+			return false
+		}
+	}
+	return len(files) > 0
+}
+
+// releaseContentHash returns the sha256 of a release spec, which changes
+// whenever a file is added, removed, renamed or modified.
+func releaseContentHash(spec string) string {
+	sum := sha256.Sum256([]byte(spec))
+	return hex.EncodeToString(sum[:])
+}
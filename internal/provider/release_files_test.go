@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	kotstypes "github.com/replicatedhq/replicated/pkg/kots/release/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testWriteFiles writes files, keyed by their path relative to dir, and
+// returns dir.
+func testWriteFiles(t *testing.T, dir string, files map[string]string) string {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	return dir
+}
+
+func TestReadReleaseDir(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     map[string]string
+		wantPaths []string
+		wantErr   bool
+	}{
+		{
+			name: "release",
+			files: map[string]string{
+				"kots-app.yaml":          "kind: Application",
+				"manifests/config.yml":   "kind: Config",
+				"charts/app-1.0.0.tgz":   "chart",
+				"embedded-cluster.yaml":  "kind: Config",
+				".hidden.yaml":           "kind: Hidden",
+				"README.md":              "# readme",
+				"manifests/notes.txt":    "notes",
+				"manifests/.git/HEAD":    "ref",
+				"manifests/.gitkeep.yml": "",
+			},
+			wantPaths: []string{"charts/app-1.0.0.tgz", "embedded-cluster.yaml", "kots-app.yaml", "manifests/config.yml"},
+		},
+		{
+			name:    "no release files",
+			files:   map[string]string{"README.md": "# readme"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := testWriteFiles(t, t.TempDir(), tt.files)
+
+			files, err := readReleaseDir(dir)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			paths := []string{}
+			for _, file := range files {
+				paths = append(paths, file.Path)
+			}
+			assert.Equal(t, tt.wantPaths, paths)
+		})
+	}
+
+	_, err := readReleaseDir(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
+
+func TestReadReleaseFiles(t *testing.T) {
+	dir := testWriteFiles(t, t.TempDir(), map[string]string{
+		"kots/kots-app.yaml":     "kind: Application",
+		"other/kots-app.yaml":    "kind: Application",
+		"charts/app-1.0.0.tgz":   "chart",
+		"embedded-cluster.json":  "{}",
+		"embedded-cluster.yaml":  "kind: Config",
+		"manifests/service.yaml": "kind: Service",
+	})
+
+	tests := []struct {
+		name      string
+		paths     []string
+		wantPaths []string
+		wantErr   bool
+	}{
+		{
+			name:      "files",
+			paths:     []string{"kots/kots-app.yaml", "charts/app-1.0.0.tgz", "embedded-cluster.yaml"},
+			wantPaths: []string{"kots-app.yaml", "app-1.0.0.tgz", "embedded-cluster.yaml"},
+		},
+		{
+			name:    "same file name",
+			paths:   []string{"kots/kots-app.yaml", "other/kots-app.yaml"},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported extension",
+			paths:   []string{"embedded-cluster.json"},
+			wantErr: true,
+		},
+		{
+			name:    "missing file",
+			paths:   []string{"missing.yaml"},
+			wantErr: true,
+		},
+		{
+			name:    "no files",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths := []string{}
+			for _, path := range tt.paths {
+				paths = append(paths, filepath.Join(dir, path))
+			}
+
+			files, err := readReleaseFiles(paths)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			got := []string{}
+			for _, file := range files {
+				got = append(got, file.Path)
+			}
+			assert.Equal(t, tt.wantPaths, got)
+		})
+	}
+}
+
+func TestReleaseSpec(t *testing.T) {
+	files := []releaseFile{
+		{Path: "manifests/config.yaml", Content: []byte("kind: Config")},
+		{Path: "app-1.0.0.tgz", Content: []byte{0x1f, 0x8b}},
+	}
+
+	spec, err := releaseSpec(files)
+	require.NoError(t, err)
+
+	var specs []kotstypes.KotsSingleSpec
+	require.NoError(t, json.Unmarshal([]byte(spec), &specs))
+	assert.Equal(t, []kotstypes.KotsSingleSpec{
+		{Name: "config.yaml", Path: "manifests/config.yaml", Content: "kind: Config", Children: []kotstypes.KotsSingleSpec{}},
+		{Name: "app-1.0.0.tgz", Path: "app-1.0.0.tgz", Content: base64.StdEncoding.EncodeToString([]byte{0x1f, 0x8b}), Children: []kotstypes.KotsSingleSpec{}},
+	}, specs)
+
+	renamed, err := releaseSpec([]releaseFile{
+		{Path: "config.yaml", Content: []byte("kind: Config")},
+		{Path: "app-1.0.0.tgz", Content: []byte{0x1f, 0x8b}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, releaseContentHash(spec), releaseContentHash(spec))
+	assert.NotEqual(t, releaseContentHash(spec), releaseContentHash(renamed))
+}
+
+func TestReleaseTarball(t *testing.T) {
+	files := []releaseFile{
+		{Path: "config.yaml", Content: []byte("kind: Config")},
+		{Path: "charts/app-1.0.0.tgz", Content: []byte{0x1f, 0x8b}},
+	}
+	tarball, err := releaseTarball(files)
+	require.NoError(t, err)
+
+	got := testReadTarball(t, tarball)
+	assert.Equal(t, map[string]string{
+		"release/config.yaml":          "kind: Config",
+		"release/charts/app-1.0.0.tgz": "\x1f\x8b",
+	}, got)
+}
+
+func TestIsHelmChartsOnly(t *testing.T) {
+	assert.True(t, isHelmChartsOnly([]releaseFile{{Path: "app-1.0.0.tgz"}, {Path: "charts/db-2.0.0.tgz"}}))
+	assert.False(t, isHelmChartsOnly([]releaseFile{{Path: "app-1.0.0.tgz"}, {Path: "config.yaml"}}))
+	assert.False(t, isHelmChartsOnly(nil))
+}
+
+// testReadTarball returns the content of the files in tarball by name.
+func testReadTarball(t *testing.T, tarball []byte) map[string]string {
+	t.Helper()
+
+	files := map[string]string{}
+	tr := tar.NewReader(bytes.NewReader(tarball))
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[header.Name] = string(content)
+	}
+}
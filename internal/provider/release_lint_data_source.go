@@ -0,0 +1,278 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	rtypes "github.com/replicatedhq/replicated/pkg/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ReleaseLintDataSource{}
+var _ datasource.DataSourceWithConfigure = &ReleaseLintDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &ReleaseLintDataSource{}
+
+func NewReleaseLintDataSource() datasource.DataSource {
+	return &ReleaseLintDataSource{}
+}
+
+// ReleaseLintDataSource defines the data source implementation.
+type ReleaseLintDataSource struct {
+	client VendorAPI
+}
+
+// ReleaseLintDataSourceModel describes the data source data model.
+type ReleaseLintDataSourceModel struct {
+	YamlDir          types.String `tfsdk:"yaml_dir"`
+	Files            types.List   `tfsdk:"files"`
+	WarningsAsErrors types.Bool   `tfsdk:"warnings_as_errors"`
+	ContentHash      types.String `tfsdk:"content_hash"`
+	Messages         types.List   `tfsdk:"messages"`
+	ErrorCount       types.Int64  `tfsdk:"error_count"`
+	WarningCount     types.Int64  `tfsdk:"warning_count"`
+}
+
+// ReleaseLintMessageModel describes a message reported by the linter.
+type ReleaseLintMessageModel struct {
+	Rule    types.String `tfsdk:"rule"`
+	Type    types.String `tfsdk:"type"`
+	Path    types.String `tfsdk:"path"`
+	Line    types.Int64  `tfsdk:"line"`
+	Message types.String `tfsdk:"message"`
+}
+
+var releaseLintMessageAttrTypes = map[string]attr.Type{
+	"rule":    types.StringType,
+	"type":    types.StringType,
+	"path":    types.StringType,
+	"line":    types.Int64Type,
+	"message": types.StringType,
+}
+
+// Types of the messages reported by the linter.
+const (
+	lintMessageError = "error"
+	lintMessageWarn  = "warn"
+)
+
+func (d *ReleaseLintDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_release_lint"
+}
+
+func (d *ReleaseLintDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lints local release files the way `replicated release lint --yaml-dir` does, before they are used by a `replicated_release`. " +
+			"Lint errors are reported as errors and fail the plan, lint warnings are reported as warnings unless `warnings_as_errors` is set. " +
+			"Every message, including informational ones, is also available in `messages`",
+
+		Attributes: map[string]schema.Attribute{
+			"yaml_dir": schema.StringAttribute{
+				MarkdownDescription: "Directory containing the release, read like the `yaml_dir` of a `replicated_release`. " +
+					"Exactly one of `yaml_dir` and `files` must be set",
+				Optional: true,
+			},
+			"files": schema.ListAttribute{
+				MarkdownDescription: "Files of the release, read like the `files` of a `replicated_release`",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"warnings_as_errors": schema.BoolAttribute{
+				MarkdownDescription: "Report lint warnings as errors, so that they fail the plan too (default false)",
+				Optional:            true,
+			},
+			"content_hash": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 of the packaged release, the `content_hash` a `replicated_release` of the same files has",
+				Computed:            true,
+			},
+			"messages": schema.ListNestedAttribute{
+				MarkdownDescription: "Messages reported by the linter",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"rule": schema.StringAttribute{
+							MarkdownDescription: "Lint rule that reported the message",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Severity of the message, one of `error`, `warn` or `info`",
+							Computed:            true,
+						},
+						"path": schema.StringAttribute{
+							MarkdownDescription: "Path of the file the message is about, relative to the root of the release",
+							Computed:            true,
+						},
+						"line": schema.Int64Attribute{
+							MarkdownDescription: "Line the message is about, not set when it is about the whole file",
+							Computed:            true,
+						},
+						"message": schema.StringAttribute{
+							MarkdownDescription: "Description of the problem",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"error_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of messages of type `error`",
+				Computed:            true,
+			},
+			"warning_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of messages of type `warn`",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ReleaseLintDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("yaml_dir"),
+			path.MatchRoot("files"),
+		),
+	}
+}
+
+func (d *ReleaseLintDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*ReplicatedProviderClients)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ReplicatedProviderClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = clients.vendorAPI
+}
+
+func (d *ReleaseLintDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ReleaseLintDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	files, attrPath, diags := readConfiguredReleaseFiles(ctx, data.YamlDir, data.Files)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spec, err := releaseSpec(files)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(attrPath, "Invalid Release Files", fmt.Sprintf("Unable to package the release files, got error: %s", err))
+		return
+	}
+	tarball, err := releaseTarball(files)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(attrPath, "Invalid Release Files", fmt.Sprintf("Unable to archive the release files, got error: %s", err))
+		return
+	}
+
+	lintMessages, err := d.client.LintRelease(tarball, isHelmChartsOnly(files), "application/tar")
+	if err != nil {
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to lint release, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "linted a release", map[string]interface{}{"messages": len(lintMessages)})
+
+	var errorCount, warningCount int64
+	messages := make([]ReleaseLintMessageModel, 0, len(lintMessages))
+	for _, lintMessage := range lintMessages {
+		switch lintMessage.Type {
+		case lintMessageError:
+			errorCount++
+		case lintMessageWarn:
+			warningCount++
+		}
+		messages = append(messages, releaseLintMessageModel(lintMessage))
+	}
+
+	list, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: releaseLintMessageAttrTypes}, messages)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ContentHash = types.StringValue(releaseContentHash(spec))
+	data.Messages = list
+	data.ErrorCount = types.Int64Value(errorCount)
+	data.WarningCount = types.Int64Value(warningCount)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(releaseLintDiagnostics(lintMessages, data.WarningsAsErrors.ValueBool())...)
+}
+
+// lintMessageLine returns the line a lint message starts at, or 0 when the
+// message is about the whole file.
+func lintMessageLine(m rtypes.LintMessage) int64 {
+	if len(m.Positions) == 0 || m.Positions[0] == nil {
+		return 0
+	}
+	return m.Positions[0].Start.Line
+}
+
+func releaseLintMessageModel(m rtypes.LintMessage) ReleaseLintMessageModel {
+	line := types.Int64Null()
+	if l := lintMessageLine(m); l > 0 {
+		line = types.Int64Value(l)
+	}
+
+	return ReleaseLintMessageModel{
+		Rule:    types.StringValue(m.Rule),
+		Type:    types.StringValue(m.Type),
+		Path:    types.StringValue(m.Path),
+		Line:    line,
+		Message: types.StringValue(m.Message),
+	}
+}
+
+// releaseLintDiagnostics reports lint errors as errors and lint warnings as
+// warnings, or as errors when warningsAsErrors is set. Informational messages
+// are only available in the state.
+func releaseLintDiagnostics(messages []rtypes.LintMessage, warningsAsErrors bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, m := range messages {
+		location := m.Path
+		if line := lintMessageLine(m); line > 0 {
+			location = fmt.Sprintf("%s:%d", m.Path, line)
+		}
+		detail := fmt.Sprintf("%s: %s (%s)", location, m.Message, m.Rule)
+
+		switch {
+		case m.Type == lintMessageError:
+			diags.AddError("Release Lint Error", detail)
+		case m.Type == lintMessageWarn && warningsAsErrors:
+			diags.AddError("Release Lint Warning", detail)
+		case m.Type == lintMessageWarn:
+			diags.AddWarning("Release Lint Warning", detail)
+		}
+	}
+
+	return diags
+}
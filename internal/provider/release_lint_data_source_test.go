@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	rtypes "github.com/replicatedhq/replicated/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccReleaseLintDataSource(t *testing.T) {
+	if testAccVendorAPI(t) == nil {
+		t.Skip("the lint rules checked here are the ones of the mock linter")
+	}
+	valid := testWriteFiles(t, t.TempDir(), map[string]string{"config.yaml": "apiVersion: kots.io/v1beta1\nkind: Config"})
+	warning := testWriteFiles(t, t.TempDir(), map[string]string{"config.yaml": "kind: Config"})
+	broken := testWriteFiles(t, t.TempDir(), map[string]string{"config.yaml": "kind: ["})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "replicated_release_lint" "test" {
+						yaml_dir = %q
+					}
+				`, valid),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.replicated_release_lint.test", "messages.#", "0"),
+					resource.TestCheckResourceAttr("data.replicated_release_lint.test", "error_count", "0"),
+					resource.TestCheckResourceAttrSet("data.replicated_release_lint.test", "content_hash"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "replicated_release_lint" "test" {
+						yaml_dir = %q
+					}
+				`, warning),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.replicated_release_lint.test", "warning_count", "1"),
+					resource.TestCheckResourceAttr("data.replicated_release_lint.test", "messages.0.rule", "missing-apiversion-field"),
+					resource.TestCheckResourceAttr("data.replicated_release_lint.test", "messages.0.path", "config.yaml"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "replicated_release_lint" "test" {
+						yaml_dir           = %q
+						warnings_as_errors = true
+					}
+				`, warning),
+				ExpectError: regexp.MustCompile("Release Lint Warning"),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "replicated_release_lint" "test" {
+						yaml_dir = %q
+					}
+				`, broken),
+				ExpectError: regexp.MustCompile("Release Lint Error"),
+			},
+		},
+	})
+}
+
+func TestReleaseLintDataSourceRead(t *testing.T) {
+	warning := rtypes.LintMessage{
+		Rule:      "missing-apiversion-field",
+		Type:      "warn",
+		Path:      "config.yaml",
+		Message:   "Missing apiVersion field",
+		Positions: []*rtypes.LintPosition{{Path: "config.yaml", Start: rtypes.LintLinePosition{Line: 1}}},
+	}
+	lintError := rtypes.LintMessage{Rule: "invalid-yaml", Type: "error", Path: "config.yaml", Message: "yaml: line 1: did not find expected node content"}
+	info := rtypes.LintMessage{Rule: "may-contain-secrets", Type: "info", Path: "config.yaml", Message: "It looks like there might be secrets in this file"}
+
+	tests := []struct {
+		name             string
+		messages         []rtypes.LintMessage
+		warningsAsErrors bool
+		setup            func(api *fakeVendorAPI)
+		wantMessages     []ReleaseLintMessageModel
+		wantWarnings     int
+		wantErr          string
+	}{
+		{
+			name:         "no messages",
+			wantMessages: []ReleaseLintMessageModel{},
+		},
+		{
+			name:     "warning",
+			messages: []rtypes.LintMessage{warning, info},
+			wantMessages: []ReleaseLintMessageModel{
+				{
+					Rule:    types.StringValue("missing-apiversion-field"),
+					Type:    types.StringValue("warn"),
+					Path:    types.StringValue("config.yaml"),
+					Line:    types.Int64Value(1),
+					Message: types.StringValue("Missing apiVersion field"),
+				},
+				{
+					Rule:    types.StringValue("may-contain-secrets"),
+					Type:    types.StringValue("info"),
+					Path:    types.StringValue("config.yaml"),
+					Line:    types.Int64Null(),
+					Message: types.StringValue("It looks like there might be secrets in this file"),
+				},
+			},
+			wantWarnings: 1,
+		},
+		{
+			name:             "warning as error",
+			messages:         []rtypes.LintMessage{warning},
+			warningsAsErrors: true,
+			wantErr:          "Release Lint Warning",
+		},
+		{
+			name:     "error",
+			messages: []rtypes.LintMessage{warning, lintError},
+			wantErr:  "Release Lint Error",
+		},
+		{
+			name: "api error",
+			setup: func(api *fakeVendorAPI) {
+				api.errs["LintRelease"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			api.lintMessages = tt.messages
+			if tt.setup != nil {
+				tt.setup(api)
+			}
+			d := NewReleaseLintDataSource()
+			testConfiguredDataSource(t, d, api)
+			s := testDataSourceSchema(t, d)
+
+			config := ReleaseLintDataSourceModel{
+				YamlDir:          types.StringValue(testWriteFiles(t, t.TempDir(), map[string]string{"config.yaml": "kind: Config"})),
+				Files:            types.ListNull(types.StringType),
+				WarningsAsErrors: types.BoolValue(tt.warningsAsErrors),
+				ContentHash:      types.StringNull(),
+				Messages:         types.ListNull(types.ObjectType{AttrTypes: releaseLintMessageAttrTypes}),
+				ErrorCount:       types.Int64Null(),
+				WarningCount:     types.Int64Null(),
+			}
+			c, state := testDataSourceConfig(t, s, &config)
+			resp := datasource.ReadResponse{State: state}
+			d.Read(context.Background(), datasource.ReadRequest{Config: c}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+			assert.Len(t, resp.Diagnostics.Warnings(), tt.wantWarnings)
+
+			var got ReleaseLintDataSourceModel
+			require.False(t, resp.State.Get(context.Background(), &got).HasError())
+			var messages []ReleaseLintMessageModel
+			require.False(t, got.Messages.ElementsAs(context.Background(), &messages, false).HasError())
+			assert.Equal(t, tt.wantMessages, messages)
+			assert.Equal(t, int64(0), got.ErrorCount.ValueInt64())
+			assert.Equal(t, int64(tt.wantWarnings), got.WarningCount.ValueInt64())
+			assert.NotEmpty(t, got.ContentHash.ValueString())
+
+			assert.NotEmpty(t, api.lastLintData)
+			assert.False(t, api.lastLintBuilders)
+		})
+	}
+}
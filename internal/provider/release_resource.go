@@ -0,0 +1,291 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/platformclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ReleaseResource{}
+var _ resource.ResourceWithConfigValidators = &ReleaseResource{}
+var _ resource.ResourceWithModifyPlan = &ReleaseResource{}
+
+func NewReleaseResource() resource.Resource {
+	return &ReleaseResource{}
+}
+
+// ReleaseResource defines the resource implementation.
+type ReleaseResource struct {
+	client      VendorAPI
+	appResolver *appResolver
+}
+
+// ReleaseResourceModel describes the resource data model.
+type ReleaseResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	AppId       types.String `tfsdk:"app_id"`
+	YamlDir     types.String `tfsdk:"yaml_dir"`
+	Files       types.List   `tfsdk:"files"`
+	ContentHash types.String `tfsdk:"content_hash"`
+	Sequence    types.Int64  `tfsdk:"sequence"`
+}
+
+func (r *ReleaseResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_release"
+}
+
+func (r *ReleaseResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Release of an app built from local files, packaged the way `replicated release create --yaml-dir` does. " +
+			"Releases are immutable, so any change to the content of the files creates a new release. " +
+			"The Vendor API cannot delete releases, destroying the resource only removes it from the state",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Sequence of the release, as a string",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"app_id": schema.StringAttribute{
+				MarkdownDescription: "ID or slug of the app the release belongs to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"yaml_dir": schema.StringAttribute{
+				MarkdownDescription: "Directory containing the release: KOTS manifests, Helm chart archives and the Embedded Cluster config. " +
+					"Subdirectories are included, hidden files and files that are not yaml or chart archives are skipped. " +
+					"Exactly one of `yaml_dir` and `files` must be set",
+				Optional: true,
+			},
+			"files": schema.ListAttribute{
+				MarkdownDescription: "Files of the release, placed at the root of the release under their file name",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"content_hash": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 of the packaged release. A new release is created when it changes",
+				Computed:            true,
+			},
+			"sequence": schema.Int64Attribute{
+				MarkdownDescription: "Sequence of the release",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ReleaseResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("yaml_dir"),
+			path.MatchRoot("files"),
+		),
+	}
+}
+
+func (r *ReleaseResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	clients, ok := req.ProviderData.(*ReplicatedProviderClients)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ReplicatedProviderClients, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = clients.vendorAPI
+	r.appResolver = clients.appResolver
+}
+
+func (r *ReleaseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ReleaseResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appID, err := r.appResolver.resolveAppID(data.AppId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("app_id"), "Invalid App", fmt.Sprintf("Unable to resolve app, got error: %s", err))
+		return
+	}
+
+	spec, diags := data.releaseSpec(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the files are read again on apply, they must not have changed since
+	// the plan was made
+	contentHash := releaseContentHash(spec)
+	if !data.ContentHash.IsUnknown() && data.ContentHash.ValueString() != contentHash {
+		resp.Diagnostics.AddError("Release Files Changed", "The release files changed after the plan was made, run terraform plan again.")
+		return
+	}
+
+	release, err := r.client.CreateRelease(appID, spec)
+	if err != nil {
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to create release, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "created a release", map[string]interface{}{"app_id": appID, "sequence": release.Sequence})
+
+	data.Id = types.StringValue(strconv.FormatInt(release.Sequence, 10))
+	data.Sequence = types.Int64Value(release.Sequence)
+	data.ContentHash = types.StringValue(contentHash)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ReleaseResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ReleaseResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	appID, err := r.appResolver.resolveAppID(data.AppId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to resolve app, got error: %s", err))
+		return
+	}
+
+	if _, err := r.client.GetRelease(appID, data.Sequence.ValueInt64()); err != nil {
+		if errors.Is(err, platformclient.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Server Error", fmt.Sprintf("Unable to get release, got error: %s", err))
+		return
+	}
+
+	// the content of a release never changes, so there is nothing to refresh
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update only runs when yaml_dir or files point at other files with the same
+// content, any content change replaces the release.
+func (r *ReleaseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ReleaseResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete is a no-op, the Vendor API cannot delete releases.
+func (r *ReleaseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ReleaseResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "removed a release from the state", map[string]interface{}{"sequence": data.Sequence.ValueInt64()})
+}
+
+func (r *ReleaseResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to do on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan ReleaseResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the files are only known once the paths are
+	if plan.YamlDir.IsUnknown() || plan.Files.IsUnknown() {
+		return
+	}
+
+	spec, diags := plan.releaseSpec(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ContentHash = types.StringValue(releaseContentHash(spec))
+
+	if !req.State.Raw.IsNull() {
+		var state ReleaseResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if state.ContentHash.Equal(plan.ContentHash) {
+			// same content from other paths, the release is kept
+			plan.Id = state.Id
+			plan.Sequence = state.Sequence
+		} else {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("content_hash"))
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// releaseSpec reads the files configured in m and returns them packaged as a
+// release spec.
+func (m *ReleaseResourceModel) releaseSpec(ctx context.Context) (string, diag.Diagnostics) {
+	files, attr, diags := readConfiguredReleaseFiles(ctx, m.YamlDir, m.Files)
+	if diags.HasError() {
+		return "", diags
+	}
+
+	spec, err := releaseSpec(files)
+	if err != nil {
+		diags.AddAttributeError(attr, "Invalid Release Files", fmt.Sprintf("Unable to package the release files, got error: %s", err))
+		return "", diags
+	}
+
+	return spec, diags
+}
@@ -0,0 +1,356 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccReleaseResource(t *testing.T) {
+	testAccVendorAPI(t)
+	dir := t.TempDir()
+	config := fmt.Sprintf(`
+		resource "replicated_release" "test" {
+			app_id   = %q
+			yaml_dir = %q
+		}
+	`, testAccAppID, dir)
+
+	var sequence string
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					testWriteFiles(t, dir, map[string]string{"config.yaml": "kind: Config\nmetadata:\n  name: v1"})
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("replicated_release.test", "sequence"),
+					resource.TestCheckResourceAttrSet("replicated_release.test", "content_hash"),
+					func(s *terraform.State) error {
+						sequence = s.RootModule().Resources["replicated_release.test"].Primary.Attributes["sequence"]
+						return nil
+					},
+				),
+			},
+			{
+				PreConfig: func() {
+					testWriteFiles(t, dir, map[string]string{"config.yaml": "kind: Config\nmetadata:\n  name: v2"})
+				},
+				Config: config,
+				Check: func(s *terraform.State) error {
+					if got := s.RootModule().Resources["replicated_release.test"].Primary.Attributes["sequence"]; got == sequence {
+						return fmt.Errorf("expected a new release, sequence is still %s", got)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func testReleaseResourceModel(t *testing.T) ReleaseResourceModel {
+	t.Helper()
+
+	return ReleaseResourceModel{
+		Id:          types.StringUnknown(),
+		AppId:       types.StringValue("test-app"),
+		YamlDir:     types.StringValue(testWriteFiles(t, t.TempDir(), map[string]string{"config.yaml": "kind: Config"})),
+		Files:       types.ListNull(types.StringType),
+		ContentHash: types.StringUnknown(),
+		Sequence:    types.Int64Unknown(),
+	}
+}
+
+func TestReleaseResourceCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		plan    func(t *testing.T, m *ReleaseResourceModel)
+		setup   func(api *fakeVendorAPI)
+		wantErr string
+	}{
+		{
+			name: "yaml dir",
+		},
+		{
+			name: "files",
+			plan: func(t *testing.T, m *ReleaseResourceModel) {
+				dir := testWriteFiles(t, t.TempDir(), map[string]string{"kots-app.yaml": "kind: Application"})
+				m.YamlDir = types.StringNull()
+				m.Files = types.ListValueMust(types.StringType, []attr.Value{types.StringValue(filepath.Join(dir, "kots-app.yaml"))})
+			},
+		},
+		{
+			name: "files changed since plan",
+			plan: func(t *testing.T, m *ReleaseResourceModel) {
+				m.ContentHash = types.StringValue("planned")
+			},
+			wantErr: "Release Files Changed",
+		},
+		{
+			name: "missing dir",
+			plan: func(t *testing.T, m *ReleaseResourceModel) {
+				m.YamlDir = types.StringValue(filepath.Join(t.TempDir(), "missing"))
+			},
+			wantErr: "Invalid Release Files",
+		},
+		{
+			name: "unknown app",
+			plan: func(t *testing.T, m *ReleaseResourceModel) {
+				m.AppId = types.StringValue("other-app")
+			},
+			wantErr: "Invalid App",
+		},
+		{
+			name: "api error",
+			setup: func(api *fakeVendorAPI) {
+				api.errs["CreateRelease"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			if tt.setup != nil {
+				tt.setup(api)
+			}
+			r := NewReleaseResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			plan := testReleaseResourceModel(t)
+			if tt.plan != nil {
+				tt.plan(t, &plan)
+			}
+			resp := fwresource.CreateResponse{State: testState(t, s, nil)}
+			r.Create(context.Background(), fwresource.CreateRequest{Plan: testPlan(t, s, &plan)}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				assert.Empty(t, api.releases[testFakeAppID])
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var got ReleaseResourceModel
+			require.False(t, resp.State.Get(context.Background(), &got).HasError())
+			assert.Equal(t, "1", got.Id.ValueString())
+			assert.Equal(t, int64(1), got.Sequence.ValueInt64())
+
+			require.Len(t, api.releases[testFakeAppID], 1)
+			assert.Equal(t, releaseContentHash(api.releases[testFakeAppID][0].Spec), got.ContentHash.ValueString())
+		})
+	}
+}
+
+func TestReleaseResourceRead(t *testing.T) {
+	tests := []struct {
+		name        string
+		sequence    int64
+		setup       func(api *fakeVendorAPI)
+		wantRemoved bool
+		wantErr     string
+	}{
+		{
+			name:     "release",
+			sequence: 1,
+		},
+		{
+			name:        "missing release",
+			sequence:    2,
+			wantRemoved: true,
+		},
+		{
+			name:     "api error",
+			sequence: 1,
+			setup: func(api *fakeVendorAPI) {
+				api.errs["GetRelease"] = errors.New("boom")
+			},
+			wantErr: "Server Error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeVendorAPI()
+			_, err := api.CreateRelease(testFakeAppID, "[]")
+			require.NoError(t, err)
+			if tt.setup != nil {
+				tt.setup(api)
+			}
+			r := NewReleaseResource()
+			testConfiguredResource(t, r, api)
+			s := testResourceSchema(t, r)
+
+			prior := testReleaseResourceModel(t)
+			prior.Id = types.StringValue(fmt.Sprint(tt.sequence))
+			prior.Sequence = types.Int64Value(tt.sequence)
+			prior.ContentHash = types.StringValue("hash")
+			state := testState(t, s, &prior)
+			resp := fwresource.ReadResponse{State: state}
+			r.Read(context.Background(), fwresource.ReadRequest{State: state}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			if tt.wantRemoved {
+				assert.True(t, resp.State.Raw.IsNull())
+				return
+			}
+
+			var got ReleaseResourceModel
+			require.False(t, resp.State.Get(context.Background(), &got).HasError())
+			assert.Equal(t, prior, got)
+		})
+	}
+}
+
+func TestReleaseResourceModifyPlan(t *testing.T) {
+	dir := testWriteFiles(t, t.TempDir(), map[string]string{"config.yaml": "kind: Config"})
+	files, err := readReleaseDir(dir)
+	require.NoError(t, err)
+	spec, err := releaseSpec(files)
+	require.NoError(t, err)
+	hash := releaseContentHash(spec)
+
+	tests := []struct {
+		name         string
+		plan         func(m *ReleaseResourceModel)
+		state        func(m *ReleaseResourceModel)
+		wantHash     types.String
+		wantSequence types.Int64
+		wantReplace  bool
+		wantErr      string
+	}{
+		{
+			name:         "new release",
+			wantHash:     types.StringValue(hash),
+			wantSequence: types.Int64Unknown(),
+		},
+		{
+			name: "same content",
+			state: func(m *ReleaseResourceModel) {
+				m.YamlDir = types.StringValue(filepath.Join(dir, "old"))
+				m.ContentHash = types.StringValue(hash)
+			},
+			wantHash:     types.StringValue(hash),
+			wantSequence: types.Int64Value(3),
+		},
+		{
+			name: "changed content",
+			state: func(m *ReleaseResourceModel) {
+				m.ContentHash = types.StringValue("old")
+			},
+			wantHash:     types.StringValue(hash),
+			wantSequence: types.Int64Unknown(),
+			wantReplace:  true,
+		},
+		{
+			name: "unknown dir",
+			plan: func(m *ReleaseResourceModel) {
+				m.YamlDir = types.StringUnknown()
+			},
+			wantHash:     types.StringUnknown(),
+			wantSequence: types.Int64Unknown(),
+		},
+		{
+			name: "missing dir",
+			plan: func(m *ReleaseResourceModel) {
+				m.YamlDir = types.StringValue(filepath.Join(dir, "missing"))
+			},
+			wantErr: "Invalid Release Files",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReleaseResource()
+			testConfiguredResource(t, r, newFakeVendorAPI())
+			s := testResourceSchema(t, r)
+
+			plan := testReleaseResourceModel(t)
+			plan.YamlDir = types.StringValue(dir)
+			if tt.plan != nil {
+				tt.plan(&plan)
+			}
+			state := testState(t, s, nil)
+			if tt.state != nil {
+				prior := plan
+				prior.Id = types.StringValue("3")
+				prior.Sequence = types.Int64Value(3)
+				tt.state(&prior)
+				state = testState(t, s, &prior)
+				// the framework keeps the prior sequence through
+				// UseStateForUnknown before ModifyPlan runs
+				if !tt.wantReplace {
+					plan.Id = prior.Id
+					plan.Sequence = prior.Sequence
+				}
+			}
+
+			resp := fwresource.ModifyPlanResponse{Plan: testPlan(t, s, &plan)}
+			r.(fwresource.ResourceWithModifyPlan).ModifyPlan(context.Background(), fwresource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: s, Raw: testState(t, s, &plan).Raw},
+				Plan:   testPlan(t, s, &plan),
+				State:  state,
+			}, &resp)
+
+			if tt.wantErr != "" {
+				require.True(t, resp.Diagnostics.HasError())
+				assert.Equal(t, tt.wantErr, resp.Diagnostics.Errors()[0].Summary())
+				return
+			}
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var got ReleaseResourceModel
+			require.False(t, resp.Plan.Get(context.Background(), &got).HasError())
+			assert.Equal(t, tt.wantHash, got.ContentHash)
+			assert.Equal(t, tt.wantSequence, got.Sequence)
+			if tt.wantReplace {
+				assert.Equal(t, path.Paths{path.Root("content_hash")}, resp.RequiresReplace)
+			} else {
+				assert.Empty(t, resp.RequiresReplace)
+			}
+		})
+	}
+}
+
+func TestReleaseResourceDelete(t *testing.T) {
+	api := newFakeVendorAPI()
+	_, err := api.CreateRelease(testFakeAppID, "[]")
+	require.NoError(t, err)
+	r := NewReleaseResource()
+	testConfiguredResource(t, r, api)
+	s := testResourceSchema(t, r)
+
+	prior := testReleaseResourceModel(t)
+	prior.Id = types.StringValue("1")
+	prior.Sequence = types.Int64Value(1)
+	prior.ContentHash = types.StringValue("hash")
+	state := testState(t, s, &prior)
+	resp := fwresource.DeleteResponse{State: state}
+	r.Delete(context.Background(), fwresource.DeleteRequest{State: state}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	// releases cannot be deleted, the release is kept
+	_, err = api.GetRelease(testFakeAppID, 1)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,271 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/kotsclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testAccResourcePrefix prefixes the name of everything created by the
+// acceptance tests. Sweepers only remove resources carrying this prefix.
+const testAccResourcePrefix = "tf-acc-test"
+
+func init() {
+	resource.AddTestSweepers("replicated_cluster", &resource.Sweeper{
+		Name: "replicated_cluster",
+		F: func(_ string) error {
+			api, err := testSweepVendorAPI()
+			if err != nil {
+				return err
+			}
+			return sweepClusters(api)
+		},
+	})
+
+	resource.AddTestSweepers("replicated_customer", &resource.Sweeper{
+		Name: "replicated_customer",
+		F: func(_ string) error {
+			api, err := testSweepVendorAPI()
+			if err != nil {
+				return err
+			}
+			return sweepCustomers(api)
+		},
+	})
+
+	resource.AddTestSweepers("replicated_channel", &resource.Sweeper{
+		Name:         "replicated_channel",
+		Dependencies: []string{"replicated_customer"},
+		F: func(_ string) error {
+			api, err := testSweepVendorAPI()
+			if err != nil {
+				return err
+			}
+			return sweepChannels(api)
+		},
+	})
+
+	resource.AddTestSweepers("replicated_app", &resource.Sweeper{
+		Name:         "replicated_app",
+		Dependencies: []string{"replicated_customer", "replicated_channel"},
+		F: func(_ string) error {
+			api, err := testSweepVendorAPI()
+			if err != nil {
+				return err
+			}
+			return sweepApps(api)
+		},
+	})
+}
+
+// TestMain runs the sweepers when go test is invoked with -sweep, e.g.
+// go test ./internal/provider -v -sweep=all.
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+// testSweepVendorAPI returns a client for the vendor api the sweepers clean
+// up, configured the same way as the provider through environment variables.
+func testSweepVendorAPI() (VendorAPI, error) {
+	apiToken := os.Getenv("REPLICATED_API_TOKEN")
+	if apiToken == "" {
+		return nil, errors.New("REPLICATED_API_TOKEN must be set to run sweepers")
+	}
+
+	apiOrigin := os.Getenv("REPLICATED_API_ORIGIN")
+	if apiOrigin == "" {
+		apiOrigin = "https://api.replicated.com/vendor"
+	}
+
+	return newVendorAPIClient(apiOrigin, apiToken, &http.Client{}), nil
+}
+
+// sweepClusters removes every running cluster created by the acceptance
+// tests.
+func sweepClusters(api VendorAPI) error {
+	clusters, err := api.ListClusters(false, nil, nil)
+	if err != nil {
+		return errors.Wrap(err, "list clusters")
+	}
+
+	var errs []string
+	for _, cluster := range clusters {
+		if !strings.HasPrefix(cluster.Name, testAccResourcePrefix) {
+			continue
+		}
+		if err := api.RemoveCluster(cluster.ID); err != nil {
+			errs = append(errs, fmt.Sprintf("remove cluster %s: %s", cluster.ID, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// sweepCustomers archives every customer created by the acceptance tests, in
+// all apps the api token has access to.
+func sweepCustomers(api VendorAPI) error {
+	apps, err := api.ListApps(true)
+	if err != nil {
+		return errors.Wrap(err, "list apps")
+	}
+
+	var errs []string
+	for _, app := range apps {
+		if app.App == nil {
+			continue
+		}
+
+		customers, err := api.ListCustomers(app.App.ID, true)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("list customers of app %s: %s", app.App.ID, err))
+			continue
+		}
+
+		for _, customer := range customers {
+			if !strings.HasPrefix(customer.Name, testAccResourcePrefix) {
+				continue
+			}
+			if err := api.ArchiveCustomer(customer.ID); err != nil {
+				errs = append(errs, fmt.Sprintf("archive customer %s: %s", customer.ID, err))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// sweepChannels archives every channel created by the acceptance tests, in
+// all apps the api token has access to.
+func sweepChannels(api VendorAPI) error {
+	apps, err := api.ListApps(true)
+	if err != nil {
+		return errors.Wrap(err, "list apps")
+	}
+
+	var errs []string
+	for _, app := range apps {
+		if app.App == nil {
+			continue
+		}
+
+		channels, err := api.ListAppChannels(app.App.ID)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("list channels of app %s: %s", app.App.ID, err))
+			continue
+		}
+
+		for _, channel := range channels {
+			if !strings.HasPrefix(channel.Name, testAccResourcePrefix) || channel.IsDefault {
+				continue
+			}
+			if err := api.ArchiveChannel(app.App.ID, channel.ID); err != nil {
+				errs = append(errs, fmt.Sprintf("archive channel %s: %s", channel.ID, err))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// sweepApps deletes every app created by the acceptance tests.
+func sweepApps(api VendorAPI) error {
+	apps, err := api.ListApps(true)
+	if err != nil {
+		return errors.Wrap(err, "list apps")
+	}
+
+	var errs []string
+	for _, app := range apps {
+		if app.App == nil || !strings.HasPrefix(app.App.Name, testAccResourcePrefix) {
+			continue
+		}
+		if err := api.DeleteKOTSApp(app.App.ID); err != nil {
+			errs = append(errs, fmt.Sprintf("delete app %s: %s", app.App.ID, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func TestSweepClusters(t *testing.T) {
+	api := newFakeVendorAPI()
+	swept, _, err := api.CreateCluster(kotsclient.CreateClusterOpts{Name: testAccResourcePrefix + "-1234", KubernetesDistribution: "kind"})
+	require.NoError(t, err)
+	kept, _, err := api.CreateCluster(kotsclient.CreateClusterOpts{Name: "production-like", KubernetesDistribution: "kind"})
+	require.NoError(t, err)
+
+	require.NoError(t, sweepClusters(api))
+
+	assert.NotContains(t, api.clusters, swept.ID)
+	assert.Contains(t, api.clusters, kept.ID)
+}
+
+func TestSweepCustomers(t *testing.T) {
+	api := newFakeVendorAPI()
+	swept, err := api.CreateCustomer(CreateCustomerOpts{CreateCustomerOpts: kotsclient.CreateCustomerOpts{Name: testAccResourcePrefix + "-1234"}})
+	require.NoError(t, err)
+	kept, err := api.CreateCustomer(CreateCustomerOpts{CreateCustomerOpts: kotsclient.CreateCustomerOpts{Name: "acme"}})
+	require.NoError(t, err)
+
+	require.NoError(t, sweepCustomers(api))
+
+	assert.True(t, api.archived[swept.ID])
+	assert.False(t, api.archived[kept.ID])
+
+	api.errs["ListCustomers"] = errors.New("boom")
+	assert.Error(t, sweepCustomers(api))
+}
+
+func TestSweepChannels(t *testing.T) {
+	api := newFakeVendorAPI()
+	swept, err := api.CreateChannel(testFakeAppID, testAccResourcePrefix+"-1234", "")
+	require.NoError(t, err)
+	kept, err := api.CreateChannel(testFakeAppID, "Stable", "")
+	require.NoError(t, err)
+
+	require.NoError(t, sweepChannels(api))
+
+	assert.True(t, api.channels[swept.ID].IsArchived)
+	assert.False(t, api.channels[kept.ID].IsArchived)
+
+	api.errs["ListAppChannels"] = errors.New("boom")
+	assert.Error(t, sweepChannels(api))
+}
+
+func TestSweepApps(t *testing.T) {
+	api := newFakeVendorAPI()
+	swept, err := api.CreateKOTSApp(testAccResourcePrefix + "-1234")
+	require.NoError(t, err)
+
+	require.NoError(t, sweepApps(api))
+
+	app, err := findApp(api, swept.Id)
+	require.NoError(t, err)
+	assert.Nil(t, app)
+	app, err = findApp(api, "test-app")
+	require.NoError(t, err)
+	assert.NotNil(t, app)
+
+	api.errs["ListApps"] = errors.New("boom")
+	assert.Error(t, sweepApps(api))
+}
@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ basetypes.StringTypable = TimestampType{}
+
+// TimestampType is a string type holding an RFC3339 timestamp, a date
+// (YYYY-MM-DD, midnight UTC) or a time relative to now such as +365d.
+type TimestampType struct {
+	basetypes.StringType
+}
+
+func (t TimestampType) Equal(o attr.Type) bool {
+	other, ok := o.(TimestampType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t TimestampType) String() string {
+	return "TimestampType"
+}
+
+func (t TimestampType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return TimestampValue{StringValue: in}, nil
+}
+
+func (t TimestampType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return TimestampValue{StringValue: stringValue}, nil
+}
+
+func (t TimestampType) ValueType(ctx context.Context) attr.Value {
+	return TimestampValue{}
+}
+
+var _ basetypes.StringValuableWithSemanticEquals = TimestampValue{}
+var _ xattr.ValidateableAttribute = TimestampValue{}
+
+// TimestampValue is a value of TimestampType. Two timestamps are semantically
+// equal when they describe the same second, so that the formatting of the
+// Vendor API does not produce a diff.
+type TimestampValue struct {
+	basetypes.StringValue
+}
+
+func NewTimestampNull() TimestampValue {
+	return TimestampValue{StringValue: basetypes.NewStringNull()}
+}
+
+func NewTimestampUnknown() TimestampValue {
+	return TimestampValue{StringValue: basetypes.NewStringUnknown()}
+}
+
+func NewTimestampValue(value string) TimestampValue {
+	return TimestampValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v TimestampValue) Equal(o attr.Value) bool {
+	other, ok := o.(TimestampValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v TimestampValue) Type(ctx context.Context) attr.Type {
+	return TimestampType{}
+}
+
+// StringSemanticEquals reports whether the timestamps describe the same
+// second. A relative timestamp only equals itself, the resources decide when
+// the timestamp it was resolved to still holds.
+func (v TimestampValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(TimestampValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			"An unexpected value type was received while performing semantic equality checks. "+
+				"Please report this to the provider developers.\n\n"+
+				"Expected Value Type: "+fmt.Sprintf("%T", v)+"\n"+
+				"Got Value Type: "+fmt.Sprintf("%T", newValuable),
+		)
+		return false, diags
+	}
+
+	priorTime, err := parseTimestamp(v.ValueString())
+	if err != nil {
+		return false, diags
+	}
+	newTime, err := parseTimestamp(newValue.ValueString())
+	if err != nil {
+		return false, diags
+	}
+
+	return priorTime.Truncate(time.Second).Equal(newTime.Truncate(time.Second)), diags
+}
+
+func (v TimestampValue) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	if _, err := v.Resolve(time.Now()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Timestamp", fmt.Sprintf("Expected an RFC3339 timestamp, a date (YYYY-MM-DD) or a relative time such as +365d or +12h, got: %q", v.ValueString()))
+	}
+}
+
+// IsRelative reports whether the timestamp is relative to now, e.g. +365d.
+func (v TimestampValue) IsRelative() bool {
+	return relativeTimestampRegexp.MatchString(v.ValueString())
+}
+
+// Resolve returns the time the timestamp describes, resolving relative
+// timestamps against now.
+func (v TimestampValue) Resolve(now time.Time) (time.Time, error) {
+	if m := relativeTimestampRegexp.FindStringSubmatch(v.ValueString()); m != nil {
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		unit := time.Hour
+		if m[2] == "d" {
+			unit = 24 * time.Hour
+		}
+		return now.Add(time.Duration(n) * unit), nil
+	}
+
+	return parseTimestamp(v.ValueString())
+}
+
+// relativeTimestampRegexp matches a number of days or hours from now.
+var relativeTimestampRegexp = regexp.MustCompile(`^\+(\d+)([dh])$`)
+
+func parseTimestamp(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+
+	return time.Parse(time.DateOnly, value)
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimestampValueStringSemanticEquals(t *testing.T) {
+	tests := []struct {
+		name      string
+		prior     string
+		new       string
+		wantEqual bool
+	}{
+		{
+			name:      "same string",
+			prior:     "2030-01-30T15:04:05Z",
+			new:       "2030-01-30T15:04:05Z",
+			wantEqual: true,
+		},
+		{
+			name:      "offset",
+			prior:     "2030-01-30T16:04:05+01:00",
+			new:       "2030-01-30T15:04:05Z",
+			wantEqual: true,
+		},
+		{
+			name:      "fractional seconds",
+			prior:     "2030-01-30T15:04:05.123Z",
+			new:       "2030-01-30T15:04:05Z",
+			wantEqual: true,
+		},
+		{
+			name:      "date",
+			prior:     "2030-01-30",
+			new:       "2030-01-30T00:00:00Z",
+			wantEqual: true,
+		},
+		{
+			name:  "relative",
+			prior: "+365d",
+			new:   "2030-01-30T15:04:05Z",
+		},
+		{
+			name:  "different time",
+			prior: "2030-01-30T15:04:05Z",
+			new:   "2030-01-30T15:04:06Z",
+		},
+		{
+			name:  "invalid",
+			prior: "tomorrow",
+			new:   "2030-01-30T15:04:05Z",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal, diags := NewTimestampValue(tt.prior).StringSemanticEquals(context.Background(), NewTimestampValue(tt.new))
+			require.False(t, diags.HasError(), "%v", diags)
+			assert.Equal(t, tt.wantEqual, equal)
+		})
+	}
+}
+
+func TestTimestampValueResolve(t *testing.T) {
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "2030-01-30T15:04:05Z", want: time.Date(2030, 1, 30, 15, 4, 5, 0, time.UTC)},
+		{value: "2030-01-30T16:04:05+01:00", want: time.Date(2030, 1, 30, 15, 4, 5, 0, time.UTC)},
+		{value: "2030-01-30", want: time.Date(2030, 1, 30, 0, 0, 0, 0, time.UTC)},
+		{value: "+365d", want: time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)},
+		{value: "+12h", want: time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC)},
+		{value: "-1d", wantErr: true},
+		{value: "+1y", wantErr: true},
+		{value: "30/01/2030", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := NewTimestampValue(tt.value).Resolve(now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %s", got)
+		})
+	}
+}
+
+func TestTimestampValueValidateAttribute(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   TimestampValue
+		wantErr bool
+	}{
+		{name: "timestamp", value: NewTimestampValue("2030-01-30T15:04:05Z")},
+		{name: "relative", value: NewTimestampValue("+30d")},
+		{name: "null", value: NewTimestampNull()},
+		{name: "unknown", value: NewTimestampUnknown()},
+		{name: "invalid", value: NewTimestampValue("next year"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp xattr.ValidateAttributeResponse
+			tt.value.ValidateAttribute(context.Background(), xattr.ValidateAttributeRequest{Path: path.Root("expires_at")}, &resp)
+			assert.Equal(t, tt.wantErr, resp.Diagnostics.HasError())
+		})
+	}
+}
@@ -0,0 +1,604 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/replicated/pkg/kotsclient"
+	"github.com/replicatedhq/replicated/pkg/platformclient"
+	rtypes "github.com/replicatedhq/replicated/pkg/types"
+	"github.com/replicatedhq/replicated/pkg/util"
+)
+
+// VendorAPI is the subset of the Vendor v3 API used by the provider. It is
+// satisfied by *vendorAPIClient and lets the resources be tested against an
+// in-memory implementation.
+type VendorAPI interface {
+	ListApps(excludeChannels bool) ([]rtypes.AppAndChannels, error)
+	CreateKOTSApp(name string) (*rtypes.KotsAppWithChannels, error)
+	DeleteKOTSApp(id string) error
+	ListLicenseFields(appID string) ([]LicenseField, error)
+
+	CreateCluster(opts kotsclient.CreateClusterOpts) (*rtypes.Cluster, *kotsclient.CreateClusterErrorError, error)
+	ListClusters(includeTerminated bool, startTime *time.Time, endTime *time.Time) ([]*rtypes.Cluster, error)
+	GetCluster(id string) (*rtypes.Cluster, error)
+	GetClusterKubeconfig(id string) ([]byte, error)
+	RemoveCluster(id string) error
+
+	CreateCustomer(opts CreateCustomerOpts) (*rtypes.Customer, error)
+	ListCustomers(appID string, includeTest bool) ([]rtypes.Customer, error)
+	GetCustomerByNameOrId(appID string, nameOrId string) (*rtypes.Customer, error)
+	UpdateCustomer(customerID string, opts UpdateCustomerOpts) (*rtypes.Customer, error)
+	ArchiveCustomer(customerID string) error
+	ListArchivedCustomers(appID string) ([]rtypes.Customer, error)
+	ListCustomersWithDetails(appID string, includeArchived bool) ([]CustomerWithDetails, error)
+	UnarchiveCustomer(customerID string) error
+	GetCustomer(customerID string) (*rtypes.Customer, string, error)
+	GetCustomerDetails(customerID string) (*CustomerDetails, error)
+	DownloadLicense(appID string, customerID string) ([]byte, error)
+
+	CreateChannel(appID string, name string, description string) (*rtypes.Channel, error)
+	GetAppChannel(appID string, channelID string) (*AppChannel, error)
+	ListAppChannels(appID string) ([]AppChannel, error)
+	UpdateAppChannel(appID string, channelID string, opts UpdateChannelOpts) (*AppChannel, error)
+	SetDefaultChannel(appID string, channelID string) error
+	ArchiveChannel(appID string, channelID string) error
+
+	CreateRelease(appID string, multiyaml string) (*rtypes.ReleaseInfo, error)
+	GetRelease(appID string, sequence int64) (*rtypes.AppRelease, error)
+	PromoteRelease(appID string, sequence int64, label string, notes string, required bool, channelIDs ...string) error
+	ListChannelReleases(appID string, channelID string) ([]AppChannelRelease, error)
+	DemoteChannelRelease(appID string, channelID string, channelSequence int64) error
+	LintRelease(data []byte, isBuildersRelease bool, contentType string) ([]rtypes.LintMessage, error)
+	BuildAirgapRelease(appID string, channelID string, channelSequence int64) error
+	GetAirgapDownloadURL(appID string, customerID string, channelID string, channelSequence int64) (string, error)
+}
+
+var _ VendorAPI = &vendorAPIClient{}
+
+// LicenseField is a custom license field defined by an app. Its type is one of
+// String, Text, Integer, Boolean or File.
+type LicenseField struct {
+	Name     string `json:"name"`
+	Title    string `json:"title"`
+	Type     string `json:"type"`
+	Default  string `json:"default"`
+	Required bool   `json:"required"`
+	Hidden   bool   `json:"hidden"`
+	IsSecret bool   `json:"isSecret"`
+}
+
+// CustomerDetails holds what rtypes.Customer does not decode: the creation and
+// last update times of a customer, the settings of its channels and the
+// license options of CustomerLicenseOptions.
+type CustomerDetails struct {
+	CreatedAt                         *util.Time               `json:"createdAt"`
+	UpdatedAt                         *util.Time               `json:"updatedAt"`
+	Channels                          []CustomerChannelDetails `json:"channels"`
+	IsHelmInstallEnabled              bool                     `json:"isHelmInstallEnabled"`
+	IsDisasterRecoverySupported       bool                     `json:"isDisasterRecoverySupported"`
+	IsDevModeEnabled                  bool                     `json:"isDevModeEnabled"`
+	IsEmbeddedClusterMultiNodeEnabled bool                     `json:"isEmbeddedClusterMultiNodeEnabled"`
+	IsKurlInstallEnabled              bool                     `json:"isKurlInstallEnabled"`
+}
+
+// licenseOptions returns the license options of the customer.
+func (d *CustomerDetails) licenseOptions() CustomerLicenseOptions {
+	return CustomerLicenseOptions{
+		IsHelmInstallEnabled:              d.IsHelmInstallEnabled,
+		IsDisasterRecoverySupported:       d.IsDisasterRecoverySupported,
+		IsDevModeEnabled:                  d.IsDevModeEnabled,
+		IsEmbeddedClusterMultiNodeEnabled: d.IsEmbeddedClusterMultiNodeEnabled,
+		IsKurlInstallEnabled:              d.IsKurlInstallEnabled,
+	}
+}
+
+// CustomerWithDetails is a customer listed along with its details.
+type CustomerWithDetails struct {
+	rtypes.Customer
+	Details  CustomerDetails
+	Archived bool
+}
+
+// CustomerChannelDetails are the settings of a channel a customer has access
+// to.
+type CustomerChannelDetails struct {
+	ID                    string `json:"id"`
+	IsDefault             bool   `json:"isDefaultForCustomer"`
+	PinnedChannelSequence *int64 `json:"pinnedChannelSequence"`
+}
+
+// CustomerLicenseOptions are the license options of a customer that the
+// client library neither sends nor decodes.
+type CustomerLicenseOptions struct {
+	IsHelmInstallEnabled              bool `json:"is_helm_install_enabled"`
+	IsDisasterRecoverySupported       bool `json:"is_disaster_recovery_supported"`
+	IsDevModeEnabled                  bool `json:"is_dev_mode_enabled"`
+	IsEmbeddedClusterMultiNodeEnabled bool `json:"is_embedded_cluster_multinode_enabled"`
+	IsKurlInstallEnabled              bool `json:"is_kurl_install_enabled"`
+}
+
+// CreateCustomerOpts extends kotsclient.CreateCustomerOpts with the license
+// options the client library does not send.
+type CreateCustomerOpts struct {
+	kotsclient.CreateCustomerOpts
+	CustomerLicenseOptions
+}
+
+// createCustomerRequest is the body of a create customer request.
+type createCustomerRequest struct {
+	kotsclient.CreateCustomerRequest
+	CustomerLicenseOptions
+}
+
+// UpdateCustomerOpts extends kotsclient.UpdateCustomerOpts with the options
+// the client library does not send. The vendor api replaces every field of the
+// customer on update, so an option that is not sent is reset.
+type UpdateCustomerOpts struct {
+	kotsclient.UpdateCustomerOpts
+	CustomerLicenseOptions
+	IsInstallerSupportEnabled bool
+}
+
+// updateCustomerRequest is the body of an update customer request.
+type updateCustomerRequest struct {
+	kotsclient.UpdateCustomerRequest
+	CustomerLicenseOptions
+	IsInstallerSupportEnabled bool `json:"is_installer_support_enabled"`
+}
+
+// AppChannel is a release channel of an app, with the settings that
+// rtypes.Channel does not decode.
+type AppChannel struct {
+	ID                       string                         `json:"id"`
+	AppID                    string                         `json:"appId"`
+	Name                     string                         `json:"name"`
+	Description              string                         `json:"description"`
+	Slug                     string                         `json:"channelSlug"`
+	IsDefault                bool                           `json:"isDefault"`
+	IsArchived               bool                           `json:"isArchived"`
+	SemverRequired           bool                           `json:"semverRequired"`
+	BuildAirgapAutomatically bool                           `json:"buildAirgapAutomatically"`
+	CustomHostNameOverrides  rtypes.CustomHostNameOverrides `json:"customHostNameOverrides"`
+	ReleaseSequence          int64                          `json:"releaseSequence"`
+	CurrentVersion           string                         `json:"currentVersion"`
+}
+
+// UpdateChannelOpts are the settings of a channel. The vendor api replaces
+// every setting of the channel on update, so a setting that is not sent is
+// reset.
+type UpdateChannelOpts struct {
+	Name                     string                         `json:"name"`
+	Description              string                         `json:"description"`
+	SemverRequired           bool                           `json:"semverRequired"`
+	BuildAirgapAutomatically bool                           `json:"buildAirgapAutomatically"`
+	CustomHostNameOverrides  rtypes.CustomHostNameOverrides `json:"customHostNameOverrides"`
+}
+
+// AppChannelRelease is a promotion of a release to a channel. Every promotion
+// gets a new channel sequence, the release sequence identifies the release
+// that was promoted.
+type AppChannelRelease struct {
+	ChannelSequence   int64     `json:"channelSequence"`
+	Sequence          int64     `json:"sequence"`
+	Semver            string    `json:"semver"`
+	ReleaseNotes      string    `json:"releaseNotes"`
+	Created           time.Time `json:"created"`
+	IsRequired        bool      `json:"isRequired"`
+	IsDemoted         bool      `json:"isDemoted"`
+	AirgapBuildStatus string    `json:"airgapBuildStatus"`
+	AirgapBuildError  string    `json:"airgapBuildError"`
+}
+
+// vendorAPIClient adds the endpoints the provider needs that are not
+// implemented by kotsclient.VendorV3Client. Its requests are sent through the
+// http client of the provider instance, see vendorTransports.
+type vendorAPIClient struct {
+	*kotsclient.VendorV3Client
+
+	// client sends the requests to the release linter, which is not part of
+	// the vendor api
+	client *http.Client
+}
+
+func newVendorAPIClient(apiOrigin string, apiToken string, client *http.Client) *vendorAPIClient {
+	httpClient := platformclient.NewHTTPClient(vendorTransports.register(apiOrigin, client), apiToken)
+
+	return &vendorAPIClient{
+		VendorV3Client: &kotsclient.VendorV3Client{HTTPClient: *httpClient},
+		client:         client,
+	}
+}
+
+// CreateCustomer replaces kotsclient.VendorV3Client.CreateCustomer, which
+// does not send the options of CustomerLicenseOptions.
+func (c *vendorAPIClient) CreateCustomer(opts CreateCustomerOpts) (*rtypes.Customer, error) {
+	request := &createCustomerRequest{
+		CreateCustomerRequest: kotsclient.CreateCustomerRequest{
+			Name:                             opts.Name,
+			CustomID:                         opts.CustomID,
+			Channels:                         opts.Channels,
+			AppID:                            opts.AppID,
+			Type:                             opts.LicenseType,
+			ExpiresAt:                        opts.ExpiresAt,
+			IsAirgapEnabled:                  opts.IsAirgapEnabled,
+			IsGitopsSupported:                opts.IsGitopsSupported,
+			IsSnapshotSupported:              opts.IsSnapshotSupported,
+			IsKotsInstallEnabled:             opts.IsKotsInstallEnabled,
+			IsEmbeddedClusterDownloadEnabled: opts.IsEmbeddedClusterDownloadEnabled,
+			IsGeoaxisSupported:               opts.IsGeoaxisSupported,
+			IsHelmVMDownloadEnabled:          opts.IsHelmVMDownloadEnabled,
+			IsIdentityServiceSupported:       opts.IsIdentityServiceSupported,
+			IsInstallerSupportEnabled:        opts.IsInstallerSupportEnabled,
+			IsSupportBundleUploadEnabled:     opts.IsSupportBundleUploadEnabled,
+			Email:                            opts.Email,
+			EntitlementValues:                opts.EntitlementValues,
+		},
+		CustomerLicenseOptions: opts.CustomerLicenseOptions,
+	}
+	if opts.ExpiresAtDuration > 0 {
+		request.ExpiresAt = time.Now().UTC().Add(opts.ExpiresAtDuration).Format(time.RFC3339)
+	}
+
+	var resp kotsclient.CreateCustomerResponse
+	err := c.DoJSON("POST", "/v3/customer", http.StatusCreated, request, &resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "create customer")
+	}
+
+	return resp.Customer, nil
+}
+
+// GetCustomerByNameOrId returns the only customer of the app with the id or
+// name. Unlike kotsclient.VendorV3Client.GetCustomerByNameOrId it includes
+// test customers, which the customer resource can create.
+func (c *vendorAPIClient) GetCustomerByNameOrId(appID string, nameOrId string) (*rtypes.Customer, error) {
+	customers, err := c.ListCustomers(appID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []rtypes.Customer
+	for _, customer := range customers {
+		if customer.ID == nameOrId || customer.Name == nameOrId {
+			matches = append(matches, customer)
+		}
+	}
+
+	if len(matches) == 0 {
+		return nil, kotsclient.ErrCustomerNotFound{Name: nameOrId}
+	}
+	if len(matches) > 1 {
+		return nil, errors.Errorf("customer %q is ambiguous, please use customer ID", nameOrId)
+	}
+
+	return &matches[0], nil
+}
+
+func (c *vendorAPIClient) ListLicenseFields(appID string) ([]LicenseField, error) {
+	var fields []LicenseField
+
+	err := c.DoJSON("GET", fmt.Sprintf("/v3/app/%s/license-fields", url.PathEscape(appID)), http.StatusOK, nil, &fields)
+	if err != nil {
+		return nil, errors.Wrap(err, "list license fields")
+	}
+
+	return fields, nil
+}
+
+// GetCustomer returns the customer with the id together with the id of its
+// app, fetching both in one request. Archived customers are not found.
+func (c *vendorAPIClient) GetCustomer(customerID string) (*rtypes.Customer, string, error) {
+	var resp struct {
+		Customer struct {
+			rtypes.Customer
+			AppID      string     `json:"appId"`
+			ArchivedAt *util.Time `json:"archivedAt"`
+		} `json:"customer"`
+	}
+
+	err := c.DoJSON("GET", fmt.Sprintf("/v3/customer/%s", url.PathEscape(customerID)), http.StatusOK, nil, &resp)
+	if err != nil {
+		if errors.Is(err, platformclient.ErrNotFound) {
+			return nil, "", kotsclient.ErrCustomerNotFound{Name: customerID}
+		}
+		return nil, "", errors.Wrap(err, "get customer")
+	}
+
+	if resp.Customer.ID != customerID || resp.Customer.ArchivedAt != nil {
+		return nil, "", kotsclient.ErrCustomerNotFound{Name: customerID}
+	}
+	if resp.Customer.AppID == "" {
+		return nil, "", errors.Errorf("customer %s has no app", customerID)
+	}
+
+	return &resp.Customer.Customer, resp.Customer.AppID, nil
+}
+
+func (c *vendorAPIClient) GetCustomerDetails(customerID string) (*CustomerDetails, error) {
+	var resp struct {
+		Customer CustomerDetails `json:"customer"`
+	}
+
+	err := c.DoJSON("GET", fmt.Sprintf("/v3/customer/%s", url.PathEscape(customerID)), http.StatusOK, nil, &resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "get customer")
+	}
+
+	return &resp.Customer, nil
+}
+
+// UpdateCustomer replaces kotsclient.VendorV3Client.UpdateCustomer, which
+// does not send whether installer support is enabled nor the options of
+// CustomerLicenseOptions.
+func (c *vendorAPIClient) UpdateCustomer(customerID string, opts UpdateCustomerOpts) (*rtypes.Customer, error) {
+	request := &updateCustomerRequest{
+		UpdateCustomerRequest: kotsclient.UpdateCustomerRequest{
+			Name:                             opts.Name,
+			CustomID:                         opts.CustomID,
+			Channels:                         opts.Channels,
+			AppID:                            opts.AppID,
+			Type:                             opts.LicenseType,
+			ExpiresAt:                        opts.ExpiresAt,
+			IsAirgapEnabled:                  opts.IsAirgapEnabled,
+			IsGitopsSupported:                opts.IsGitopsSupported,
+			IsSnapshotSupported:              opts.IsSnapshotSupported,
+			IsKotsInstallEnabled:             opts.IsKotsInstallEnabled,
+			IsEmbeddedClusterDownloadEnabled: opts.IsEmbeddedClusterDownloadEnabled,
+			IsGeoaxisSupported:               opts.IsGeoaxisSupported,
+			IsHelmVMDownloadEnabled:          opts.IsHelmVMDownloadEnabled,
+			IsIdentityServiceSupported:       opts.IsIdentityServiceSupported,
+			IsSupportBundleUploadEnabled:     opts.IsSupportBundleUploadEnabled,
+			Email:                            opts.Email,
+			EntitlementValues:                opts.EntitlementValues,
+		},
+		CustomerLicenseOptions:    opts.CustomerLicenseOptions,
+		IsInstallerSupportEnabled: opts.IsInstallerSupportEnabled,
+	}
+	if opts.ExpiresAtDuration > 0 {
+		request.ExpiresAt = time.Now().UTC().Add(opts.ExpiresAtDuration).Format(time.RFC3339)
+	}
+
+	var resp kotsclient.UpdateCustomerResponse
+	err := c.DoJSON("PUT", fmt.Sprintf("/v3/customer/%s", url.PathEscape(customerID)), http.StatusOK, request, &resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "update customer")
+	}
+
+	return resp.Customer, nil
+}
+
+// ListArchivedCustomers returns the archived customers of the app, which
+// kotsclient.VendorV3Client.ListCustomers leaves out.
+func (c *vendorAPIClient) ListArchivedCustomers(appID string) ([]rtypes.Customer, error) {
+	listed, err := c.ListCustomersWithDetails(appID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	customers := []rtypes.Customer{}
+	for _, customer := range listed {
+		if customer.Archived {
+			customers = append(customers, customer.Customer)
+		}
+	}
+
+	return customers, nil
+}
+
+// ListCustomersWithDetails returns the customers of the app, test customers
+// included, decoding their details from the list instead of getting each
+// customer.
+func (c *vendorAPIClient) ListCustomersWithDetails(appID string, includeArchived bool) ([]CustomerWithDetails, error) {
+	customers := []CustomerWithDetails{}
+	for page := 0; ; page++ {
+		var resp struct {
+			Customers      []json.RawMessage `json:"customers"`
+			TotalCustomers int               `json:"totalCustomers"`
+		}
+
+		endpoint := fmt.Sprintf("/v3/app/%s/customers?currentPage=%d&includeTest=true", url.PathEscape(appID), page)
+		if includeArchived {
+			endpoint += "&includeArchived=true"
+		}
+		if err := c.DoJSON("GET", endpoint, http.StatusOK, nil, &resp); err != nil {
+			return nil, errors.Wrapf(err, "list customers page %d", page)
+		}
+
+		for _, data := range resp.Customers {
+			// rtypes.Customer and CustomerDetails both decode channels, so
+			// they cannot be embedded in one struct
+			var customer CustomerWithDetails
+			var archived struct {
+				ArchivedAt *util.Time `json:"archivedAt"`
+			}
+			if err := json.Unmarshal(data, &customer.Customer); err != nil {
+				return nil, errors.Wrap(err, "decode customer")
+			}
+			if err := json.Unmarshal(data, &customer.Details); err != nil {
+				return nil, errors.Wrap(err, "decode customer details")
+			}
+			if err := json.Unmarshal(data, &archived); err != nil {
+				return nil, errors.Wrap(err, "decode customer archive time")
+			}
+			customer.Archived = archived.ArchivedAt != nil
+			customers = append(customers, customer)
+		}
+
+		if len(customers) >= resp.TotalCustomers || len(resp.Customers) == 0 {
+			break
+		}
+	}
+
+	return customers, nil
+}
+
+func (c *vendorAPIClient) UnarchiveCustomer(customerID string) error {
+	err := c.DoJSON("POST", fmt.Sprintf("/v3/customer/%s/unarchive", url.PathEscape(customerID)), http.StatusNoContent, nil, nil)
+	if err != nil {
+		return errors.Wrap(err, "unarchive customer")
+	}
+
+	return nil
+}
+
+// GetAppChannel replaces kotsclient.VendorV3Client.GetChannel, which does not
+// decode the channel settings.
+func (c *vendorAPIClient) GetAppChannel(appID string, channelID string) (*AppChannel, error) {
+	var resp struct {
+		Channel AppChannel `json:"channel"`
+	}
+
+	err := c.DoJSON("GET", fmt.Sprintf("/v3/app/%s/channel/%s", url.PathEscape(appID), url.PathEscape(channelID)), http.StatusOK, nil, &resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "get channel")
+	}
+
+	return &resp.Channel, nil
+}
+
+func (c *vendorAPIClient) ListAppChannels(appID string) ([]AppChannel, error) {
+	var resp struct {
+		Channels []AppChannel `json:"channels"`
+	}
+
+	err := c.DoJSON("GET", fmt.Sprintf("/v3/app/%s/channels?excludeDetail=true", url.PathEscape(appID)), http.StatusOK, nil, &resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "list channels")
+	}
+
+	return resp.Channels, nil
+}
+
+// UpdateAppChannel replaces kotsclient.VendorV3Client.UpdateSemanticVersioning,
+// which can only enable semantic versioning and resets the other settings.
+func (c *vendorAPIClient) UpdateAppChannel(appID string, channelID string, opts UpdateChannelOpts) (*AppChannel, error) {
+	var resp struct {
+		Channel AppChannel `json:"channel"`
+	}
+
+	err := c.DoJSON("PUT", fmt.Sprintf("/v3/app/%s/channel/%s", url.PathEscape(appID), url.PathEscape(channelID)), http.StatusOK, opts, &resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "update channel")
+	}
+
+	return &resp.Channel, nil
+}
+
+// SetDefaultChannel makes the channel the default channel of the app, the
+// previous default channel is unset by the vendor api.
+func (c *vendorAPIClient) SetDefaultChannel(appID string, channelID string) error {
+	err := c.DoJSON("PUT", fmt.Sprintf("/v3/app/%s/channel/%s/default", url.PathEscape(appID), url.PathEscape(channelID)), http.StatusOK, nil, nil)
+	if err != nil {
+		return errors.Wrap(err, "set default channel")
+	}
+
+	return nil
+}
+
+// ListChannelReleases returns the promotions of the channel, which
+// kotsclient.VendorV3Client only exposes without the required flag.
+func (c *vendorAPIClient) ListChannelReleases(appID string, channelID string) ([]AppChannelRelease, error) {
+	var resp struct {
+		Releases []AppChannelRelease `json:"releases"`
+	}
+
+	err := c.DoJSON("GET", fmt.Sprintf("/v3/app/%s/channel/%s/releases", url.PathEscape(appID), url.PathEscape(channelID)), http.StatusOK, nil, &resp)
+	if err != nil {
+		return nil, errors.Wrap(err, "list channel releases")
+	}
+
+	return resp.Releases, nil
+}
+
+// DemoteChannelRelease demotes the promotion with the channel sequence, the
+// release stays available on the other channels it was promoted to.
+func (c *vendorAPIClient) DemoteChannelRelease(appID string, channelID string, channelSequence int64) error {
+	endpoint := fmt.Sprintf("/v3/app/%s/channel/%s/release/%d/demote", url.PathEscape(appID), url.PathEscape(channelID), channelSequence)
+	if err := c.DoJSON("POST", endpoint, http.StatusOK, nil, nil); err != nil {
+		return errors.Wrap(err, "demote channel release")
+	}
+
+	return nil
+}
+
+// BuildAirgapRelease starts building the airgap bundle of the promotion with
+// the channel sequence, the build status is reported by ListChannelReleases.
+func (c *vendorAPIClient) BuildAirgapRelease(appID string, channelID string, channelSequence int64) error {
+	endpoint := fmt.Sprintf("/v3/app/%s/channel/%s/release/%d/airgap/build", url.PathEscape(appID), url.PathEscape(channelID), channelSequence)
+	if err := c.DoJSON("POST", endpoint, http.StatusOK, nil, nil); err != nil {
+		return errors.Wrap(err, "build airgap release")
+	}
+
+	return nil
+}
+
+// GetAirgapDownloadURL returns a signed url to download the airgap bundle of
+// the promotion with the channel sequence with the license of the customer.
+func (c *vendorAPIClient) GetAirgapDownloadURL(appID string, customerID string, channelID string, channelSequence int64) (string, error) {
+	var resp struct {
+		URL string `json:"url"`
+	}
+
+	query := url.Values{}
+	query.Set("channelId", channelID)
+	query.Set("channelSequence", fmt.Sprint(channelSequence))
+	endpoint := fmt.Sprintf("/v3/app/%s/customer/%s/airgap/download-url?%s", url.PathEscape(appID), url.PathEscape(customerID), query.Encode())
+	if err := c.DoJSON("GET", endpoint, http.StatusOK, nil, &resp); err != nil {
+		return "", errors.Wrap(err, "get airgap download url")
+	}
+
+	return resp.URL, nil
+}
+
+// linterOrigin returns the origin of the release linter, which can be
+// overridden with LINTER_API_ORIGIN like the replicated CLI allows.
+func linterOrigin() string {
+	if origin := os.Getenv("LINTER_API_ORIGIN"); origin != "" {
+		return origin
+	}
+	return "https://lint.replicated.com"
+}
+
+// LintRelease replaces kotsclient.VendorV3Client.LintRelease, which reports a
+// release without any lint message when the linter answers with an error.
+func (c *vendorAPIClient) LintRelease(data []byte, isBuildersRelease bool, contentType string) ([]rtypes.LintMessage, error) {
+	endpoint := "/v1/lint"
+	if isBuildersRelease {
+		endpoint = "/v1/builders-lint"
+	}
+
+	req, err := http.NewRequest("POST", linterOrigin()+endpoint, bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "create lint request")
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "lint release")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, errors.Errorf("lint release: unexpected status code %d: %s", resp.StatusCode, body)
+	}
+
+	var lintResp struct {
+		LintExpressions []rtypes.LintMessage `json:"lintExpressions"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&lintResp); err != nil {
+		return nil, errors.Wrap(err, "decode lint response")
+	}
+
+	return lintResp.LintExpressions, nil
+}
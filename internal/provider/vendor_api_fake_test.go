@@ -0,0 +1,930 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/replicatedhq/replicated/pkg/kotsclient"
+	"github.com/replicatedhq/replicated/pkg/platformclient"
+	rtypes "github.com/replicatedhq/replicated/pkg/types"
+	"github.com/replicatedhq/replicated/pkg/util"
+)
+
+var _ VendorAPI = &fakeVendorAPI{}
+
+// fakeNow is the creation time of everything created through the fake,
+// updates happen an hour later.
+var fakeNow = time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+
+// fakeVendorAPI is an in-memory VendorAPI for unit tests. Errors can be
+// injected per method name through errs, and the options of the last create
+// and update calls are recorded so that tests can assert on the payloads.
+type fakeVendorAPI struct {
+	mu sync.Mutex
+
+	apps          []rtypes.AppAndChannels
+	licenseFields []LicenseField
+	clusters      map[string]*rtypes.Cluster
+	kubeconfigs   map[string][]byte
+	customers     map[string]*rtypes.Customer
+	details       map[string]*CustomerDetails
+	archived      map[string]bool
+	// customerApps holds the app id of each customer
+	customerApps map[string]string
+	channels     map[string]*AppChannel
+	// releases holds the releases of each app, ordered by sequence starting
+	// at 1
+	releases map[string][]*rtypes.KotsAppRelease
+	// channelReleases holds the promotions of each channel, ordered by
+	// channel sequence starting at 0
+	channelReleases map[string][]*AppChannelRelease
+
+	// clusterStatus is the status of newly created clusters, it defaults to
+	// running
+	clusterStatus rtypes.ClusterStatus
+	// clusterValidationError is returned by CreateCluster when set
+	clusterValidationError *kotsclient.CreateClusterErrorError
+	// airgapBuildStatus is the status airgap builds end with after being
+	// listed as building once, it defaults to built
+	airgapBuildStatus string
+	// airgapBuildQueuedListings is how many listings a started airgap build
+	// keeps the status of the previous build, like a build still queued
+	airgapBuildQueuedListings int
+	queuedAirgapBuilds        map[*AppChannelRelease]int
+	// lintMessages is returned by LintRelease for every release
+	lintMessages []rtypes.LintMessage
+
+	errs map[string]error
+
+	lastCreateClusterOpts  *kotsclient.CreateClusterOpts
+	lastCreateCustomerOpts *CreateCustomerOpts
+	lastUpdateCustomerOpts *UpdateCustomerOpts
+	lastUpdateChannelOpts  *UpdateChannelOpts
+	lastLintData           []byte
+	lastLintBuilders       bool
+
+	nextID int
+}
+
+// testFakeAppID is the ID of the app every fakeVendorAPI starts with.
+const testFakeAppID = "2fvVIbMQtNBwMzeTJt2yJrEKEFN"
+
+func newFakeVendorAPI() *fakeVendorAPI {
+	return &fakeVendorAPI{
+		apps: []rtypes.AppAndChannels{
+			{App: &rtypes.App{ID: testFakeAppID, Name: "Test App", Slug: "test-app"}},
+		},
+		licenseFields: []LicenseField{
+			{Name: "seats", Title: "Seats", Type: "Integer", Default: "5"},
+			{Name: "environment", Title: "Environment", Type: "String", Default: "production"},
+			{Name: "sso_enabled", Title: "SSO Enabled", Type: "Boolean", Default: "false"},
+			{Name: "api_key", Title: "API Key", Type: "String", IsSecret: true},
+		},
+		clusters:           map[string]*rtypes.Cluster{},
+		kubeconfigs:        map[string][]byte{},
+		customers:          map[string]*rtypes.Customer{},
+		details:            map[string]*CustomerDetails{},
+		archived:           map[string]bool{},
+		customerApps:       map[string]string{},
+		channels:           map[string]*AppChannel{},
+		releases:           map[string][]*rtypes.KotsAppRelease{},
+		channelReleases:    map[string][]*AppChannelRelease{},
+		queuedAirgapBuilds: map[*AppChannelRelease]int{},
+		errs:               map[string]error{},
+	}
+}
+
+func (f *fakeVendorAPI) id(prefix string) string {
+	f.nextID++
+	return fmt.Sprintf("%s%d", prefix, f.nextID)
+}
+
+func (f *fakeVendorAPI) ListApps(excludeChannels bool) ([]rtypes.AppAndChannels, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["ListApps"]; err != nil {
+		return nil, err
+	}
+
+	return append([]rtypes.AppAndChannels{}, f.apps...), nil
+}
+
+func (f *fakeVendorAPI) CreateKOTSApp(name string) (*rtypes.KotsAppWithChannels, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["CreateKOTSApp"]; err != nil {
+		return nil, err
+	}
+
+	// like the vendor api, the slug is derived from the name
+	app := &rtypes.App{
+		ID:   f.id("app-"),
+		Name: name,
+		Slug: strings.ReplaceAll(strings.ToLower(name), " ", "-"),
+	}
+	f.apps = append(f.apps, rtypes.AppAndChannels{App: app})
+
+	return &rtypes.KotsAppWithChannels{Id: app.ID, Name: app.Name, Slug: app.Slug, Created: fakeNow}, nil
+}
+
+func (f *fakeVendorAPI) DeleteKOTSApp(id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["DeleteKOTSApp"]; err != nil {
+		return err
+	}
+
+	for i, app := range f.apps {
+		if app.App.ID == id {
+			f.apps = append(f.apps[:i], f.apps[i+1:]...)
+			return nil
+		}
+	}
+
+	return platformclient.ErrNotFound
+}
+
+func (f *fakeVendorAPI) ListLicenseFields(appID string) ([]LicenseField, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["ListLicenseFields"]; err != nil {
+		return nil, err
+	}
+
+	return f.licenseFields, nil
+}
+
+func (f *fakeVendorAPI) CreateCluster(opts kotsclient.CreateClusterOpts) (*rtypes.Cluster, *kotsclient.CreateClusterErrorError, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.lastCreateClusterOpts = &opts
+
+	if err := f.errs["CreateCluster"]; err != nil {
+		return nil, nil, err
+	}
+	if f.clusterValidationError != nil {
+		return nil, f.clusterValidationError, nil
+	}
+
+	status := f.clusterStatus
+	if status == "" {
+		status = rtypes.ClusterStatusRunning
+	}
+
+	name := opts.Name
+	if name == "" {
+		name = f.id("cluster-name-")
+	}
+	version := opts.KubernetesVersion
+	if version == "" {
+		version = "1.30.0"
+	}
+	nodeCount := opts.NodeCount
+	if nodeCount == 0 {
+		nodeCount = 1
+	}
+	diskGiB := opts.DiskGiB
+	if diskGiB == 0 {
+		diskGiB = 50
+	}
+	instanceType := opts.InstanceType
+	if instanceType == "" {
+		instanceType = "r1.small"
+	}
+
+	cluster := &rtypes.Cluster{
+		ID:                     f.id("cluster-"),
+		Name:                   name,
+		KubernetesDistribution: opts.KubernetesDistribution,
+		KubernetesVersion:      version,
+		Status:                 status,
+		TTL:                    opts.TTL,
+		NodeGroups: []*rtypes.NodeGroup{
+			{
+				InstanceType: instanceType,
+				NodeCount:    nodeCount,
+				DiskGiB:      diskGiB,
+			},
+		},
+	}
+	f.clusters[cluster.ID] = cluster
+	f.kubeconfigs[cluster.ID] = []byte("kubeconfig-" + cluster.ID)
+
+	c := *cluster
+	return &c, nil, nil
+}
+
+func (f *fakeVendorAPI) ListClusters(includeTerminated bool, startTime *time.Time, endTime *time.Time) ([]*rtypes.Cluster, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["ListClusters"]; err != nil {
+		return nil, err
+	}
+
+	clusters := []*rtypes.Cluster{}
+	for _, cluster := range f.clusters {
+		c := *cluster
+		clusters = append(clusters, &c)
+	}
+
+	return clusters, nil
+}
+
+func (f *fakeVendorAPI) GetCluster(id string) (*rtypes.Cluster, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["GetCluster"]; err != nil {
+		return nil, err
+	}
+
+	cluster, ok := f.clusters[id]
+	if !ok {
+		return nil, platformclient.ErrNotFound
+	}
+
+	c := *cluster
+	return &c, nil
+}
+
+func (f *fakeVendorAPI) GetClusterKubeconfig(id string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["GetClusterKubeconfig"]; err != nil {
+		return nil, err
+	}
+
+	kubeconfig, ok := f.kubeconfigs[id]
+	if !ok {
+		return nil, platformclient.ErrNotFound
+	}
+
+	return kubeconfig, nil
+}
+
+func (f *fakeVendorAPI) RemoveCluster(id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["RemoveCluster"]; err != nil {
+		return err
+	}
+
+	if _, ok := f.clusters[id]; !ok {
+		return platformclient.ErrNotFound
+	}
+
+	delete(f.clusters, id)
+	delete(f.kubeconfigs, id)
+	return nil
+}
+
+func (f *fakeVendorAPI) CreateCustomer(opts CreateCustomerOpts) (*rtypes.Customer, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.lastCreateCustomerOpts = &opts
+
+	if err := f.errs["CreateCustomer"]; err != nil {
+		return nil, err
+	}
+
+	customer := &rtypes.Customer{
+		ID:                               f.id("customer-"),
+		Name:                             opts.Name,
+		CustomID:                         opts.CustomID,
+		Email:                            opts.Email,
+		Type:                             opts.LicenseType,
+		InstallationID:                   f.id("license-"),
+		IsAirgapEnabled:                  opts.IsAirgapEnabled,
+		IsEmbeddedClusterDownloadEnabled: opts.IsEmbeddedClusterDownloadEnabled,
+		IsGeoaxisSupported:               opts.IsGeoaxisSupported,
+		IsHelmVMDownloadEnabled:          opts.IsHelmVMDownloadEnabled,
+		IsIdentityServiceSupported:       opts.IsIdentityServiceSupported,
+		IsInstallerSupportEnabled:        opts.IsInstallerSupportEnabled,
+		IsKotsInstallEnabled:             opts.IsKotsInstallEnabled,
+		IsSnapshotSupported:              opts.IsSnapshotSupported,
+		IsSupportBundleUploadEnabled:     opts.IsSupportBundleUploadEnabled,
+		IsGitopsSupported:                opts.IsGitopsSupported,
+	}
+	if err := f.applyCustomerValues(customer, opts.Channels, opts.EntitlementValues, opts.ExpiresAt); err != nil {
+		return nil, err
+	}
+	f.customers[customer.ID] = customer
+	f.customerApps[customer.ID] = opts.AppID
+	details := customerDetailsFromOpts(opts.Channels, opts.CustomerLicenseOptions)
+	details.CreatedAt = &util.Time{Time: fakeNow}
+	details.UpdatedAt = &util.Time{Time: fakeNow}
+	f.details[customer.ID] = details
+
+	c := *customer
+	return &c, nil
+}
+
+func (f *fakeVendorAPI) ListCustomers(appID string, includeTest bool) ([]rtypes.Customer, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["ListCustomers"]; err != nil {
+		return nil, err
+	}
+
+	customers := []rtypes.Customer{}
+	for id, customer := range f.customers {
+		if f.archived[id] || (customer.Type == "test" && !includeTest) {
+			continue
+		}
+		customers = append(customers, *customer)
+	}
+
+	return customers, nil
+}
+
+func (f *fakeVendorAPI) GetCustomerByNameOrId(appID string, nameOrId string) (*rtypes.Customer, error) {
+	f.mu.Lock()
+	err := f.errs["GetCustomerByNameOrId"]
+	f.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// like vendorAPIClient, the lookup includes test customers
+	customers, err := f.ListCustomers(appID, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, customer := range customers {
+		if customer.ID == nameOrId || customer.Name == nameOrId {
+			return &customer, nil
+		}
+	}
+
+	return nil, kotsclient.ErrCustomerNotFound{Name: nameOrId}
+}
+
+func (f *fakeVendorAPI) UpdateCustomer(customerID string, opts UpdateCustomerOpts) (*rtypes.Customer, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.lastUpdateCustomerOpts = &opts
+
+	if err := f.errs["UpdateCustomer"]; err != nil {
+		return nil, err
+	}
+
+	customer, ok := f.customers[customerID]
+	if !ok || f.archived[customerID] {
+		return nil, platformclient.ErrNotFound
+	}
+
+	// like the vendor api, the update replaces every field of the customer
+	customer.Name = opts.Name
+	customer.CustomID = opts.CustomID
+	customer.Email = opts.Email
+	customer.Type = opts.LicenseType
+	customer.IsAirgapEnabled = opts.IsAirgapEnabled
+	customer.IsEmbeddedClusterDownloadEnabled = opts.IsEmbeddedClusterDownloadEnabled
+	customer.IsGeoaxisSupported = opts.IsGeoaxisSupported
+	customer.IsHelmVMDownloadEnabled = opts.IsHelmVMDownloadEnabled
+	customer.IsIdentityServiceSupported = opts.IsIdentityServiceSupported
+	customer.IsInstallerSupportEnabled = opts.IsInstallerSupportEnabled
+	customer.IsKotsInstallEnabled = opts.IsKotsInstallEnabled
+	customer.IsSnapshotSupported = opts.IsSnapshotSupported
+	customer.IsSupportBundleUploadEnabled = opts.IsSupportBundleUploadEnabled
+	customer.IsGitopsSupported = opts.IsGitopsSupported
+	if err := f.applyCustomerValues(customer, opts.Channels, opts.EntitlementValues, opts.ExpiresAt); err != nil {
+		return nil, err
+	}
+	details := customerDetailsFromOpts(opts.Channels, opts.CustomerLicenseOptions)
+	details.CreatedAt = f.details[customerID].CreatedAt
+	details.UpdatedAt = &util.Time{Time: fakeNow.Add(time.Hour)}
+	f.details[customerID] = details
+
+	c := *customer
+	return &c, nil
+}
+
+func (f *fakeVendorAPI) GetCustomer(customerID string) (*rtypes.Customer, string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["GetCustomer"]; err != nil {
+		return nil, "", err
+	}
+
+	customer, ok := f.customers[customerID]
+	if !ok || f.archived[customerID] {
+		return nil, "", kotsclient.ErrCustomerNotFound{Name: customerID}
+	}
+
+	c := *customer
+	return &c, f.customerApps[customerID], nil
+}
+
+func (f *fakeVendorAPI) GetCustomerDetails(customerID string) (*CustomerDetails, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["GetCustomerDetails"]; err != nil {
+		return nil, err
+	}
+
+	details, ok := f.details[customerID]
+	if !ok {
+		return nil, platformclient.ErrNotFound
+	}
+
+	d := *details
+	d.Channels = append([]CustomerChannelDetails{}, details.Channels...)
+	return &d, nil
+}
+
+// testCustomerChannelDetails returns the channel settings the vendor api
+// stores for the channels of a customer create or update request.
+func (f *fakeVendorAPI) ArchiveCustomer(customerID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["ArchiveCustomer"]; err != nil {
+		return err
+	}
+
+	if _, ok := f.customers[customerID]; !ok {
+		return platformclient.ErrNotFound
+	}
+
+	f.archived[customerID] = true
+	return nil
+}
+
+func (f *fakeVendorAPI) ListArchivedCustomers(appID string) ([]rtypes.Customer, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["ListArchivedCustomers"]; err != nil {
+		return nil, err
+	}
+
+	customers := []rtypes.Customer{}
+	for id, customer := range f.customers {
+		if f.archived[id] {
+			customers = append(customers, *customer)
+		}
+	}
+
+	return customers, nil
+}
+
+func (f *fakeVendorAPI) ListCustomersWithDetails(appID string, includeArchived bool) ([]CustomerWithDetails, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["ListCustomersWithDetails"]; err != nil {
+		return nil, err
+	}
+
+	customers := []CustomerWithDetails{}
+	for id, customer := range f.customers {
+		if f.archived[id] && !includeArchived {
+			continue
+		}
+		details := *f.details[id]
+		details.Channels = append([]CustomerChannelDetails{}, details.Channels...)
+		customers = append(customers, CustomerWithDetails{Customer: *customer, Details: details, Archived: f.archived[id]})
+	}
+
+	return customers, nil
+}
+
+func (f *fakeVendorAPI) UnarchiveCustomer(customerID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["UnarchiveCustomer"]; err != nil {
+		return err
+	}
+
+	if _, ok := f.customers[customerID]; !ok {
+		return platformclient.ErrNotFound
+	}
+
+	delete(f.archived, customerID)
+	return nil
+}
+
+func (f *fakeVendorAPI) DownloadLicense(appID string, customerID string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["DownloadLicense"]; err != nil {
+		return nil, err
+	}
+
+	customer, ok := f.customers[customerID]
+	if !ok || f.archived[customerID] {
+		return nil, platformclient.ErrNotFound
+	}
+
+	return testLicenseYAML(customer), nil
+}
+
+// testLicenseYAML returns a license file for customer, shaped like the ones
+// served by the vendor api.
+func testLicenseYAML(customer *rtypes.Customer) []byte {
+	channelID, channelName := "", ""
+	if len(customer.Channels) > 0 {
+		channelID, channelName = customer.Channels[0].ID, customer.Channels[0].Name
+	}
+
+	expiresAt := ""
+	if customer.Expires != nil {
+		expiresAt = customer.Expires.Format(time.RFC3339)
+	}
+
+	return []byte(fmt.Sprintf(`apiVersion: kots.io/v1beta1
+kind: License
+metadata:
+  name: %[1]s
+spec:
+  licenseID: %[2]s
+  licenseType: %[3]s
+  customerName: %[1]s
+  channelID: %[4]q
+  channelName: %[5]q
+  entitlements:
+    expires_at:
+      title: Expiration
+      value: %[6]q
+      valueType: String
+  signature: c2lnbmF0dXJl
+`, customer.Name, customer.InstallationID, customer.Type, channelID, channelName, expiresAt))
+}
+
+func (f *fakeVendorAPI) applyCustomerValues(customer *rtypes.Customer, channels []kotsclient.CustomerChannel, entitlementValues []kotsclient.EntitlementValue, expiresAt string) error {
+	customer.Channels = nil
+	for _, channel := range channels {
+		customer.Channels = append(customer.Channels, rtypes.Channel{ID: channel.ID})
+	}
+
+	customer.Entitlements = nil
+	set := map[string]bool{}
+	for _, value := range entitlementValues {
+		customer.Entitlements = append(customer.Entitlements, rtypes.Entitlement{Name: value.Name, Value: value.Value})
+		set[value.Name] = true
+	}
+	// like the vendor api, fields without a value fall back to their default
+	for _, field := range f.licenseFields {
+		if !set[field.Name] {
+			customer.Entitlements = append(customer.Entitlements, rtypes.Entitlement{Name: field.Name, Value: field.Default, IsDefault: true})
+		}
+	}
+
+	customer.Expires = nil
+	if expiresAt != "" {
+		expires, err := util.ParseTime(expiresAt)
+		if err != nil {
+			return err
+		}
+		customer.Expires = &util.Time{Time: expires}
+	}
+
+	return nil
+}
+
+func (f *fakeVendorAPI) CreateChannel(appID string, name string, description string) (*rtypes.Channel, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["CreateChannel"]; err != nil {
+		return nil, err
+	}
+
+	channel := &AppChannel{
+		ID:          f.id("channel-"),
+		AppID:       appID,
+		Name:        name,
+		Description: description,
+		Slug:        strings.ReplaceAll(strings.ToLower(name), " ", "-"),
+	}
+	f.channels[channel.ID] = channel
+
+	return &rtypes.Channel{ID: channel.ID, Name: channel.Name, Description: channel.Description, Slug: channel.Slug}, nil
+}
+
+func (f *fakeVendorAPI) GetAppChannel(appID string, channelID string) (*AppChannel, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["GetAppChannel"]; err != nil {
+		return nil, err
+	}
+
+	channel, ok := f.channels[channelID]
+	if !ok || channel.AppID != appID {
+		return nil, platformclient.ErrNotFound
+	}
+
+	c := *channel
+	return &c, nil
+}
+
+func (f *fakeVendorAPI) ListAppChannels(appID string) ([]AppChannel, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["ListAppChannels"]; err != nil {
+		return nil, err
+	}
+
+	channels := []AppChannel{}
+	for _, channel := range f.channels {
+		if channel.AppID == appID && !channel.IsArchived {
+			channels = append(channels, *channel)
+		}
+	}
+
+	return channels, nil
+}
+
+func (f *fakeVendorAPI) UpdateAppChannel(appID string, channelID string, opts UpdateChannelOpts) (*AppChannel, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.lastUpdateChannelOpts = &opts
+
+	if err := f.errs["UpdateAppChannel"]; err != nil {
+		return nil, err
+	}
+
+	channel, ok := f.channels[channelID]
+	if !ok || channel.AppID != appID || channel.IsArchived {
+		return nil, platformclient.ErrNotFound
+	}
+
+	channel.Name = opts.Name
+	channel.Description = opts.Description
+	channel.SemverRequired = opts.SemverRequired
+	channel.BuildAirgapAutomatically = opts.BuildAirgapAutomatically
+	channel.CustomHostNameOverrides = opts.CustomHostNameOverrides
+
+	c := *channel
+	return &c, nil
+}
+
+func (f *fakeVendorAPI) SetDefaultChannel(appID string, channelID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["SetDefaultChannel"]; err != nil {
+		return err
+	}
+
+	channel, ok := f.channels[channelID]
+	if !ok || channel.AppID != appID || channel.IsArchived {
+		return platformclient.ErrNotFound
+	}
+
+	for _, other := range f.channels {
+		if other.AppID == appID {
+			other.IsDefault = false
+		}
+	}
+	channel.IsDefault = true
+
+	return nil
+}
+
+func (f *fakeVendorAPI) ArchiveChannel(appID string, channelID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["ArchiveChannel"]; err != nil {
+		return err
+	}
+
+	channel, ok := f.channels[channelID]
+	if !ok || channel.AppID != appID {
+		return platformclient.ErrNotFound
+	}
+	if channel.IsDefault {
+		return errors.New("the default channel cannot be archived")
+	}
+
+	channel.IsArchived = true
+	return nil
+}
+
+func (f *fakeVendorAPI) CreateRelease(appID string, multiyaml string) (*rtypes.ReleaseInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["CreateRelease"]; err != nil {
+		return nil, err
+	}
+
+	release := &rtypes.KotsAppRelease{
+		AppID:     appID,
+		Sequence:  int64(len(f.releases[appID]) + 1),
+		CreatedAt: fakeNow,
+		Spec:      multiyaml,
+	}
+	f.releases[appID] = append(f.releases[appID], release)
+
+	return &rtypes.ReleaseInfo{AppID: appID, Sequence: release.Sequence, CreatedAt: release.CreatedAt}, nil
+}
+
+func (f *fakeVendorAPI) GetRelease(appID string, sequence int64) (*rtypes.AppRelease, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["GetRelease"]; err != nil {
+		return nil, err
+	}
+
+	releases := f.releases[appID]
+	if sequence < 1 || sequence > int64(len(releases)) {
+		return nil, platformclient.ErrNotFound
+	}
+
+	release := releases[sequence-1]
+	return &rtypes.AppRelease{Config: release.Spec, CreatedAt: release.CreatedAt, Sequence: release.Sequence}, nil
+}
+
+func (f *fakeVendorAPI) PromoteRelease(appID string, sequence int64, label string, notes string, required bool, channelIDs ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["PromoteRelease"]; err != nil {
+		return err
+	}
+
+	if sequence < 1 || sequence > int64(len(f.releases[appID])) {
+		return platformclient.ErrNotFound
+	}
+	for _, channelID := range channelIDs {
+		channel, ok := f.channels[channelID]
+		if !ok || channel.AppID != appID || channel.IsArchived {
+			return platformclient.ErrNotFound
+		}
+	}
+
+	for _, channelID := range channelIDs {
+		f.channelReleases[channelID] = append(f.channelReleases[channelID], &AppChannelRelease{
+			ChannelSequence: int64(len(f.channelReleases[channelID])),
+			Sequence:        sequence,
+			Semver:          label,
+			ReleaseNotes:    notes,
+			Created:         fakeNow,
+			IsRequired:      required,
+		})
+		f.channels[channelID].ReleaseSequence = sequence
+		f.channels[channelID].CurrentVersion = label
+	}
+
+	return nil
+}
+
+func (f *fakeVendorAPI) ListChannelReleases(appID string, channelID string) ([]AppChannelRelease, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["ListChannelReleases"]; err != nil {
+		return nil, err
+	}
+
+	channel, ok := f.channels[channelID]
+	if !ok || channel.AppID != appID {
+		return nil, platformclient.ErrNotFound
+	}
+
+	releases := []AppChannelRelease{}
+	for _, release := range f.channelReleases[channelID] {
+		releases = append(releases, *release)
+		if queued, ok := f.queuedAirgapBuilds[release]; ok {
+			if queued <= 1 {
+				delete(f.queuedAirgapBuilds, release)
+				release.AirgapBuildStatus = "building"
+				release.AirgapBuildError = ""
+			} else {
+				f.queuedAirgapBuilds[release] = queued - 1
+			}
+			continue
+		}
+		if release.AirgapBuildStatus == "building" {
+			release.AirgapBuildStatus = f.airgapBuildStatus
+			if release.AirgapBuildStatus == "" {
+				release.AirgapBuildStatus = "built"
+			}
+			if release.AirgapBuildStatus == "failed" {
+				release.AirgapBuildError = "build failed"
+			}
+		}
+	}
+
+	return releases, nil
+}
+
+func (f *fakeVendorAPI) DemoteChannelRelease(appID string, channelID string, channelSequence int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["DemoteChannelRelease"]; err != nil {
+		return err
+	}
+
+	channel, ok := f.channels[channelID]
+	if !ok || channel.AppID != appID {
+		return platformclient.ErrNotFound
+	}
+	releases := f.channelReleases[channelID]
+	if channelSequence < 0 || channelSequence >= int64(len(releases)) {
+		return platformclient.ErrNotFound
+	}
+
+	releases[channelSequence].IsDemoted = true
+	return nil
+}
+
+func (f *fakeVendorAPI) LintRelease(data []byte, isBuildersRelease bool, contentType string) ([]rtypes.LintMessage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["LintRelease"]; err != nil {
+		return nil, err
+	}
+
+	f.lastLintData = data
+	f.lastLintBuilders = isBuildersRelease
+	return f.lintMessages, nil
+}
+
+func (f *fakeVendorAPI) BuildAirgapRelease(appID string, channelID string, channelSequence int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["BuildAirgapRelease"]; err != nil {
+		return err
+	}
+
+	release, err := f.channelRelease(appID, channelID, channelSequence)
+	if err != nil {
+		return err
+	}
+
+	if f.airgapBuildQueuedListings > 0 {
+		f.queuedAirgapBuilds[release] = f.airgapBuildQueuedListings
+		return nil
+	}
+
+	release.AirgapBuildStatus = "building"
+	release.AirgapBuildError = ""
+	return nil
+}
+
+func (f *fakeVendorAPI) GetAirgapDownloadURL(appID string, customerID string, channelID string, channelSequence int64) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["GetAirgapDownloadURL"]; err != nil {
+		return "", err
+	}
+
+	customer, ok := f.customers[customerID]
+	if !ok || f.archived[customerID] || !customer.IsAirgapEnabled {
+		return "", platformclient.ErrNotFound
+	}
+	release, err := f.channelRelease(appID, channelID, channelSequence)
+	if err != nil {
+		return "", err
+	}
+	if release.AirgapBuildStatus != "built" {
+		return "", platformclient.ErrNotFound
+	}
+
+	return fmt.Sprintf("https://airgap.example.com/%s/%s/%d?customer=%s", appID, channelID, channelSequence, customerID), nil
+}
+
+// channelRelease returns the promotion with the channel sequence, f.mu must be
+// held.
+func (f *fakeVendorAPI) channelRelease(appID string, channelID string, channelSequence int64) (*AppChannelRelease, error) {
+	channel, ok := f.channels[channelID]
+	if !ok || channel.AppID != appID {
+		return nil, platformclient.ErrNotFound
+	}
+	releases := f.channelReleases[channelID]
+	if channelSequence < 0 || channelSequence >= int64(len(releases)) {
+		return nil, platformclient.ErrNotFound
+	}
+
+	return releases[channelSequence], nil
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// vendorTransports routes the requests of kotsclient.VendorV3Client, which
+// always sends through http.DefaultClient, to the http client of the provider
+// instance that made them.
+var vendorTransports = &vendorTransportRouter{clients: map[string]*http.Client{}}
+
+// vendorTransportRouter is installed as the transport of http.DefaultClient.
+// Each provider instance registers its http client and gets an api origin
+// whose user name identifies it. Requests to such an origin are sent with the
+// user name removed through the registered client; any other request goes to
+// the transport http.DefaultClient had before.
+type vendorTransportRouter struct {
+	install sync.Once
+	next    http.RoundTripper
+
+	mu      sync.RWMutex
+	clients map[string]*http.Client
+}
+
+// register returns the api origin to give to the vendor api client so that
+// its requests are sent through client.
+func (r *vendorTransportRouter) register(apiOrigin string, client *http.Client) string {
+	r.install.Do(func() {
+		r.next = http.DefaultClient.Transport
+		if r.next == nil {
+			r.next = http.DefaultTransport
+		}
+		http.DefaultClient.Transport = r
+	})
+
+	u, err := url.Parse(apiOrigin)
+	if err != nil {
+		// the request will fail to be created with the same error
+		return apiOrigin
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := fmt.Sprintf("provider-%d", len(r.clients)+1)
+	r.clients[key] = client
+	u.User = url.User(key)
+
+	return u.String()
+}
+
+func (r *vendorTransportRouter) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.User == nil {
+		return r.next.RoundTrip(req)
+	}
+
+	r.mu.RLock()
+	key := req.URL.User.Username()
+	client, ok := r.clients[key]
+	r.mu.RUnlock()
+	if !ok {
+		return r.next.RoundTrip(req)
+	}
+
+	req = req.Clone(req.Context())
+	req.URL.User = nil
+	// http.Client sends the user name as basic auth when the request has no
+	// authorization header of its own
+	if user, pass, ok := req.BasicAuth(); ok && user == key && pass == "" {
+		req.Header.Del("Authorization")
+	}
+
+	return client.Do(req)
+}
@@ -0,0 +1,1283 @@
+package provider
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/replicatedhq/replicated/pkg/kotsclient"
+	"github.com/replicatedhq/replicated/pkg/platformclient"
+	rtypes "github.com/replicatedhq/replicated/pkg/types"
+	"github.com/replicatedhq/replicated/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	testAccAppID     = "2fvVIbMQtNBwMzeTJt2yJrEKEFN"
+	testAccAppSlug   = "terraform-provider-acc"
+	testAccChannelID = "2fvVIfi3WTTAt3GpiKP8Fz86WuA"
+)
+
+// testAccVendorAPI points the provider at a mockVendorAPIServer unless
+// REPLICATED_API_TOKEN is set, in which case acceptance tests run against the
+// real vendor api. The returned server is nil when running against the real
+// api.
+func testAccVendorAPI(t *testing.T) *mockVendorAPIServer {
+	t.Helper()
+
+	if os.Getenv("REPLICATED_API_TOKEN") != "" {
+		return nil
+	}
+
+	server := newMockVendorAPIServer()
+	t.Cleanup(server.Close)
+
+	t.Setenv("REPLICATED_API_ORIGIN", server.URL)
+	t.Setenv("REPLICATED_API_TOKEN", "mock-api-token")
+	t.Setenv("LINTER_API_ORIGIN", server.URL)
+
+	return server
+}
+
+// mockVendorAPIServer is a stateful stand-in for the Vendor v3 endpoints used
+// by the provider. It is seeded with the app and channel referenced by the
+// acceptance test configurations, and errors can be scripted per endpoint
+// with injectError.
+type mockVendorAPIServer struct {
+	*httptest.Server
+
+	mu sync.Mutex
+
+	apps          []*rtypes.App
+	licenseFields map[string][]LicenseField
+	channels      map[string]*mockChannel
+	releases      map[string][]*rtypes.KotsAppRelease
+	// channelReleases holds the promotions of each channel
+	channelReleases map[string][]*AppChannelRelease
+	clusters        map[string]*rtypes.Cluster
+	customers       map[string]*mockCustomer
+	injected        []*mockInjectedError
+
+	nextID int
+}
+
+// mockChannel is a channel with the settings rtypes.KotsChannel does not
+// carry.
+type mockChannel struct {
+	rtypes.KotsChannel
+	SemverRequired bool `json:"semverRequired"`
+}
+
+type mockCustomer struct {
+	appID      string
+	archived   bool
+	customer   rtypes.Customer
+	channels   []CustomerChannelDetails
+	options    CustomerLicenseOptions
+	createdAt  time.Time
+	updatedAt  time.Time
+	archivedAt time.Time
+}
+
+// mockCustomerJSON is a customer as the vendor api returns it, with the
+// fields rtypes.Customer does not decode. Archived customers carry their
+// archive time.
+type mockCustomerJSON struct {
+	rtypes.Customer
+	AppID                             string                    `json:"appId"`
+	Channels                          []mockCustomerChannelJSON `json:"channels"`
+	IsHelmInstallEnabled              bool                      `json:"isHelmInstallEnabled"`
+	IsDisasterRecoverySupported       bool                      `json:"isDisasterRecoverySupported"`
+	IsDevModeEnabled                  bool                      `json:"isDevModeEnabled"`
+	IsEmbeddedClusterMultiNodeEnabled bool                      `json:"isEmbeddedClusterMultiNodeEnabled"`
+	IsKurlInstallEnabled              bool                      `json:"isKurlInstallEnabled"`
+	CreatedAt                         time.Time                 `json:"createdAt"`
+	UpdatedAt                         time.Time                 `json:"updatedAt"`
+	ArchivedAt                        *time.Time                `json:"archivedAt"`
+}
+
+type mockCustomerChannelJSON struct {
+	rtypes.Channel
+	IsDefaultForCustomer  bool   `json:"isDefaultForCustomer"`
+	PinnedChannelSequence *int64 `json:"pinnedChannelSequence"`
+}
+
+func (c *mockCustomer) json() mockCustomerJSON {
+	customer := mockCustomerJSON{
+		Customer:                          c.customer,
+		AppID:                             c.appID,
+		Channels:                          []mockCustomerChannelJSON{},
+		IsHelmInstallEnabled:              c.options.IsHelmInstallEnabled,
+		IsDisasterRecoverySupported:       c.options.IsDisasterRecoverySupported,
+		IsDevModeEnabled:                  c.options.IsDevModeEnabled,
+		IsEmbeddedClusterMultiNodeEnabled: c.options.IsEmbeddedClusterMultiNodeEnabled,
+		IsKurlInstallEnabled:              c.options.IsKurlInstallEnabled,
+		CreatedAt:                         c.createdAt,
+		UpdatedAt:                         c.updatedAt,
+	}
+	for i, channel := range c.customer.Channels {
+		customer.Channels = append(customer.Channels, mockCustomerChannelJSON{
+			Channel:               channel,
+			IsDefaultForCustomer:  c.channels[i].IsDefault,
+			PinnedChannelSequence: c.channels[i].PinnedChannelSequence,
+		})
+	}
+	if c.archived {
+		archivedAt := c.archivedAt
+		customer.ArchivedAt = &archivedAt
+	}
+	return customer
+}
+
+type mockInjectedError struct {
+	method     string
+	pathPrefix string
+	status     int
+	message    string
+	remaining  int
+}
+
+func newMockVendorAPIServer() *mockVendorAPIServer {
+	s := &mockVendorAPIServer{
+		apps: []*rtypes.App{
+			{ID: testAccAppID, Name: "Terraform Provider Acceptance", Slug: testAccAppSlug, Scheduler: "kots"},
+		},
+		licenseFields: map[string][]LicenseField{
+			testAccAppID: {
+				{Name: "testEntitlement", Title: "Test Entitlement", Type: "String"},
+				{Name: "seats", Title: "Seats", Type: "Integer", Default: "5"},
+			},
+		},
+		channels: map[string]*mockChannel{
+			testAccChannelID: {KotsChannel: rtypes.KotsChannel{Id: testAccChannelID, AppId: testAccAppID, Name: "Stable", ChannelSlug: "stable", IsDefault: true}},
+		},
+		releases:        map[string][]*rtypes.KotsAppRelease{},
+		channelReleases: map[string][]*AppChannelRelease{},
+		clusters:        map[string]*rtypes.Cluster{},
+		customers:       map[string]*mockCustomer{},
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /v3/apps", s.listApps)
+	mux.HandleFunc("POST /v3/app", s.createApp)
+	mux.HandleFunc("DELETE /v3/app/{appID}", s.deleteApp)
+
+	mux.HandleFunc("GET /v3/app/{appID}/license-fields", s.listLicenseFields)
+
+	mux.HandleFunc("GET /v3/app/{appID}/channels", s.listChannels)
+	mux.HandleFunc("POST /v3/app/{appID}/channel", s.createChannel)
+	mux.HandleFunc("GET /v3/app/{appID}/channel/{channelID}", s.getChannel)
+	mux.HandleFunc("PUT /v3/app/{appID}/channel/{channelID}", s.updateChannel)
+	mux.HandleFunc("PUT /v3/app/{appID}/channel/{channelID}/default", s.setDefaultChannel)
+	mux.HandleFunc("DELETE /v3/app/{appID}/channel/{channelID}", s.archiveChannel)
+
+	mux.HandleFunc("POST /v3/app/{appID}/release", s.createRelease)
+	mux.HandleFunc("GET /v3/app/{appID}/release/{sequence}", s.getRelease)
+	mux.HandleFunc("POST /v3/app/{appID}/release/{sequence}/promote", s.promoteRelease)
+	mux.HandleFunc("GET /v3/app/{appID}/channel/{channelID}/releases", s.listChannelReleases)
+	mux.HandleFunc("POST /v3/app/{appID}/channel/{channelID}/release/{channelSequence}/demote", s.demoteChannelRelease)
+	mux.HandleFunc("POST /v3/app/{appID}/channel/{channelID}/release/{channelSequence}/airgap/build", s.buildAirgapRelease)
+
+	mux.HandleFunc("POST /v1/lint", s.lintRelease)
+	mux.HandleFunc("POST /v1/builders-lint", s.lintRelease)
+
+	mux.HandleFunc("POST /v3/cluster", s.createCluster)
+	mux.HandleFunc("GET /v3/clusters", s.listClusters)
+	mux.HandleFunc("GET /v3/cluster/{id}", s.getCluster)
+	mux.HandleFunc("GET /v3/cluster/{id}/kubeconfig", s.getClusterKubeconfig)
+	mux.HandleFunc("DELETE /v3/cluster/{id}", s.removeCluster)
+
+	mux.HandleFunc("POST /v3/customer", s.createCustomer)
+	mux.HandleFunc("GET /v3/app/{appID}/customers", s.listCustomers)
+	mux.HandleFunc("GET /v3/customer/{id}", s.getCustomer)
+	mux.HandleFunc("PUT /v3/customer/{id}", s.updateCustomer)
+	mux.HandleFunc("POST /v3/customer/{id}/archive", s.archiveCustomer)
+	mux.HandleFunc("POST /v3/customer/{id}/unarchive", s.unarchiveCustomer)
+	mux.HandleFunc("GET /v3/app/{appID}/customer/{id}/license-download", s.downloadLicense)
+	mux.HandleFunc("GET /v3/app/{appID}/customer/{id}/airgap/download-url", s.getAirgapDownloadURL)
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the linter does not authenticate requests
+		if r.Header.Get("Authorization") == "" && !strings.HasPrefix(r.URL.Path, "/v1/") {
+			writeMockJSON(w, http.StatusUnauthorized, map[string]string{"message": "missing api token"})
+			return
+		}
+		if s.takeInjectedError(w, r) {
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+
+	return s
+}
+
+// injectError makes the next count requests whose method matches and whose
+// path starts with pathPrefix fail with status and message. A count of zero
+// or less fails every matching request.
+func (s *mockVendorAPIServer) injectError(method string, pathPrefix string, status int, message string, count int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.injected = append(s.injected, &mockInjectedError{
+		method:     method,
+		pathPrefix: pathPrefix,
+		status:     status,
+		message:    message,
+		remaining:  count,
+	})
+}
+
+func (s *mockVendorAPIServer) takeInjectedError(w http.ResponseWriter, r *http.Request) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, e := range s.injected {
+		if e.method != r.Method || !strings.HasPrefix(r.URL.Path, e.pathPrefix) {
+			continue
+		}
+		if e.remaining > 0 {
+			e.remaining--
+			if e.remaining == 0 {
+				s.injected = append(s.injected[:i], s.injected[i+1:]...)
+			}
+		}
+		writeMockJSON(w, e.status, map[string]string{"message": e.message})
+		return true
+	}
+
+	return false
+}
+
+func (s *mockVendorAPIServer) id() string {
+	s.nextID++
+	return fmt.Sprintf("mock%023d", s.nextID)
+}
+
+func (s *mockVendorAPIServer) listApps(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	apps := []rtypes.KotsAppWithChannels{}
+	for _, app := range s.apps {
+		apps = append(apps, rtypes.KotsAppWithChannels{Id: app.ID, Name: app.Name, Slug: app.Slug, IsKotsApp: true})
+	}
+
+	writeMockJSON(w, http.StatusOK, map[string]interface{}{"apps": apps})
+}
+
+func (s *mockVendorAPIServer) createApp(w http.ResponseWriter, r *http.Request) {
+	var req kotsclient.CreateKOTSAppRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeMockJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	app := &rtypes.App{
+		ID:        s.id(),
+		Name:      req.Name,
+		Slug:      strings.ToLower(strings.ReplaceAll(req.Name, " ", "-")),
+		Scheduler: "kots",
+	}
+	s.apps = append(s.apps, app)
+	s.licenseFields[app.ID] = []LicenseField{}
+
+	writeMockJSON(w, http.StatusCreated, kotsclient.CreateKOTSAppResponse{
+		App: &rtypes.KotsAppWithChannels{Id: app.ID, Name: app.Name, Slug: app.Slug, IsKotsApp: true, Created: time.Now().UTC()},
+	})
+}
+
+func (s *mockVendorAPIServer) deleteApp(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, app := range s.apps {
+		if app.ID == r.PathValue("appID") {
+			s.apps = append(s.apps[:i], s.apps[i+1:]...)
+			delete(s.licenseFields, app.ID)
+			writeMockJSON(w, http.StatusOK, map[string]string{})
+			return
+		}
+	}
+
+	writeMockJSON(w, http.StatusNotFound, map[string]string{"message": "app not found"})
+}
+
+func (s *mockVendorAPIServer) listLicenseFields(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fields, ok := s.licenseFields[r.PathValue("appID")]
+	if !ok {
+		writeMockJSON(w, http.StatusNotFound, map[string]string{"message": "app not found"})
+		return
+	}
+
+	writeMockJSON(w, http.StatusOK, fields)
+}
+
+func (s *mockVendorAPIServer) listChannels(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	channelName := r.URL.Query().Get("channelName")
+	channels := []*mockChannel{}
+	for _, channel := range s.channels {
+		if channel.AppId != r.PathValue("appID") || channel.IsArchived {
+			continue
+		}
+		if channelName != "" && channel.Name != channelName {
+			continue
+		}
+		channels = append(channels, channel)
+	}
+
+	writeMockJSON(w, http.StatusOK, map[string]interface{}{"channels": channels})
+}
+
+func (s *mockVendorAPIServer) createChannel(w http.ResponseWriter, r *http.Request) {
+	var req rtypes.CreateChannelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeMockJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	channel := &mockChannel{KotsChannel: rtypes.KotsChannel{
+		Id:          s.id(),
+		AppId:       r.PathValue("appID"),
+		Name:        req.Name,
+		Description: req.Description,
+		ChannelSlug: strings.ToLower(strings.ReplaceAll(req.Name, " ", "-")),
+		Created:     time.Now().UTC(),
+	}}
+	s.channels[channel.Id] = channel
+
+	writeMockJSON(w, http.StatusCreated, map[string]interface{}{"channel": channel})
+}
+
+func (s *mockVendorAPIServer) getChannel(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	channel, ok := s.channels[r.PathValue("channelID")]
+	if !ok || channel.AppId != r.PathValue("appID") {
+		writeMockJSON(w, http.StatusNotFound, map[string]string{"message": "channel not found"})
+		return
+	}
+
+	writeMockJSON(w, http.StatusOK, map[string]interface{}{"channel": channel})
+}
+
+func (s *mockVendorAPIServer) updateChannel(w http.ResponseWriter, r *http.Request) {
+	var req UpdateChannelOpts
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeMockJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	channel, ok := s.channels[r.PathValue("channelID")]
+	if !ok || channel.AppId != r.PathValue("appID") || channel.IsArchived {
+		writeMockJSON(w, http.StatusNotFound, map[string]string{"message": "channel not found"})
+		return
+	}
+
+	channel.Name = req.Name
+	channel.Description = req.Description
+	channel.SemverRequired = req.SemverRequired
+	channel.BuildAirgapAutomatically = req.BuildAirgapAutomatically
+	channel.CustomHostNameOverrides = req.CustomHostNameOverrides
+	channel.Updated = time.Now().UTC()
+
+	writeMockJSON(w, http.StatusOK, map[string]interface{}{"channel": channel})
+}
+
+func (s *mockVendorAPIServer) setDefaultChannel(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	channel, ok := s.channels[r.PathValue("channelID")]
+	if !ok || channel.AppId != r.PathValue("appID") || channel.IsArchived {
+		writeMockJSON(w, http.StatusNotFound, map[string]string{"message": "channel not found"})
+		return
+	}
+
+	for _, other := range s.channels {
+		if other.AppId == channel.AppId {
+			other.IsDefault = false
+		}
+	}
+	channel.IsDefault = true
+
+	writeMockJSON(w, http.StatusOK, map[string]string{})
+}
+
+func (s *mockVendorAPIServer) archiveChannel(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	channel, ok := s.channels[r.PathValue("channelID")]
+	if !ok || channel.AppId != r.PathValue("appID") {
+		writeMockJSON(w, http.StatusNotFound, map[string]string{"message": "channel not found"})
+		return
+	}
+	if channel.IsDefault {
+		writeMockJSON(w, http.StatusBadRequest, map[string]string{"message": "the default channel cannot be archived"})
+		return
+	}
+
+	channel.IsArchived = true
+	writeMockJSON(w, http.StatusOK, map[string]string{})
+}
+
+func (s *mockVendorAPIServer) createRelease(w http.ResponseWriter, r *http.Request) {
+	var req rtypes.KotsCreateReleaseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeMockJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(req.SpecGzip))
+	if err != nil {
+		writeMockJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return
+	}
+	spec, err := io.ReadAll(gz)
+	if err != nil {
+		writeMockJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	appID := r.PathValue("appID")
+	release := &rtypes.KotsAppRelease{
+		AppID:     appID,
+		Sequence:  int64(len(s.releases[appID]) + 1),
+		CreatedAt: time.Now().UTC(),
+		Spec:      string(spec),
+	}
+	s.releases[appID] = append(s.releases[appID], release)
+
+	writeMockJSON(w, http.StatusCreated, map[string]interface{}{"release": release})
+}
+
+func (s *mockVendorAPIServer) getRelease(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	releases := s.releases[r.PathValue("appID")]
+	sequence, err := strconv.ParseInt(r.PathValue("sequence"), 10, 64)
+	if err != nil || sequence < 1 || sequence > int64(len(releases)) {
+		writeMockJSON(w, http.StatusNotFound, map[string]string{"message": "release not found"})
+		return
+	}
+
+	writeMockJSON(w, http.StatusOK, map[string]interface{}{"release": releases[sequence-1]})
+}
+
+func (s *mockVendorAPIServer) promoteRelease(w http.ResponseWriter, r *http.Request) {
+	var req rtypes.KotsPromoteReleaseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeMockJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	appID := r.PathValue("appID")
+	sequence, err := strconv.ParseInt(r.PathValue("sequence"), 10, 64)
+	if err != nil || sequence < 1 || sequence > int64(len(s.releases[appID])) {
+		writeMockJSON(w, http.StatusNotFound, map[string]string{"message": "release not found"})
+		return
+	}
+	for _, channelID := range req.ChannelIDs {
+		channel, ok := s.channels[channelID]
+		if !ok || channel.AppId != appID || channel.IsArchived {
+			writeMockJSON(w, http.StatusBadRequest, map[string]string{"message": "channel not found"})
+			return
+		}
+	}
+
+	for _, channelID := range req.ChannelIDs {
+		s.channelReleases[channelID] = append(s.channelReleases[channelID], &AppChannelRelease{
+			ChannelSequence: int64(len(s.channelReleases[channelID])),
+			Sequence:        sequence,
+			Semver:          req.VersionLabel,
+			ReleaseNotes:    req.ReleaseNotes,
+			Created:         time.Now().UTC(),
+			IsRequired:      req.IsRequired,
+		})
+		s.channels[channelID].ReleaseSequence = int32(sequence)
+		s.channels[channelID].CurrentVersion = req.VersionLabel
+	}
+
+	writeMockJSON(w, http.StatusOK, map[string]string{})
+}
+
+func (s *mockVendorAPIServer) listChannelReleases(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	channel, ok := s.channels[r.PathValue("channelID")]
+	if !ok || channel.AppId != r.PathValue("appID") {
+		writeMockJSON(w, http.StatusNotFound, map[string]string{"message": "channel not found"})
+		return
+	}
+
+	releases := []AppChannelRelease{}
+	for _, release := range s.channelReleases[channel.Id] {
+		releases = append(releases, *release)
+		// airgap builds complete after being listed as building once
+		if release.AirgapBuildStatus == "building" {
+			release.AirgapBuildStatus = "built"
+		}
+	}
+
+	writeMockJSON(w, http.StatusOK, map[string]interface{}{"releases": releases})
+}
+
+func (s *mockVendorAPIServer) demoteChannelRelease(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	channel, ok := s.channels[r.PathValue("channelID")]
+	if !ok || channel.AppId != r.PathValue("appID") {
+		writeMockJSON(w, http.StatusNotFound, map[string]string{"message": "channel not found"})
+		return
+	}
+	releases := s.channelReleases[channel.Id]
+	channelSequence, err := strconv.ParseInt(r.PathValue("channelSequence"), 10, 64)
+	if err != nil || channelSequence < 0 || channelSequence >= int64(len(releases)) {
+		writeMockJSON(w, http.StatusNotFound, map[string]string{"message": "channel release not found"})
+		return
+	}
+
+	releases[channelSequence].IsDemoted = true
+	writeMockJSON(w, http.StatusOK, map[string]string{})
+}
+
+// lintRelease stands in for the release linter. It reports yaml files that do
+// not parse as errors, and documents without an apiVersion or a kind as
+// warnings.
+func (s *mockVendorAPIServer) lintRelease(w http.ResponseWriter, r *http.Request) {
+	messages := []rtypes.LintMessage{}
+
+	tr := tar.NewReader(r.Body)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			writeMockJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+			return
+		}
+		// paths are reported relative to the top level directory
+		_, name, _ := strings.Cut(header.Name, "/")
+		if ext := path.Ext(name); ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			writeMockJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+			return
+		}
+
+		var doc map[string]interface{}
+		if err := yaml.Unmarshal(content, &doc); err != nil {
+			messages = append(messages, rtypes.LintMessage{Rule: "invalid-yaml", Type: "error", Path: name, Message: err.Error()})
+			continue
+		}
+		for _, field := range []string{"apiVersion", "kind"} {
+			if _, ok := doc[field]; !ok {
+				messages = append(messages, rtypes.LintMessage{
+					Rule:      fmt.Sprintf("missing-%s-field", strings.ToLower(field)),
+					Type:      "warn",
+					Path:      name,
+					Message:   fmt.Sprintf("Missing %s field", field),
+					Positions: []*rtypes.LintPosition{{Path: name, Start: rtypes.LintLinePosition{Line: 1}}},
+				})
+			}
+		}
+	}
+
+	writeMockJSON(w, http.StatusOK, map[string]interface{}{"lintExpressions": messages})
+}
+
+func (s *mockVendorAPIServer) buildAirgapRelease(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	release := s.channelRelease(r.PathValue("appID"), r.PathValue("channelID"), r.PathValue("channelSequence"))
+	if release == nil {
+		writeMockJSON(w, http.StatusNotFound, map[string]string{"message": "channel release not found"})
+		return
+	}
+
+	release.AirgapBuildStatus = "building"
+	writeMockJSON(w, http.StatusOK, map[string]string{})
+}
+
+// channelRelease returns the promotion of the channel with the channel
+// sequence, or nil when there is none. The caller must hold s.mu.
+func (s *mockVendorAPIServer) channelRelease(appID string, channelID string, channelSequence string) *AppChannelRelease {
+	channel, ok := s.channels[channelID]
+	if !ok || channel.AppId != appID {
+		return nil
+	}
+	releases := s.channelReleases[channel.Id]
+	i, err := strconv.ParseInt(channelSequence, 10, 64)
+	if err != nil || i < 0 || i >= int64(len(releases)) {
+		return nil
+	}
+
+	return releases[i]
+}
+
+func (s *mockVendorAPIServer) createCluster(w http.ResponseWriter, r *http.Request) {
+	var req kotsclient.CreateClusterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeMockJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return
+	}
+
+	if req.KubernetesDistribution == "" {
+		writeMockJSON(w, http.StatusBadRequest, kotsclient.CreateClusterErrorResponse{
+			Error: kotsclient.CreateClusterErrorError{Message: "kubernetes distribution is required"},
+		})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cluster := &rtypes.Cluster{
+		ID:                     s.id(),
+		Name:                   req.Name,
+		KubernetesDistribution: req.KubernetesDistribution,
+		KubernetesVersion:      req.KubernetesVersion,
+		Status:                 rtypes.ClusterStatusRunning,
+		CreatedAt:              time.Now().UTC(),
+		TTL:                    req.TTL,
+		Tags:                   req.Tags,
+		NodeGroups: []*rtypes.NodeGroup{
+			{
+				ID:           s.id(),
+				IsDefault:    true,
+				InstanceType: req.InstanceType,
+				NodeCount:    req.NodeCount,
+				DiskGiB:      req.DiskGiB,
+			},
+		},
+	}
+	if cluster.Name == "" {
+		cluster.Name = "mock-cluster-" + cluster.ID[len(cluster.ID)-4:]
+	}
+	if cluster.KubernetesVersion == "" {
+		cluster.KubernetesVersion = "1.30.0"
+	}
+	if cluster.NodeGroups[0].InstanceType == "" {
+		cluster.NodeGroups[0].InstanceType = "r1.small"
+	}
+	if cluster.NodeGroups[0].NodeCount == 0 {
+		cluster.NodeGroups[0].NodeCount = 1
+	}
+	if cluster.NodeGroups[0].DiskGiB == 0 {
+		cluster.NodeGroups[0].DiskGiB = 50
+	}
+	s.clusters[cluster.ID] = cluster
+
+	writeMockJSON(w, http.StatusCreated, kotsclient.CreateClusterResponse{Cluster: cluster})
+}
+
+func (s *mockVendorAPIServer) listClusters(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clusters := []*rtypes.Cluster{}
+	if r.URL.Query().Get("currentPage") == "" || r.URL.Query().Get("currentPage") == "0" {
+		for _, cluster := range s.clusters {
+			clusters = append(clusters, cluster)
+		}
+	}
+
+	writeMockJSON(w, http.StatusOK, kotsclient.ListClustersResponse{Clusters: clusters, TotalClusters: len(s.clusters)})
+}
+
+func (s *mockVendorAPIServer) getCluster(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cluster, ok := s.clusters[r.PathValue("id")]
+	if !ok {
+		writeMockJSON(w, http.StatusNotFound, map[string]string{"message": "cluster not found"})
+		return
+	}
+
+	writeMockJSON(w, http.StatusOK, kotsclient.GetClusterResponse{Cluster: cluster})
+}
+
+func (s *mockVendorAPIServer) getClusterKubeconfig(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cluster, ok := s.clusters[r.PathValue("id")]
+	if !ok {
+		writeMockJSON(w, http.StatusNotFound, map[string]string{"message": "cluster not found"})
+		return
+	}
+
+	kubeconfig := fmt.Sprintf("apiVersion: v1\nkind: Config\ncurrent-context: %s\n", cluster.Name)
+	writeMockJSON(w, http.StatusOK, kotsclient.GetClusterKubeconfigResponse{Kubeconfig: []byte(kubeconfig)})
+}
+
+func (s *mockVendorAPIServer) removeCluster(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.clusters[r.PathValue("id")]; !ok {
+		writeMockJSON(w, http.StatusNotFound, map[string]string{"message": "cluster not found"})
+		return
+	}
+	delete(s.clusters, r.PathValue("id"))
+
+	writeMockJSON(w, http.StatusOK, kotsclient.RemoveClusterResponse{})
+}
+
+func (s *mockVendorAPIServer) createCustomer(w http.ResponseWriter, r *http.Request) {
+	var req createCustomerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeMockJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	customer := rtypes.Customer{
+		ID:                               s.id(),
+		InstallationID:                   s.id(),
+		Name:                             req.Name,
+		CustomID:                         req.CustomID,
+		Email:                            req.Email,
+		Type:                             req.Type,
+		IsAirgapEnabled:                  req.IsAirgapEnabled,
+		IsEmbeddedClusterDownloadEnabled: req.IsEmbeddedClusterDownloadEnabled,
+		IsGeoaxisSupported:               req.IsGeoaxisSupported,
+		IsHelmVMDownloadEnabled:          req.IsHelmVMDownloadEnabled,
+		IsIdentityServiceSupported:       req.IsIdentityServiceSupported,
+		IsInstallerSupportEnabled:        req.IsInstallerSupportEnabled,
+		IsKotsInstallEnabled:             req.IsKotsInstallEnabled,
+		IsSnapshotSupported:              req.IsSnapshotSupported,
+		IsSupportBundleUploadEnabled:     req.IsSupportBundleUploadEnabled,
+		IsGitopsSupported:                req.IsGitopsSupported,
+	}
+	if msg := s.applyCustomerValues(&customer, req.AppID, req.Channels, req.EntitlementValues, req.ExpiresAt); msg != "" {
+		writeMockJSON(w, http.StatusBadRequest, map[string]string{"message": msg})
+		return
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	s.customers[customer.ID] = &mockCustomer{appID: req.AppID, customer: customer, channels: customerDetailsFromOpts(req.Channels, req.CustomerLicenseOptions).Channels, options: req.CustomerLicenseOptions, createdAt: now, updatedAt: now}
+
+	writeMockJSON(w, http.StatusCreated, kotsclient.CreateCustomerResponse{Customer: &customer})
+}
+
+func (s *mockVendorAPIServer) listCustomers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	includeArchived := r.URL.Query().Get("includeArchived") == "true"
+	includeTest := r.URL.Query().Get("includeTest") == "true"
+
+	all := []mockCustomerJSON{}
+	for _, c := range s.customers {
+		if c.appID != r.PathValue("appID") || (c.archived && !includeArchived) || (c.customer.Type == "test" && !includeTest) {
+			continue
+		}
+		all = append(all, c.json())
+	}
+
+	// serve everything on the first page, the client stops paging once it
+	// has seen totalCustomers customers
+	customers := []mockCustomerJSON{}
+	if page, _ := strconv.Atoi(r.URL.Query().Get("currentPage")); page == 0 {
+		customers = all
+	}
+
+	writeMockJSON(w, http.StatusOK, map[string]interface{}{"customers": customers, "totalCustomers": len(all)})
+}
+
+func (s *mockVendorAPIServer) updateCustomer(w http.ResponseWriter, r *http.Request) {
+	var req updateCustomerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeMockJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.customers[r.PathValue("id")]
+	if !ok || c.archived {
+		writeMockJSON(w, http.StatusNotFound, map[string]string{"message": "customer not found"})
+		return
+	}
+
+	customer := c.customer
+	customer.Name = req.Name
+	customer.CustomID = req.CustomID
+	customer.Email = req.Email
+	customer.Type = req.Type
+	customer.IsAirgapEnabled = req.IsAirgapEnabled
+	customer.IsEmbeddedClusterDownloadEnabled = req.IsEmbeddedClusterDownloadEnabled
+	customer.IsGeoaxisSupported = req.IsGeoaxisSupported
+	customer.IsHelmVMDownloadEnabled = req.IsHelmVMDownloadEnabled
+	customer.IsIdentityServiceSupported = req.IsIdentityServiceSupported
+	customer.IsInstallerSupportEnabled = req.IsInstallerSupportEnabled
+	customer.IsKotsInstallEnabled = req.IsKotsInstallEnabled
+	customer.IsSnapshotSupported = req.IsSnapshotSupported
+	customer.IsSupportBundleUploadEnabled = req.IsSupportBundleUploadEnabled
+	customer.IsGitopsSupported = req.IsGitopsSupported
+	if msg := s.applyCustomerValues(&customer, req.AppID, req.Channels, req.EntitlementValues, req.ExpiresAt); msg != "" {
+		writeMockJSON(w, http.StatusBadRequest, map[string]string{"message": msg})
+		return
+	}
+	c.customer = customer
+	c.channels = customerDetailsFromOpts(req.Channels, req.CustomerLicenseOptions).Channels
+	c.options = req.CustomerLicenseOptions
+	c.updatedAt = time.Now().UTC().Truncate(time.Second)
+
+	writeMockJSON(w, http.StatusOK, kotsclient.UpdateCustomerResponse{Customer: &customer})
+}
+
+func (s *mockVendorAPIServer) getCustomer(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.customers[r.PathValue("id")]
+	if !ok {
+		writeMockJSON(w, http.StatusNotFound, map[string]string{"message": "customer not found"})
+		return
+	}
+
+	writeMockJSON(w, http.StatusOK, map[string]interface{}{"customer": c.json()})
+}
+
+func (s *mockVendorAPIServer) archiveCustomer(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.customers[r.PathValue("id")]
+	if !ok {
+		writeMockJSON(w, http.StatusNotFound, map[string]string{"message": "customer not found"})
+		return
+	}
+	c.archived = true
+	c.archivedAt = time.Now().UTC().Truncate(time.Second)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *mockVendorAPIServer) unarchiveCustomer(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.customers[r.PathValue("id")]
+	if !ok {
+		writeMockJSON(w, http.StatusNotFound, map[string]string{"message": "customer not found"})
+		return
+	}
+	c.archived = false
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *mockVendorAPIServer) downloadLicense(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.customers[r.PathValue("id")]
+	if !ok || c.archived || c.appID != r.PathValue("appID") {
+		writeMockJSON(w, http.StatusNotFound, map[string]string{"message": "customer not found"})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(testLicenseYAML(&c.customer))
+}
+
+func (s *mockVendorAPIServer) getAirgapDownloadURL(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	appID := r.PathValue("appID")
+	c, ok := s.customers[r.PathValue("id")]
+	if !ok || c.archived || c.appID != appID {
+		writeMockJSON(w, http.StatusNotFound, map[string]string{"message": "customer not found"})
+		return
+	}
+	if !c.customer.IsAirgapEnabled {
+		writeMockJSON(w, http.StatusBadRequest, map[string]string{"message": "airgap is not enabled for the customer"})
+		return
+	}
+	channelID := r.URL.Query().Get("channelId")
+	channelSequence := r.URL.Query().Get("channelSequence")
+	release := s.channelRelease(appID, channelID, channelSequence)
+	if release == nil || release.AirgapBuildStatus != "built" {
+		writeMockJSON(w, http.StatusNotFound, map[string]string{"message": "airgap bundle not found"})
+		return
+	}
+
+	downloadURL := fmt.Sprintf("%s/airgap/%s/%s/%s.airgap?customer=%s", s.URL, appID, channelID, channelSequence, c.customer.ID)
+	writeMockJSON(w, http.StatusOK, map[string]string{"url": downloadURL})
+}
+
+// applyCustomerValues validates and sets the values shared by customer create
+// and update requests. It returns an error message for invalid requests. The
+// caller must hold s.mu.
+func (s *mockVendorAPIServer) applyCustomerValues(customer *rtypes.Customer, appID string, channels []kotsclient.CustomerChannel, entitlementValues []kotsclient.EntitlementValue, expiresAt string) string {
+	if customer.Name == "" {
+		return "name is required"
+	}
+
+	found := false
+	for _, app := range s.apps {
+		if app.ID == appID {
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Sprintf("app %q not found", appID)
+	}
+
+	customer.Channels = nil
+	for _, cc := range channels {
+		channel, ok := s.channels[cc.ID]
+		if !ok || channel.AppId != appID {
+			return fmt.Sprintf("channel %q not found", cc.ID)
+		}
+		customer.Channels = append(customer.Channels, *channel.ToChannel())
+	}
+	if len(customer.Channels) == 0 {
+		return "at least one channel is required"
+	}
+
+	fields := map[string]LicenseField{}
+	for _, field := range s.licenseFields[appID] {
+		fields[field.Name] = field
+	}
+
+	customer.Entitlements = nil
+	set := map[string]bool{}
+	for _, value := range entitlementValues {
+		if _, ok := fields[value.Name]; !ok {
+			return fmt.Sprintf("license field %q not found", value.Name)
+		}
+		customer.Entitlements = append(customer.Entitlements, rtypes.Entitlement{Name: value.Name, Value: value.Value})
+		set[value.Name] = true
+	}
+	for _, field := range s.licenseFields[appID] {
+		if !set[field.Name] {
+			customer.Entitlements = append(customer.Entitlements, rtypes.Entitlement{Name: field.Name, Value: field.Default, IsDefault: true})
+		}
+	}
+
+	customer.Expires = nil
+	if expiresAt != "" {
+		expires, err := util.ParseTime(expiresAt)
+		if err != nil {
+			return fmt.Sprintf("invalid expires_at: %s", err)
+		}
+		customer.Expires = &util.Time{Time: expires}
+	}
+
+	return ""
+}
+
+func writeMockJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func TestMockVendorAPIServer(t *testing.T) {
+	server := newMockVendorAPIServer()
+	defer server.Close()
+
+	client := newVendorAPIClient(server.URL, "token", server.Client())
+
+	apps, err := client.ListApps(true)
+	require.NoError(t, err)
+	require.Len(t, apps, 1)
+	assert.Equal(t, testAccAppSlug, apps[0].App.Slug)
+
+	app, err := client.CreateKOTSApp("My App")
+	require.NoError(t, err)
+	assert.Equal(t, "my-app", app.Slug)
+	apps, err = client.ListApps(true)
+	require.NoError(t, err)
+	assert.Len(t, apps, 2)
+	require.NoError(t, client.DeleteKOTSApp(app.Id))
+	apps, err = client.ListApps(true)
+	require.NoError(t, err)
+	assert.Len(t, apps, 1)
+	assert.Error(t, client.DeleteKOTSApp(app.Id))
+
+	created, err := client.CreateChannel(testAccAppID, "Beta", "beta releases")
+	require.NoError(t, err)
+	channel, err := client.UpdateAppChannel(testAccAppID, created.ID, UpdateChannelOpts{
+		Name:           "Beta",
+		SemverRequired: true,
+	})
+	require.NoError(t, err)
+	assert.True(t, channel.SemverRequired)
+	assert.Empty(t, channel.Description)
+	require.NoError(t, client.SetDefaultChannel(testAccAppID, created.ID))
+	channel, err = client.GetAppChannel(testAccAppID, created.ID)
+	require.NoError(t, err)
+	assert.True(t, channel.IsDefault)
+	assert.Equal(t, "beta", channel.Slug)
+	channels, err := client.ListAppChannels(testAccAppID)
+	require.NoError(t, err)
+	assert.Len(t, channels, 2)
+	assert.Error(t, client.ArchiveChannel(testAccAppID, created.ID))
+	require.NoError(t, client.SetDefaultChannel(testAccAppID, testAccChannelID))
+	require.NoError(t, client.ArchiveChannel(testAccAppID, created.ID))
+	_, err = client.GetAppChannel(testAccAppID, "missing")
+	assert.ErrorIs(t, err, platformclient.ErrNotFound)
+
+	releaseInfo, err := client.CreateRelease(testAccAppID, `[{"name":"config.yaml","path":"config.yaml","content":"kind: Config"}]`)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), releaseInfo.Sequence)
+	release, err := client.GetRelease(testAccAppID, releaseInfo.Sequence)
+	require.NoError(t, err)
+	assert.Contains(t, release.Config, "kind: Config")
+	_, err = client.GetRelease(testAccAppID, 2)
+	assert.ErrorIs(t, err, platformclient.ErrNotFound)
+
+	require.NoError(t, client.PromoteRelease(testAccAppID, 1, "1.0.0", "first release", true, testAccChannelID))
+	assert.Error(t, client.PromoteRelease(testAccAppID, 2, "2.0.0", "", false, testAccChannelID))
+	channelReleases, err := client.ListChannelReleases(testAccAppID, testAccChannelID)
+	require.NoError(t, err)
+	require.Len(t, channelReleases, 1)
+	assert.Equal(t, int64(1), channelReleases[0].Sequence)
+	assert.Equal(t, "1.0.0", channelReleases[0].Semver)
+	assert.True(t, channelReleases[0].IsRequired)
+	require.NoError(t, client.DemoteChannelRelease(testAccAppID, testAccChannelID, channelReleases[0].ChannelSequence))
+	channelReleases, err = client.ListChannelReleases(testAccAppID, testAccChannelID)
+	require.NoError(t, err)
+	assert.True(t, channelReleases[0].IsDemoted)
+	assert.ErrorIs(t, client.DemoteChannelRelease(testAccAppID, testAccChannelID, 5), platformclient.ErrNotFound)
+	require.NoError(t, client.PromoteRelease(testAccAppID, 1, "1.0.1", "", false, testAccChannelID))
+	require.NoError(t, client.BuildAirgapRelease(testAccAppID, testAccChannelID, 1))
+	channelReleases, err = client.ListChannelReleases(testAccAppID, testAccChannelID)
+	require.NoError(t, err)
+	assert.Equal(t, "building", channelReleases[1].AirgapBuildStatus)
+	channelReleases, err = client.ListChannelReleases(testAccAppID, testAccChannelID)
+	require.NoError(t, err)
+	assert.Equal(t, "built", channelReleases[1].AirgapBuildStatus)
+	assert.ErrorIs(t, client.BuildAirgapRelease(testAccAppID, testAccChannelID, 5), platformclient.ErrNotFound)
+
+	t.Setenv("LINTER_API_ORIGIN", server.URL)
+	tarball, err := releaseTarball([]releaseFile{
+		{Path: "config.yaml", Content: []byte("apiVersion: kots.io/v1beta1\nkind: Config")},
+		{Path: "manifests/deployment.yaml", Content: []byte("kind: Deployment")},
+		{Path: "broken.yaml", Content: []byte("kind: [")},
+	})
+	require.NoError(t, err)
+	lintMessages, err := client.LintRelease(tarball, false, "application/tar")
+	require.NoError(t, err)
+	require.Len(t, lintMessages, 2)
+	assert.Equal(t, "manifests/deployment.yaml", lintMessages[0].Path)
+	assert.Equal(t, "warn", lintMessages[0].Type)
+	assert.Equal(t, "invalid-yaml", lintMessages[1].Rule)
+	server.injectError("POST", "/v1/lint", http.StatusInternalServerError, "linter unavailable", 1)
+	_, err = client.LintRelease(tarball, false, "application/tar")
+	assert.ErrorContains(t, err, "linter unavailable")
+
+	fields, err := client.ListLicenseFields(testAccAppID)
+	require.NoError(t, err)
+	assert.Len(t, fields, 2)
+
+	cluster, ve, err := client.CreateCluster(kotsclient.CreateClusterOpts{KubernetesDistribution: "kind"})
+	require.NoError(t, err)
+	require.Nil(t, ve)
+	got, err := client.GetCluster(cluster.ID)
+	require.NoError(t, err)
+	assert.Equal(t, rtypes.ClusterStatusRunning, got.Status)
+	kubeconfig, err := client.GetClusterKubeconfig(cluster.ID)
+	require.NoError(t, err)
+	assert.Contains(t, string(kubeconfig), got.Name)
+	require.NoError(t, client.RemoveCluster(cluster.ID))
+	_, err = client.GetCluster(cluster.ID)
+	assert.Equal(t, ErrClusterNotFound.Error(), err.Error())
+
+	_, ve, err = client.CreateCluster(kotsclient.CreateClusterOpts{})
+	require.NoError(t, err)
+	require.NotNil(t, ve)
+
+	customer, err := client.CreateCustomer(CreateCustomerOpts{
+		CreateCustomerOpts: kotsclient.CreateCustomerOpts{
+			Name:      "acme",
+			AppID:     testAccAppID,
+			Channels:  []kotsclient.CustomerChannel{{ID: testAccChannelID, IsDefault: true}},
+			ExpiresAt: "2030-01-30T15:04:05Z",
+		},
+		CustomerLicenseOptions: CustomerLicenseOptions{IsHelmInstallEnabled: true, IsKurlInstallEnabled: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "2030-01-30T15:04:05Z", customer.Expires.Format(time.RFC3339))
+	assert.Equal(t, testAccChannelID, customer.Channels[0].ID)
+
+	updated, err := client.UpdateCustomer(customer.ID, UpdateCustomerOpts{
+		UpdateCustomerOpts: kotsclient.UpdateCustomerOpts{
+			Name:     "acme-renamed",
+			AppID:    testAccAppID,
+			Channels: []kotsclient.CustomerChannel{{ID: testAccChannelID, IsDefault: true}},
+		},
+		CustomerLicenseOptions:    CustomerLicenseOptions{IsHelmInstallEnabled: true, IsDevModeEnabled: true},
+		IsInstallerSupportEnabled: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "acme-renamed", updated.Name)
+	assert.True(t, updated.IsInstallerSupportEnabled)
+	assert.Nil(t, updated.Expires)
+
+	found, err := client.GetCustomerByNameOrId(testAccAppID, "acme-renamed")
+	require.NoError(t, err)
+	assert.Equal(t, customer.ID, found.ID)
+
+	details, err := client.GetCustomerDetails(customer.ID)
+	require.NoError(t, err)
+	require.NotNil(t, details.CreatedAt)
+	require.NotNil(t, details.UpdatedAt)
+	assert.Equal(t, []CustomerChannelDetails{{ID: testAccChannelID, IsDefault: true}}, details.Channels)
+	// the update replaced the license options sent on create
+	assert.Equal(t, CustomerLicenseOptions{IsHelmInstallEnabled: true, IsDevModeEnabled: true}, details.licenseOptions())
+
+	license, err := client.DownloadLicense(testAccAppID, customer.ID)
+	require.NoError(t, err)
+	parsed, err := parseLicense(license)
+	require.NoError(t, err)
+	assert.Equal(t, customer.InstallationID, parsed.Spec.LicenseID)
+	assert.Equal(t, testAccChannelID, parsed.Spec.ChannelID)
+
+	_, err = client.GetAirgapDownloadURL(testAccAppID, customer.ID, testAccChannelID, 1)
+	assert.ErrorContains(t, err, "airgap is not enabled")
+	airgapCustomer, err := client.CreateCustomer(CreateCustomerOpts{
+		CreateCustomerOpts: kotsclient.CreateCustomerOpts{
+			Name:            "airgap",
+			AppID:           testAccAppID,
+			Channels:        []kotsclient.CustomerChannel{{ID: testAccChannelID, IsDefault: true}},
+			IsAirgapEnabled: true,
+		},
+	})
+	require.NoError(t, err)
+	downloadURL, err := client.GetAirgapDownloadURL(testAccAppID, airgapCustomer.ID, testAccChannelID, 1)
+	require.NoError(t, err)
+	assert.Contains(t, downloadURL, airgapCustomer.ID)
+	_, err = client.GetAirgapDownloadURL(testAccAppID, airgapCustomer.ID, testAccChannelID, 0)
+	assert.ErrorIs(t, err, platformclient.ErrNotFound)
+
+	testCustomer, err := client.CreateCustomer(CreateCustomerOpts{
+		CreateCustomerOpts: kotsclient.CreateCustomerOpts{
+			Name:        "qa",
+			AppID:       testAccAppID,
+			Channels:    []kotsclient.CustomerChannel{{ID: testAccChannelID, IsDefault: true}},
+			LicenseType: "test",
+		},
+	})
+	require.NoError(t, err)
+	found, err = client.GetCustomerByNameOrId(testAccAppID, "qa")
+	require.NoError(t, err)
+	assert.Equal(t, testCustomer.ID, found.ID)
+	customers, err := client.ListCustomers(testAccAppID, false)
+	require.NoError(t, err)
+	for _, c := range customers {
+		assert.NotEqual(t, testCustomer.ID, c.ID)
+	}
+
+	gotCustomer, appID, err := client.GetCustomer(customer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, customer.ID, gotCustomer.ID)
+	assert.Equal(t, testAccAppID, appID)
+	_, _, err = client.GetCustomer("missing")
+	assert.ErrorAs(t, err, &kotsclient.ErrCustomerNotFound{})
+
+	require.NoError(t, client.ArchiveCustomer(customer.ID))
+	_, err = client.GetCustomerByNameOrId(testAccAppID, customer.ID)
+	assert.ErrorAs(t, err, &kotsclient.ErrCustomerNotFound{})
+	_, _, err = client.GetCustomer(customer.ID)
+	assert.ErrorAs(t, err, &kotsclient.ErrCustomerNotFound{})
+
+	archived, err := client.ListArchivedCustomers(testAccAppID)
+	require.NoError(t, err)
+	require.Len(t, archived, 1)
+	assert.Equal(t, customer.ID, archived[0].ID)
+
+	listed, err := client.ListCustomersWithDetails(testAccAppID, true)
+	require.NoError(t, err)
+	require.Len(t, listed, 3)
+	for _, c := range listed {
+		assert.Equal(t, c.ID == customer.ID, c.Archived)
+		require.NotNil(t, c.Details.CreatedAt)
+		assert.Equal(t, []CustomerChannelDetails{{ID: testAccChannelID, IsDefault: true}}, c.Details.Channels)
+		if c.ID == customer.ID {
+			assert.Equal(t, CustomerLicenseOptions{IsHelmInstallEnabled: true, IsDevModeEnabled: true}, c.Details.licenseOptions())
+		}
+	}
+	listed, err = client.ListCustomersWithDetails(testAccAppID, false)
+	require.NoError(t, err)
+	assert.Len(t, listed, 2)
+
+	require.NoError(t, client.UnarchiveCustomer(customer.ID))
+	_, err = client.GetCustomerByNameOrId(testAccAppID, customer.ID)
+	assert.NoError(t, err)
+	archived, err = client.ListArchivedCustomers(testAccAppID)
+	require.NoError(t, err)
+	assert.Empty(t, archived)
+
+	server.injectError("POST", "/v3/customer", http.StatusInternalServerError, "database unavailable", 1)
+	_, err = client.CreateCustomer(CreateCustomerOpts{
+		CreateCustomerOpts: kotsclient.CreateCustomerOpts{
+			Name:     "acme",
+			AppID:    testAccAppID,
+			Channels: []kotsclient.CustomerChannel{{ID: testAccChannelID, IsDefault: true}},
+		},
+	})
+	assert.ErrorContains(t, err, "database unavailable")
+	_, err = client.CreateCustomer(CreateCustomerOpts{
+		CreateCustomerOpts: kotsclient.CreateCustomerOpts{
+			Name:     "acme",
+			AppID:    testAccAppID,
+			Channels: []kotsclient.CustomerChannel{{ID: testAccChannelID, IsDefault: true}},
+		},
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/replicatedhq/replicated/pkg/kotsclient"
+	rtypes "github.com/replicatedhq/replicated/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testAccVendorAPIClient returns a client for the vendor api the acceptance
+// tests run against, the mock server unless REPLICATED_API_TOKEN is set. The
+// tests using it exercise the endpoints vendorAPIClient implements itself
+// because kotsclient.VendorV3Client does not.
+func testAccVendorAPIClient(t *testing.T) *vendorAPIClient {
+	t.Helper()
+
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+	testAccVendorAPI(t)
+
+	apiOrigin := os.Getenv("REPLICATED_API_ORIGIN")
+	if apiOrigin == "" {
+		apiOrigin = "https://api.replicated.com/vendor"
+	}
+
+	return newVendorAPIClient(apiOrigin, os.Getenv("REPLICATED_API_TOKEN"), &http.Client{})
+}
+
+func TestAccVendorAPIClientCustomers(t *testing.T) {
+	client := testAccVendorAPIClient(t)
+	rName := acctest.RandomWithPrefix(testAccResourcePrefix)
+
+	fields, err := client.ListLicenseFields(testAccAppID)
+	require.NoError(t, err)
+	assert.NotEmpty(t, fields)
+
+	customer, err := client.CreateCustomer(CreateCustomerOpts{
+		CreateCustomerOpts: kotsclient.CreateCustomerOpts{
+			Name:     rName,
+			AppID:    testAccAppID,
+			Channels: []kotsclient.CustomerChannel{{ID: testAccChannelID, IsDefault: true}},
+		},
+		CustomerLicenseOptions: CustomerLicenseOptions{IsHelmInstallEnabled: true, IsKurlInstallEnabled: true},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.ArchiveCustomer(customer.ID) })
+
+	got, appID, err := client.GetCustomer(customer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, customer.ID, got.ID)
+	assert.Equal(t, testAccAppID, appID)
+
+	details, err := client.GetCustomerDetails(customer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, CustomerLicenseOptions{IsHelmInstallEnabled: true, IsKurlInstallEnabled: true}, details.licenseOptions())
+	assert.Equal(t, []CustomerChannelDetails{{ID: testAccChannelID, IsDefault: true}}, details.Channels)
+
+	updated, err := client.UpdateCustomer(customer.ID, UpdateCustomerOpts{
+		UpdateCustomerOpts: kotsclient.UpdateCustomerOpts{
+			Name:     rName + "-updated",
+			AppID:    testAccAppID,
+			Channels: []kotsclient.CustomerChannel{{ID: testAccChannelID, IsDefault: true}},
+		},
+		CustomerLicenseOptions:    CustomerLicenseOptions{IsDevModeEnabled: true},
+		IsInstallerSupportEnabled: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, rName+"-updated", updated.Name)
+	assert.True(t, updated.IsInstallerSupportEnabled)
+
+	details, err = client.GetCustomerDetails(customer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, CustomerLicenseOptions{IsDevModeEnabled: true}, details.licenseOptions())
+
+	found, err := client.GetCustomerByNameOrId(testAccAppID, rName+"-updated")
+	require.NoError(t, err)
+	assert.Equal(t, customer.ID, found.ID)
+
+	listed, err := client.ListCustomersWithDetails(testAccAppID, false)
+	require.NoError(t, err)
+	if c := testListedCustomer(listed, customer.ID); assert.NotNil(t, c) {
+		assert.Equal(t, rName+"-updated", c.Name)
+		assert.Equal(t, CustomerLicenseOptions{IsDevModeEnabled: true}, c.Details.licenseOptions())
+		assert.False(t, c.Archived)
+	}
+
+	require.NoError(t, client.ArchiveCustomer(customer.ID))
+	_, _, err = client.GetCustomer(customer.ID)
+	assert.ErrorAs(t, err, &kotsclient.ErrCustomerNotFound{})
+	archived, err := client.ListArchivedCustomers(testAccAppID)
+	require.NoError(t, err)
+	assert.Contains(t, testCustomerIDs(archived), customer.ID)
+	listed, err = client.ListCustomersWithDetails(testAccAppID, true)
+	require.NoError(t, err)
+	if c := testListedCustomer(listed, customer.ID); assert.NotNil(t, c) {
+		assert.True(t, c.Archived)
+	}
+
+	require.NoError(t, client.UnarchiveCustomer(customer.ID))
+	_, _, err = client.GetCustomer(customer.ID)
+	require.NoError(t, err)
+}
+
+func TestAccVendorAPIClientChannels(t *testing.T) {
+	client := testAccVendorAPIClient(t)
+	rName := acctest.RandomWithPrefix(testAccResourcePrefix)
+
+	created, err := client.CreateChannel(testAccAppID, rName, "")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.ArchiveChannel(testAccAppID, created.ID) })
+
+	channel, err := client.UpdateAppChannel(testAccAppID, created.ID, UpdateChannelOpts{
+		Name:           rName,
+		Description:    "acceptance test channel",
+		SemverRequired: true,
+	})
+	require.NoError(t, err)
+	assert.True(t, channel.SemverRequired)
+	assert.Equal(t, "acceptance test channel", channel.Description)
+
+	channels, err := client.ListAppChannels(testAccAppID)
+	require.NoError(t, err)
+	var listed bool
+	for _, c := range channels {
+		listed = listed || c.ID == created.ID
+	}
+	assert.True(t, listed, "channel %s is not listed", created.ID)
+
+	t.Cleanup(func() { _ = client.SetDefaultChannel(testAccAppID, testAccChannelID) })
+	require.NoError(t, client.SetDefaultChannel(testAccAppID, created.ID))
+	channel, err = client.GetAppChannel(testAccAppID, created.ID)
+	require.NoError(t, err)
+	assert.True(t, channel.IsDefault)
+	require.NoError(t, client.SetDefaultChannel(testAccAppID, testAccChannelID))
+
+	release, err := client.CreateRelease(testAccAppID, `[{"name":"config.yaml","path":"config.yaml","content":"kind: Config"}]`)
+	require.NoError(t, err)
+	require.NoError(t, client.PromoteRelease(testAccAppID, release.Sequence, "1.0.0", "", false, created.ID))
+
+	releases, err := client.ListChannelReleases(testAccAppID, created.ID)
+	require.NoError(t, err)
+	require.Len(t, releases, 1)
+	assert.Equal(t, release.Sequence, releases[0].Sequence)
+	assert.Equal(t, "1.0.0", releases[0].Semver)
+
+	customer, err := client.CreateCustomer(CreateCustomerOpts{
+		CreateCustomerOpts: kotsclient.CreateCustomerOpts{
+			Name:            rName,
+			AppID:           testAccAppID,
+			Channels:        []kotsclient.CustomerChannel{{ID: created.ID, IsDefault: true}},
+			IsAirgapEnabled: true,
+		},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.ArchiveCustomer(customer.ID) })
+
+	require.NoError(t, client.BuildAirgapRelease(testAccAppID, created.ID, releases[0].ChannelSequence))
+	built, err := waitForAirgapBuild(context.Background(), client, testAccAppID, created.ID, releases[0].ChannelSequence, 30*time.Minute, false)
+	require.NoError(t, err)
+	assert.Equal(t, "built", built.AirgapBuildStatus)
+	downloadURL, err := client.GetAirgapDownloadURL(testAccAppID, customer.ID, created.ID, releases[0].ChannelSequence)
+	require.NoError(t, err)
+	assert.NotEmpty(t, downloadURL)
+
+	require.NoError(t, client.DemoteChannelRelease(testAccAppID, created.ID, releases[0].ChannelSequence))
+	releases, err = client.ListChannelReleases(testAccAppID, created.ID)
+	require.NoError(t, err)
+	require.Len(t, releases, 1)
+	assert.True(t, releases[0].IsDemoted)
+}
+
+func TestAccVendorAPIClientLintRelease(t *testing.T) {
+	client := testAccVendorAPIClient(t)
+
+	tarball, err := releaseTarball([]releaseFile{
+		{Path: "config.yaml", Content: []byte("apiVersion: kots.io/v1beta1\nkind: Config")},
+		{Path: "broken.yaml", Content: []byte("kind: [")},
+	})
+	require.NoError(t, err)
+
+	messages, err := client.LintRelease(tarball, false, "application/tar")
+	require.NoError(t, err)
+	var rules []string
+	for _, m := range messages {
+		rules = append(rules, m.Rule)
+	}
+	assert.Contains(t, rules, "invalid-yaml")
+}
+
+// testListedCustomer returns the listed customer with the id, or nil.
+func testListedCustomer(customers []CustomerWithDetails, id string) *CustomerWithDetails {
+	for i := range customers {
+		if customers[i].ID == id {
+			return &customers[i]
+		}
+	}
+	return nil
+}
+
+// testCustomerIDs returns the ids of the customers.
+func testCustomerIDs(customers []rtypes.Customer) []string {
+	ids := []string{}
+	for _, c := range customers {
+		ids = append(ids, c.ID)
+	}
+	return ids
+}